@@ -0,0 +1,122 @@
+package bizday
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoBusinessDay は前後の営業日を探したが maxProjectionDays 日以内に見つからなかったことを表す
+// 定休日が 7 曜日すべてのカレンダーや、WithCustomRule で毎日を休業日にしたカレンダーで起きる
+var ErrNoBusinessDay = fmt.Errorf("%d 日以内に営業日がありません", maxProjectionDays)
+
+// NextBusinessDay は t の翌日以降で最初の営業日を返す (時刻は t のまま)
+func (c *Calendar) NextBusinessDay(t time.Time) (time.Time, error) {
+	return c.stepBusinessDay(t, 1)
+}
+
+// PrevBusinessDay は t の前日以前で最後の営業日を返す (時刻は t のまま)
+func (c *Calendar) PrevBusinessDay(t time.Time) (time.Time, error) {
+	return c.stepBusinessDay(t, -1)
+}
+
+// AddBusinessDays は t から n 営業日後の日付を返す (時刻は t のまま)
+// n が負なら n 営業日前、0 なら t をそのまま返す
+// t 自身は数えないので、t が休業日でも AddBusinessDays(t, 1) は t の翌日以降で最初の営業日になる
+// 途中で maxProjectionDays 日続けて営業日がなければ ErrNoBusinessDay を返す
+func (c *Calendar) AddBusinessDays(t time.Time, n int) (time.Time, error) {
+	dir := 1
+	if n < 0 {
+		dir, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		var err error
+		if t, err = c.stepBusinessDay(t, dir); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t, nil
+}
+
+// StartCountingFrom は営業日を数え始める日 (出発日を 1 営業日目に含めるか)
+type StartCountingFrom string
+
+const (
+	CountFromNext StartCountingFrom = "next" // 出発日は数えず、翌営業日を 1 営業日目とする (AddBusinessDays と同じ)
+	CountFromSame StartCountingFrom = "same" // 出発日が営業日ならその日を 1 営業日目とする (「受付日を含めて 5 営業日以内」など)
+)
+
+// ParseStartCountingFrom は next・same をパースする
+func ParseStartCountingFrom(s string) (StartCountingFrom, error) {
+	switch v := StartCountingFrom(strings.ToLower(strings.TrimSpace(s))); v {
+	case CountFromNext, CountFromSame:
+		return v, nil
+	}
+	return "", fmt.Errorf("数え始める日は next か same にしてください: %s", s)
+}
+
+// AddOptions は AddBusinessDaysWith の数え方
+type AddOptions struct {
+	// From は出発日の扱い (空なら CountFromNext)
+	From StartCountingFrom
+	// ZeroRoll は n が 0 で t が休業日のときのずらし方 (空なら t をそのまま返す)
+	ZeroRoll RollConvention
+}
+
+// AddBusinessDaysWith は opt の数え方で t から n 営業日後 (負なら前) の日付を返す (時刻は t のまま)
+//   - CountFromNext: AddBusinessDays と同じく t 自身は数えない
+//   - CountFromSame: t が営業日なら t が 1 営業日目 (負なら -1 営業日目) になるので、n が 1 か -1 なら t を返す。
+//     t が休業日なら t は数えようがないので CountFromNext と同じ
+//
+// n が 0 のときは、どちらの数え方でも t を opt.ZeroRoll でずらした日を返す (ZeroRoll が空ならずらさない)
+func (c *Calendar) AddBusinessDaysWith(t time.Time, n int, opt AddOptions) (time.Time, error) {
+	if n == 0 {
+		if opt.ZeroRoll == "" {
+			return t, nil
+		}
+		return c.Roll(t, opt.ZeroRoll)
+	}
+	if opt.From == CountFromSame && c.IsBusinessDay(t) {
+		if n > 0 {
+			n--
+		} else {
+			n++
+		}
+	}
+	return c.AddBusinessDays(t, n)
+}
+
+// stepBusinessDay は t から dir (1 か -1) の向きに 1 日ずつ進め、最初に見つかった営業日を返す
+// maxProjectionDays 日進めても営業日がなければ ErrNoBusinessDay を返す
+func (c *Calendar) stepBusinessDay(t time.Time, dir int) (time.Time, error) {
+	for i := 0; i < maxProjectionDays; i++ {
+		t = t.AddDate(0, 0, dir)
+		if c.IsBusinessDay(t) {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrNoBusinessDay
+}
+
+// BusinessDaysBetween は a から b までの営業日数を符号付きで返す (b が a より前なら負)
+// a から b へ向かって、出発日 a を含めず到着日 b を含めて数える
+// (a < b なら (a, b]、b < a なら [b, a) の営業日数) ので、BusinessDaysBetween(a, AddBusinessDays(a, n)) は n になる
+func (c *Calendar) BusinessDaysBetween(a, b time.Time) int {
+	from, to := BeginningOfDay(a), BeginningOfDay(b)
+	n := 0
+	switch {
+	case from.Before(to):
+		for d := from.AddDate(0, 0, 1); !d.After(to); d = d.AddDate(0, 0, 1) {
+			if c.IsBusinessDay(d) {
+				n++
+			}
+		}
+	case to.Before(from):
+		for d := to; d.Before(from); d = d.AddDate(0, 0, 1) {
+			if c.IsBusinessDay(d) {
+				n--
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package bizday
+
+import (
+	"math/bits"
+	"time"
+)
+
+// yearBits は 1 年分の営業日のビットマップ (ビット i が 1 月 1 日から i 日目の日)
+type yearBits [6]uint64
+
+// bitmapCache は WithBitmapCache のカレンダーが年ごとに算出した営業日のビットマップ
+type bitmapCache = yearMap[*yearBits]
+
+// WithBitmapCache は営業日を年ごとに 366 ビットのビットマップにして覚えておく Calendar を返す
+// ビットマップは年を初めて引いたときに作るので、IsBusinessDay は 2 回目以降ビットを 1 つ読むだけになり、
+// CountBusinessDays も年ごとのビット数え上げで済む。serve のように同じカレンダーに何度も問い合わせるときに使う
+// 作成後に Weekend などのフィールドを書き換えるとビットマップと食い違うので、書き換えたら作り直すこと
+func (c *Calendar) WithBitmapCache() *Calendar {
+	n := *c
+	n.bitmaps = &bitmapCache{}
+	return &n
+}
+
+// yearBitmap は year 年のビットマップを返す (なければ作る)
+// 営業日かどうかは日付だけで決まるので、夏時間の影響を受けないよう UTC の日付で作る
+// 取得元 (NewProviderCalendar) から祝日を取得できなかった年は、取得し直せるよう作ったビットマップを覚えない
+func (c *Calendar) yearBitmap(year int) *yearBits {
+	if b, ok := c.bitmaps.load(year); ok {
+		return b
+	}
+
+	b := &yearBits{}
+	i := 0
+	for d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d, i = d.AddDate(0, 0, 1), i+1 {
+		if c.isBusinessDay(d) {
+			b[i/64] |= 1 << (i % 64)
+		}
+	}
+	if c.providersComplete(year) {
+		c.bitmaps.store(year, b)
+	}
+	return b
+}
+
+// providersComplete は year 年の祝日を取得元からすべて取得できているかを返す (取得元のないカレンダーは true)
+func (c *Calendar) providersComplete(year int) bool {
+	if c.providers != nil && !c.providers.complete(year) {
+		return false
+	}
+	for _, m := range c.members {
+		if !m.providersComplete(year) {
+			return false
+		}
+	}
+	return true
+}
+
+// cachedBusinessDay はビットマップで t の日付が営業日かを判定する
+func (c *Calendar) cachedBusinessDay(t time.Time) bool {
+	y, m, d := t.Date()
+	i := int(civilDay(y, m, d) - civilDay(y, time.January, 1))
+	return c.yearBitmap(y)[i/64]&(1<<(i%64)) != 0
+}
+
+// cachedCount はビットマップで start~end (両端含む、日付のみ) の営業日数を数える
+func (c *Calendar) cachedCount(start, end time.Time) int {
+	count := 0
+	for year := start.Year(); year <= end.Year(); year++ {
+		from, to := 0, 365
+		if year == start.Year() {
+			from = start.YearDay() - 1
+		}
+		if year == end.Year() {
+			to = end.YearDay() - 1
+		}
+		count += c.yearBitmap(year).count(from, to)
+	}
+	return count
+}
+
+// count はビット from~to (両端含む) のうち立っているものを数える
+func (b *yearBits) count(from, to int) int {
+	n := 0
+	for w := from / 64; w <= to/64; w++ {
+		word := b[w]
+		if w == from/64 {
+			word &= ^uint64(0) << (from % 64)
+		}
+		if w == to/64 && to%64 < 63 {
+			word &= 1<<(to%64+1) - 1
+		}
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
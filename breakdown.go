@@ -0,0 +1,77 @@
+package bizday
+
+import (
+	"errors"
+	"time"
+)
+
+// RangeBreakdown は期間内の日数を除外理由ごとに分けたもの
+type RangeBreakdown struct {
+	TotalDays    int // 暦日数
+	WeekendDays  int // 定休日 (既定では土日)
+	Holidays     int // 平日に当たる祝日
+	Closures     int // 平日に当たる会社独自の休業日 (WithExtra・WithClosureRules・WithCustomRule で重ねたもの、祝日と重なる日は祝日として数える)
+	Workdays     int // 営業日に含まれる振替出勤日
+	BusinessDays int // 営業日
+}
+
+// Breakdown は start~end (両端含む) の日数を定休日・祝日・営業日に分けて数える
+// 定休日に重なる祝日は定休日として数え、振替出勤日は定休日・祝日から差し戻す分として数える
+// CountBusinessDays と同じく start・end の時刻は無視する
+func (c *Calendar) Breakdown(start, end time.Time) (RangeBreakdown, error) {
+	start, end = BeginningOfDay(start), BeginningOfDay(end)
+	if end.Before(start) {
+		return RangeBreakdown{}, errors.New("end は start より後の日付を指定してください")
+	}
+
+	var b RangeBreakdown
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		b.TotalDays++
+		if class, ok := c.customClass(d); ok {
+			b.addCustom(c, d, class)
+			continue
+		}
+		if class, ok := c.memberClass(d); ok {
+			b.addCustom(c, d, class)
+			continue
+		}
+		weekend := c.IsWeekend(d)
+		holiday := !weekend && c.IsHoliday(d)
+		switch {
+		case weekend:
+			b.WeekendDays++
+		case holiday && c.isClosure(d):
+			b.Closures++
+		case holiday:
+			b.Holidays++
+		}
+		switch {
+		case c.IsWorkday(d) && (weekend || holiday):
+			b.Workdays++
+			b.BusinessDays++
+		case !weekend && !holiday:
+			b.BusinessDays++
+		}
+	}
+	return b, nil
+}
+
+// addCustom は WithCustomRule の関数 (組み合わせたカレンダーでは元のカレンダーのもの) が d を class とした日を b に数える
+// ClassHoliday とした日は、元の祝日なら祝日、そうでなければ会社独自の休業日として数える
+func (b *RangeBreakdown) addCustom(c *Calendar, d time.Time, class DayClass) {
+	switch class {
+	case ClassWeekend:
+		b.WeekendDays++
+	case ClassHoliday:
+		if _, ok := c.baseHolidayOn(d); ok {
+			b.Holidays++
+		} else {
+			b.Closures++
+		}
+	case ClassWorkday:
+		b.Workdays++
+		b.BusinessDays++
+	default:
+		b.BusinessDays++
+	}
+}
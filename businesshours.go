@@ -0,0 +1,72 @@
+package bizday
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AddBusinessHours は t から営業時間 (休憩を除く) だけを数えて d 進めた日時を返す
+// 休業日と営業時間外は飛ばすので、金曜 17:00 に 2 時間足すと次の営業日の 10:00 になる
+// d が負なら営業時間をさかのぼり、0 なら t をそのまま返す
+func (c *Calendar) AddBusinessHours(t time.Time, d time.Duration) (time.Time, error) {
+	if d >= 0 {
+		return c.ProjectCompletion(t, d)
+	}
+	if c.Hours.Duration() <= 0 {
+		return time.Time{}, errors.New("営業時間が設定されていません")
+	}
+
+	remaining := -d
+	day := BeginningOfDay(t)
+	segs := c.Hours.segments()
+	for i := 0; i < maxProjectionDays; i, day = i+1, day.AddDate(0, 0, -1) {
+		if !c.IsBusinessDay(day) {
+			continue
+		}
+		y, m, dd := day.Date()
+		for j := len(segs) - 1; j >= 0; j-- {
+			s := time.Date(y, m, dd, 0, 0, 0, int(segs[j][0]), day.Location())
+			e := earlier(time.Date(y, m, dd, 0, 0, 0, int(segs[j][1]), day.Location()), t)
+			if !s.Before(e) {
+				continue
+			}
+			avail := e.Sub(s)
+			if remaining <= avail {
+				return e.Add(-remaining), nil
+			}
+			remaining -= avail
+		}
+	}
+	return time.Time{}, fmt.Errorf("%d 日以内にさかのぼれません", maxProjectionDays)
+}
+
+// BusinessHoursBetween は a から b までに含まれる営業時間 (休憩を除く) を符号付きで返す (b が a より前なら負)
+// AddBusinessHours(a, d) が b なら BusinessHoursBetween(a, b) は d になる
+func (c *Calendar) BusinessHoursBetween(a, b time.Time) time.Duration {
+	if b.Before(a) {
+		return -c.WorkedDuration(b, a)
+	}
+	return c.WorkedDuration(a, b)
+}
+
+// NextOpen は t 以降で最初に営業時間内になる日時を返す (t が営業時間内なら t そのもの)
+func (c *Calendar) NextOpen(t time.Time) (time.Time, error) {
+	if c.Hours.Duration() <= 0 {
+		return time.Time{}, errors.New("営業時間が設定されていません")
+	}
+	day := BeginningOfDay(t)
+	for i := 0; i < maxProjectionDays; i, day = i+1, day.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(day) {
+			continue
+		}
+		y, m, dd := day.Date()
+		for _, seg := range c.Hours.segments() {
+			s := later(time.Date(y, m, dd, 0, 0, 0, int(seg[0]), day.Location()), t)
+			if s.Before(time.Date(y, m, dd, 0, 0, 0, int(seg[1]), day.Location())) {
+				return s, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%d 日以内に営業時間がありません", maxProjectionDays)
+}
@@ -0,0 +1,483 @@
+package bizday
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar は祝日一覧と営業時間をまとめた営業日カレンダー
+// 祝日一覧と振替出勤日は作成時に epochDay の集合にしてしまうので、作成に使ったスライスを後で書き換えても影響しない
+type Calendar struct {
+	Hours WorkHours
+	// Weekend は定休日とする曜日 (nil なら土日)
+	Weekend []time.Weekday
+	// Generate は年ごとに祝日を算出する規則 (nil なら一覧の祝日のみを使う)
+	// NewRuleCalendar で作ると算出結果が年ごとにキャッシュされる
+	Generate func(year int) []Holiday
+
+	// names は一覧由来の祝日の名前 (キーは epochDay)
+	names map[int32]string
+	// holidays は一覧由来の祝日、workdays は土日や祝日でも営業日として扱う日 (中国の調休などの振替出勤日)
+	holidays, workdays daySet
+	// genCache は Generate の結果の年ごとのキャッシュ (nil ならキャッシュしない)
+	genCache *yearCache
+	// entries は有効期間付きの元データ (AsOf で別時点のカレンダーを作り直すために保持)
+	entries []HolidayEntry
+	// extra は WithExtra で重ねた会社独自の休業日・振替出勤日 (AsOf で作り直しても引き継ぐ)
+	extra []HolidayEntry
+	// closures は extra の休業日のうち、元の祝日と重ならない日 (Breakdown で祝日と分けて数える)
+	closures map[int32]struct{}
+	// closureRules は WithClosureRules で重ねた繰り返しの休業日の規則
+	closureRules []ClosureRule
+	// customRules は WithCustomRule で重ねた日の分類を上書きする関数
+	customRules []func(time.Time) DayClass
+	// members は NewCombinedCalendar で組み合わせた元のカレンダー (営業日の判定と UncoveredYears で使う)
+	members []*Calendar
+	// providers は NewProviderCalendar の取得元 (Generate はこの結果を返す)
+	providers *providerSet
+	// bitmaps は WithBitmapCache で有効にした営業日のビットマップ (nil なら使わない)
+	bitmaps *bitmapCache
+	// asOf は entries・extra のどの時点の内容を使っているか (ゼロ値なら作成時の現在時刻)
+	asOf time.Time
+}
+
+// Holiday は名前付きの祝日
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayEntry は有効期間付きの祝日定義
+// ValidFrom/ValidTo はカレンダーにその祝日が載っていた期間で、ゼロ値なら期限なし
+type HolidayEntry struct {
+	Date      time.Time
+	Name      string
+	ValidFrom time.Time
+	ValidTo   time.Time
+	// Workday が true なら休日ではなく振替出勤日 (営業日として扱う日) の定義
+	Workday bool
+}
+
+// validAt は asOf 時点のカレンダーにこの祝日が含まれるかどうかを判定
+func (e HolidayEntry) validAt(asOf time.Time) bool {
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	if !e.ValidFrom.IsZero() && day.Before(e.ValidFrom) {
+		return false
+	}
+	if !e.ValidTo.IsZero() && day.After(e.ValidTo) {
+		return false
+	}
+	return true
+}
+
+// WorkHours は 1 日の営業時間帯を 0:00 からの経過時間で表す
+type WorkHours struct {
+	Start      time.Duration // 始業
+	End        time.Duration // 終業
+	BreakStart time.Duration // 休憩開始 (休憩なしなら BreakEnd と同じ値)
+	BreakEnd   time.Duration // 休憩終了
+}
+
+// DefaultWorkHours は 9:00~18:00 (12:00~13:00 休憩) の 8 時間勤務
+var DefaultWorkHours = WorkHours{
+	Start:      9 * time.Hour,
+	End:        18 * time.Hour,
+	BreakStart: 12 * time.Hour,
+	BreakEnd:   13 * time.Hour,
+}
+
+// ParseWorkHours は "9:00-18:00" の形式の営業時間帯と、同じ形式の休憩時間帯 (空なら休憩なし) から WorkHours を作る
+func ParseWorkHours(hours, breakTime string) (WorkHours, error) {
+	var h WorkHours
+	var err error
+	if h.Start, h.End, err = parseClockRange(hours); err != nil {
+		return WorkHours{}, err
+	}
+	h.BreakStart, h.BreakEnd = h.Start, h.Start
+	if breakTime != "" {
+		if h.BreakStart, h.BreakEnd, err = parseClockRange(breakTime); err != nil {
+			return WorkHours{}, err
+		}
+		if h.BreakStart < h.Start || h.BreakEnd > h.End {
+			return WorkHours{}, fmt.Errorf("休憩時間は営業時間の中に指定してください: %s", breakTime)
+		}
+	}
+	return h, nil
+}
+
+// parseClockRange は "9:00-18:00" を 0:00 からの経過時間の組にする
+func parseClockRange(s string) (from, to time.Duration, err error) {
+	a, b, ok := strings.Cut(s, "-")
+	if ok {
+		from, err = parseClock(strings.TrimSpace(a))
+	}
+	if ok && err == nil {
+		to, err = parseClock(strings.TrimSpace(b))
+	}
+	if !ok || err != nil || to <= from {
+		return 0, 0, fmt.Errorf("時間帯は 9:00-18:00 の形式で指定してください: %s", s)
+	}
+	return from, to, nil
+}
+
+// parseClock は "9:00" を 0:00 からの経過時間にする
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// WithHours は営業時間を h に差し替えた Calendar を返す
+func (c *Calendar) WithHours(h WorkHours) *Calendar {
+	n := *c
+	n.Hours = h
+	return &n
+}
+
+// NewCalendar は祝日一覧から既定の営業時間を持つ Calendar を作る
+func NewCalendar(holidays []time.Time) *Calendar {
+	return &Calendar{holidays: newDaySet(holidays), Hours: DefaultWorkHours}
+}
+
+// NewCalendarAsOf は有効期間付きの祝日定義から、asOf 時点で有効な祝日を持つ Calendar を作る
+func NewCalendarAsOf(entries []HolidayEntry, asOf time.Time) *Calendar {
+	holidays, workdays := daySet{}, daySet{}
+	names := map[int32]string{}
+	for _, e := range entries {
+		switch day := epochDay(e.Date); {
+		case !e.validAt(asOf):
+		case e.Workday:
+			workdays[day] = struct{}{}
+		default:
+			holidays[day] = struct{}{}
+			if e.Name != "" {
+				names[day] = e.Name
+			}
+		}
+	}
+	return &Calendar{Hours: DefaultWorkHours, holidays: holidays, workdays: workdays, names: names, entries: entries, asOf: asOf}
+}
+
+// AsOf は asOf 時点で有効だった祝日に差し替えた Calendar を返す
+// 有効期間付きの元データを持たない Calendar はそのまま返す
+func (c *Calendar) AsOf(asOf time.Time) *Calendar {
+	if c.members != nil {
+		members := make([]*Calendar, len(c.members))
+		for i, m := range c.members {
+			members[i] = m.AsOf(asOf)
+		}
+		n := NewCombinedCalendar(members...)
+		n.Hours, n.Weekend, n.asOf = c.Hours, c.Weekend, asOf
+		n.closureRules, n.customRules = c.closureRules, c.customRules
+		return n.withExtra(c.extra)
+	}
+	if c.entries == nil && c.extra == nil {
+		return c
+	}
+	var n *Calendar
+	if c.entries != nil {
+		n = NewCalendarAsOf(c.entries, asOf)
+	} else {
+		n = &Calendar{holidays: c.holidays, workdays: c.workdays, names: c.names, asOf: asOf}
+	}
+	n.Hours = c.Hours
+	n.Weekend = c.Weekend
+	n.Generate = c.Generate
+	n.genCache = c.genCache
+	n.providers = c.providers
+	n.closureRules = c.closureRules
+	n.customRules = c.customRules
+	return n.withExtra(c.extra)
+}
+
+// IsBusinessDay は t の日付が営業日かどうかを判定
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if c.bitmaps != nil {
+		return c.cachedBusinessDay(t)
+	}
+	return c.isBusinessDay(t)
+}
+
+// isBusinessDay はビットマップを使わずに t の日付が営業日かどうかを判定する
+func (c *Calendar) isBusinessDay(t time.Time) bool {
+	y, m, d := t.Date()
+	return c.businessDay(y, m, d, civilDay(y, m, d), t.Location(), nil)
+}
+
+// businessDay は y 年 m 月 d 日 (epochDay は day) が営業日かどうかを判定する
+// 日付は分解したまま受け取り、time.Time は WithCustomRule の関数と繰り返しの休業日の規則を判定するときだけ loc で作る
+// gen は y 年の Generate の祝日 (nil なら必要になったときに引く)。期間を数えるときは年ごとに一度引いて渡す
+func (c *Calendar) businessDay(y int, m time.Month, d int, day int32, loc *time.Location, gen map[int32]string) bool {
+	// 呼び出し側の関数による上書き
+	if len(c.customRules) > 0 {
+		if class, ok := c.customClass(time.Date(y, m, d, 0, 0, 0, 0, loc)); ok {
+			return class == ClassBusiness || class == ClassWorkday
+		}
+	}
+	// 組み合わせたカレンダーは元のカレンダーの判定に任せる
+	if c.members != nil {
+		return c.combinedBusinessDay(day, time.Date(y, m, d, 0, 0, 0, 0, loc))
+	}
+	// 振替出勤日
+	if c.workdays.contains(day) {
+		return true
+	}
+	// 定休日判定
+	if c.isWeekendDay(weekdayOf(day)) {
+		return false
+	}
+	// 祝日判定
+	if c.holidays.contains(day) {
+		return false
+	}
+	if c.Generate != nil {
+		if gen == nil {
+			gen = c.generated(y)
+		}
+		if _, ok := gen[day]; ok {
+			return false
+		}
+	}
+	if len(c.closureRules) > 0 {
+		if _, ok := c.closureRuleOn(time.Date(y, m, d, 0, 0, 0, 0, loc)); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// countBusinessDays はビットマップを使わずに start~end (両端含む、日付のみ) の営業日数を数える
+// 1 日ごとに AddDate で time.Time を作り直さず、年月日と epochDay を 1 日ずつ進めて判定する
+func (c *Calendar) countBusinessDays(start, end time.Time) int {
+	y, m, d := start.Date()
+	loc := start.Location()
+	var gen map[int32]string
+	n := 0
+	for day, last := civilDay(y, m, d), epochDay(end); day <= last; day++ {
+		if gen == nil && c.Generate != nil {
+			gen = c.generated(y)
+		}
+		if c.businessDay(y, m, d, day, loc, gen) {
+			n++
+		}
+		if d++; d > daysIn(y, m) {
+			d = 1
+			if m++; m > time.December {
+				m, y, gen = time.January, y+1, nil
+			}
+		}
+	}
+	return n
+}
+
+// IsWeekend は t の曜日が定休日 (既定では土日) かどうかを判定
+func (c *Calendar) IsWeekend(t time.Time) bool {
+	if c.Weekend == nil {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	}
+	for _, w := range c.Weekend {
+		if t.Weekday() == w {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWorkday は t の日付が振替出勤日として明示的に営業日とされているかを判定
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	return c.workdays.contains(epochDay(t))
+}
+
+// IsHoliday は t の日付が祝日 (一覧または生成規則によるもの) かどうかを判定
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.holidayOn(t)
+	return ok
+}
+
+// CountBusinessDays は start~end (両端含む) の営業日数を返す
+// start・end の時刻は無視して日付だけで数える (23:59 の start と翌日以降 0:00 の end でも end の日を含む)
+// 両端の扱いを変えるなら CountBusinessDaysWith を使う (CountBusinessDays は Inclusive を指定したのと同じ)
+func (c *Calendar) CountBusinessDays(start, end time.Time) (int, error) {
+	start, end = BeginningOfDay(start), BeginningOfDay(end)
+	if end.Before(start) {
+		return 0, errors.New("end は start より後の日付を指定してください")
+	}
+	if c.bitmaps != nil {
+		return c.cachedCount(start, end), nil
+	}
+	return c.countBusinessDays(start, end), nil
+}
+
+// CountOptions は CountBusinessDaysWith で期間の両端の日を数えるか
+// ゼロ値は両端とも含めない。金融の日数計算でよく使う「始点を含めず終点を含める」なら IncludeEnd だけを真にする
+type CountOptions struct {
+	IncludeStart bool
+	IncludeEnd   bool
+}
+
+// Inclusive は両端の日を含めて数える CountOptions (CountBusinessDays の数え方)
+var Inclusive = CountOptions{IncludeStart: true, IncludeEnd: true}
+
+// CountBusinessDaysWith は start~end の営業日数を、両端の日を opt に従って含めるか除いて返す
+// start と end が同じ日なら、両端とも含めるときだけその日を数える
+func (c *Calendar) CountBusinessDaysWith(start, end time.Time, opt CountOptions) (int, error) {
+	n, err := c.CountBusinessDays(start, end)
+	if err != nil {
+		return 0, err
+	}
+	start, end = BeginningOfDay(start), BeginningOfDay(end)
+	if start.Equal(end) {
+		if opt != Inclusive {
+			return 0, nil
+		}
+		return n, nil
+	}
+	if !opt.IncludeStart && c.IsBusinessDay(start) {
+		n--
+	}
+	if !opt.IncludeEnd && c.IsBusinessDay(end) {
+		n--
+	}
+	return n, nil
+}
+
+// IsOpen は t が営業日かつ営業時間内 (休憩時間を除く) かどうかを判定
+func (c *Calendar) IsOpen(t time.Time) bool {
+	if !c.IsBusinessDay(t) {
+		return false
+	}
+	return c.Hours.Contains(timeOfDay(t))
+}
+
+// Contains は 0:00 からの経過時間 d が営業時間内かどうかを判定
+func (h WorkHours) Contains(d time.Duration) bool {
+	if d < h.Start || d >= h.End {
+		return false
+	}
+	return d < h.BreakStart || d >= h.BreakEnd
+}
+
+// timeOfDay は t の 0:00 からの経過時間を返す
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+}
+
+// BusinessHours は day の日付の営業時間帯 (始業~終業) を day のロケーションで返す
+// 営業日でなければ ok は false
+func (c *Calendar) BusinessHours(day time.Time) (start, end time.Time, ok bool) {
+	if !c.IsBusinessDay(day) {
+		return time.Time{}, time.Time{}, false
+	}
+	y, m, d := day.Date()
+	start = time.Date(y, m, d, 0, 0, 0, int(c.Hours.Start), day.Location())
+	end = time.Date(y, m, d, 0, 0, 0, int(c.Hours.End), day.Location())
+	return start, end, true
+}
+
+// Duration は休憩を除いた 1 日の稼働時間
+func (h WorkHours) Duration() time.Duration {
+	return h.End - h.Start - (h.BreakEnd - h.BreakStart)
+}
+
+// segments は休憩で区切られた営業時間帯を [開始, 終了) の組で返す
+func (h WorkHours) segments() [][2]time.Duration {
+	if h.BreakEnd <= h.BreakStart {
+		return [][2]time.Duration{{h.Start, h.End}}
+	}
+	return [][2]time.Duration{{h.Start, h.BreakStart}, {h.BreakEnd, h.End}}
+}
+
+// WorkedDuration は from~to の間に含まれる営業時間 (休憩を除く) の合計を返す
+func (c *Calendar) WorkedDuration(from, to time.Time) time.Duration {
+	var total time.Duration
+	for d := BeginningOfDay(from); d.Before(to); d = d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		y, m, day := d.Date()
+		for _, seg := range c.Hours.segments() {
+			s := time.Date(y, m, day, 0, 0, 0, int(seg[0]), d.Location())
+			e := time.Date(y, m, day, 0, 0, 0, int(seg[1]), d.Location())
+			s, e = later(s, from), earlier(e, to)
+			if s.Before(e) {
+				total += e.Sub(s)
+			}
+		}
+	}
+	return total
+}
+
+// maxProjectionDays は ProjectCompletion が探索する最大日数
+const maxProjectionDays = 366 * 10
+
+// ProjectCompletion は start から営業時間内に work だけ作業したときの完了日時を返す
+func (c *Calendar) ProjectCompletion(start time.Time, work time.Duration) (time.Time, error) {
+	if work <= 0 {
+		return start, nil
+	}
+	if c.Hours.Duration() <= 0 {
+		return time.Time{}, errors.New("営業時間が設定されていません")
+	}
+
+	remaining := work
+	d := BeginningOfDay(start)
+	for i := 0; i < maxProjectionDays; i, d = i+1, d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		y, m, day := d.Date()
+		for _, seg := range c.Hours.segments() {
+			s := later(time.Date(y, m, day, 0, 0, 0, int(seg[0]), d.Location()), start)
+			e := time.Date(y, m, day, 0, 0, 0, int(seg[1]), d.Location())
+			if !s.Before(e) {
+				continue
+			}
+			avail := e.Sub(s)
+			if remaining <= avail {
+				return s.Add(remaining), nil
+			}
+			remaining -= avail
+		}
+	}
+	return time.Time{}, fmt.Errorf("%d 日以内に作業が完了しません", maxProjectionDays)
+}
+
+// isSameDay は、2つの time.Time が同じ年月日かどうかを判定
+func isSameDay(day1, day2 time.Time) bool {
+	y1, m1, d1 := day1.Date()
+	y2, m2, d2 := day2.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// BeginningOfDay は t の日付の 0:00 を t のロケーションで返す
+func BeginningOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// BeginningOfMonth は与えられた日付の月初 (xx月1日 0:00:00) を返す
+func BeginningOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfMonth は与えられた日付の月末 (xx月末日 23:59:59) を返す
+func EndOfMonth(t time.Time) time.Time {
+	// 月初を取得
+	firstDayOfMonth := BeginningOfMonth(t)
+	// 次の月に +1 して日数を -1 すると、当月末日
+	nextMonth := firstDayOfMonth.AddDate(0, 1, 0)
+	endOfThisMonth := nextMonth.AddDate(0, 0, -1)
+	// 23:59:59 に設定
+	return time.Date(
+		endOfThisMonth.Year(),
+		endOfThisMonth.Month(),
+		endOfThisMonth.Day(),
+		23, 59, 59, 0,
+		t.Location(),
+	)
+}
@@ -0,0 +1,1434 @@
+package bizday
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テストからは bizday/data/jp を import できない (循環する) ので、同じファイルを祝日データとして登録する
+func init() {
+	data, err := os.ReadFile("data/jp/holidays.yaml")
+	if err != nil {
+		panic(err)
+	}
+	RegisterDataset("jp", data)
+}
+
+func mustJapan(t *testing.T) *Calendar {
+	t.Helper()
+	entries, err := DefaultHolidays()
+	if err != nil {
+		t.Fatalf("DefaultHolidays: %v", err)
+	}
+	return NewCalendarAsOf(entries, date(2025, 6, 1))
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		day  time.Time
+		want bool
+	}{
+		{date(2025, 5, 2), true},   // 金曜
+		{date(2025, 5, 3), false},  // 土曜・憲法記念日
+		{date(2025, 5, 5), false},  // こどもの日
+		{date(2025, 5, 6), false},  // 振替休日
+		{date(2025, 5, 7), true},   // 水曜
+		{date(2025, 5, 11), false}, // 日曜
+	}
+	for _, tt := range tests {
+		if got := cal.IsBusinessDay(tt.day); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.day.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestIsBusinessDayWorkday(t *testing.T) {
+	cal := NewCalendarAsOf([]HolidayEntry{
+		{Date: date(2025, 5, 5), Name: "こどもの日"},
+		{Date: date(2025, 5, 10), Workday: true},
+	}, date(2025, 1, 1))
+	if cal.IsBusinessDay(date(2025, 5, 5)) {
+		t.Error("2025-05-05 は祝日なので営業日ではない")
+	}
+	if !cal.IsBusinessDay(date(2025, 5, 10)) {
+		t.Error("2025-05-10 は振替出勤日なので営業日")
+	}
+}
+
+func TestCountBusinessDays(t *testing.T) {
+	cal := mustJapan(t)
+	got, err := cal.CountBusinessDays(date(2025, 5, 1), date(2025, 5, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 20 {
+		t.Errorf("2025 年 5 月の営業日数 = %d, want 20", got)
+	}
+
+	if _, err := cal.CountBusinessDays(date(2025, 5, 31), date(2025, 5, 1)); err == nil {
+		t.Error("end < start でエラーにならない")
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	entries := []HolidayEntry{
+		{Date: date(2025, 11, 24), Name: "臨時休業", ValidFrom: date(2025, 10, 1)},
+	}
+	before := NewCalendarAsOf(entries, date(2025, 9, 30))
+	after := before.AsOf(date(2025, 10, 1))
+	if !before.IsBusinessDay(date(2025, 11, 24)) {
+		t.Error("valid_from より前の時点では祝日にならない")
+	}
+	if after.IsBusinessDay(date(2025, 11, 24)) {
+		t.Error("valid_from 以降の時点では祝日になる")
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2025, 5, 7, 8, 59, 0, 0, time.UTC), false},
+		{time.Date(2025, 5, 7, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 5, 7, 12, 30, 0, 0, time.UTC), false}, // 休憩
+		{time.Date(2025, 5, 7, 17, 59, 0, 0, time.UTC), true},
+		{time.Date(2025, 5, 7, 18, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 5, 5, 10, 0, 0, 0, time.UTC), false}, // 祝日
+	}
+	for _, tt := range tests {
+		if got := cal.IsOpen(tt.at); got != tt.want {
+			t.Errorf("IsOpen(%s) = %v, want %v", tt.at.Format("2006-01-02 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestWorkedDuration(t *testing.T) {
+	cal := mustJapan(t)
+	// 5/2 (金) の 10:00 から 5/7 (水) の 10:00 まで: 7 時間 + 1 時間
+	got := cal.WorkedDuration(time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC), time.Date(2025, 5, 7, 10, 0, 0, 0, time.UTC))
+	if want := 8 * time.Hour; got != want {
+		t.Errorf("WorkedDuration = %v, want %v", got, want)
+	}
+}
+
+func TestProjectCompletion(t *testing.T) {
+	cal := mustJapan(t)
+	got, err := cal.ProjectCompletion(time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC), 12*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 5, 7, 14, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ProjectCompletion = %v, want %v", got, want)
+	}
+}
+
+func TestAddBusinessHours(t *testing.T) {
+	cal := mustJapan(t)
+	at := func(d, h, m int) time.Time { return time.Date(2025, 5, d, h, m, 0, 0, time.UTC) }
+	tests := []struct {
+		from time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{at(2, 17, 0), 2 * time.Hour, at(7, 10, 0)},  // 連休をまたぐ
+		{at(7, 12, 30), time.Hour, at(7, 14, 0)},     // 休憩中から数える
+		{at(7, 8, 0), 8 * time.Hour, at(7, 18, 0)},   // 始業前から終業ちょうど
+		{at(7, 10, 0), -2 * time.Hour, at(2, 17, 0)}, // さかのぼる
+		{at(7, 14, 0), -90 * time.Minute, at(7, 11, 30)},
+		{at(7, 10, 0), 0, at(7, 10, 0)},
+	}
+	for _, tt := range tests {
+		got, err := cal.AddBusinessHours(tt.from, tt.d)
+		if err != nil {
+			t.Fatalf("AddBusinessHours(%v, %v): %v", tt.from, tt.d, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("AddBusinessHours(%v, %v) = %v, want %v", tt.from, tt.d, got, tt.want)
+		}
+		if back := cal.BusinessHoursBetween(tt.from, got); back != tt.d {
+			t.Errorf("BusinessHoursBetween(%v, %v) = %v, want %v", tt.from, got, back, tt.d)
+		}
+	}
+}
+
+func TestNextOpen(t *testing.T) {
+	cal := mustJapan(t)
+	at := func(d, h, m int) time.Time { return time.Date(2025, 5, d, h, m, 0, 0, time.UTC) }
+	tests := []struct{ from, want time.Time }{
+		{at(2, 10, 0), at(2, 10, 0)},  // 営業時間内
+		{at(2, 12, 15), at(2, 13, 0)}, // 休憩中
+		{at(2, 18, 0), at(7, 9, 0)},   // 終業後から連休明け
+		{at(3, 10, 0), at(7, 9, 0)},   // 祝日
+	}
+	for _, tt := range tests {
+		got, err := cal.NextOpen(tt.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("NextOpen(%v) = %v, want %v", tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestMonthStats(t *testing.T) {
+	cal := mustJapan(t)
+	s := cal.MonthStats(date(2025, 5, 7))
+	if s.BusinessDays != 20 || s.Index != 3 || s.Remaining != 17 {
+		t.Errorf("MonthStats = %d/%d/%d, want 20/3/17", s.BusinessDays, s.Index, s.Remaining)
+	}
+	if !isSameDay(s.FirstBusinessDay, date(2025, 5, 1)) || !isSameDay(s.LastBusinessDay, date(2025, 5, 30)) {
+		t.Errorf("最初と最後の営業日 = %v, %v", s.FirstBusinessDay, s.LastBusinessDay)
+	}
+	if len(s.Holidays) != 4 {
+		t.Errorf("5 月の祝日 = %d 件, want 4", len(s.Holidays))
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	cal := mustJapan(t)
+	b, err := cal.Breakdown(date(2025, 5, 1), date(2025, 5, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RangeBreakdown{TotalDays: 31, WeekendDays: 9, Holidays: 2, BusinessDays: 20}
+	if b != want {
+		t.Errorf("Breakdown = %+v, want %+v", b, want)
+	}
+}
+
+func TestNonBusinessDays(t *testing.T) {
+	cal := mustJapan(t)
+	days, err := cal.NonBusinessDays(date(2025, 5, 3), date(2025, 5, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 4 {
+		t.Fatalf("NonBusinessDays = %d 件, want 4", len(days))
+	}
+	if d := days[0]; !d.Weekend || !d.Holiday || d.Name != "憲法記念日" {
+		t.Errorf("5/3 = %+v", d)
+	}
+	if d := days[3]; d.Weekend || !d.Holiday {
+		t.Errorf("5/6 = %+v", d)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cal := NewCalendarAsOf([]HolidayEntry{
+		{Date: date(2025, 5, 5), Name: "こどもの日"},
+		{Date: date(2025, 5, 10), Workday: true},
+	}, date(2025, 1, 1))
+	tests := []struct {
+		day  time.Time
+		want DayClass
+	}{
+		{date(2025, 5, 5), ClassHoliday},
+		{date(2025, 5, 7), ClassBusiness},
+		{date(2025, 5, 10), ClassWorkday},
+		{date(2025, 5, 11), ClassWeekend},
+	}
+	for _, tt := range tests {
+		if got, _ := cal.Classify(tt.day); got != tt.want {
+			t.Errorf("Classify(%s) = %s, want %s", tt.day.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestCalendarCopiesHolidays(t *testing.T) {
+	hs := []time.Time{date(2025, 5, 5)}
+	cal := NewCalendar(hs)
+	// 作成に使ったスライスを後で書き換えても、カレンダーの祝日は変わらない
+	hs[0] = date(2025, 5, 7)
+	if cal.IsBusinessDay(date(2025, 5, 5)) {
+		t.Error("作成時の祝日が営業日になった")
+	}
+	if !cal.IsBusinessDay(date(2025, 5, 7)) {
+		t.Error("作成後にスライスへ書いた日が祝日になった")
+	}
+}
+
+func TestDayFromEpoch(t *testing.T) {
+	for d := date(1899, 12, 25); d.Year() < 2101; d = d.AddDate(0, 0, 7) {
+		y, m, day := dayFromEpoch(epochDay(d))
+		if y != d.Year() || m != d.Month() || day != d.Day() {
+			t.Fatalf("dayFromEpoch(epochDay(%s)) = %d-%d-%d", d.Format("2006-01-02"), y, m, day)
+		}
+	}
+}
+
+func TestRuleCalendarCache(t *testing.T) {
+	calls := 0
+	cal := NewRuleCalendar(func(year int) []Holiday {
+		calls++
+		return []Holiday{{Date: date(year, 1, 1), Name: "元日"}}
+	})
+	n, err := cal.CountBusinessDays(date(2025, 1, 1), date(2025, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 260 {
+		t.Errorf("営業日数 = %d, want 260", n)
+	}
+	if calls != 1 {
+		t.Errorf("Generate の呼び出し回数 = %d, want 1", calls)
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{date(2025, 4, 28), 5, date(2025, 5, 8)},  // 昭和の日・GW を飛ばす
+		{date(2025, 5, 8), -5, date(2025, 4, 28)}, // 負の n は前へ
+		{date(2025, 5, 3), 1, date(2025, 5, 7)},   // 休業日からでも翌営業日
+		{date(2025, 5, 3), 0, date(2025, 5, 3)},   // 0 はそのまま
+		{date(2025, 5, 7), -1, date(2025, 5, 2)},  // 連休をまたいで前の営業日
+	}
+	for _, tt := range tests {
+		if got, err := cal.AddBusinessDays(tt.from, tt.n); err != nil || !got.Equal(tt.want) {
+			t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tt.from.Format("2006-01-02"), tt.n, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestAddBusinessDaysWith(t *testing.T) {
+	cal := mustJapan(t)
+	next := AddOptions{}
+	same := AddOptions{From: CountFromSame}
+	// 2025 年の GW: 4/29 (火) 昭和の日、5/3 (土)~5/6 (火) 休み
+	tests := []struct {
+		from time.Time
+		n    int
+		opt  AddOptions
+		want time.Time
+	}{
+		// 営業日から、出発日を数えない
+		{date(2025, 5, 2), -3, next, date(2025, 4, 28)},
+		{date(2025, 5, 2), -2, next, date(2025, 4, 30)},
+		{date(2025, 5, 2), -1, next, date(2025, 5, 1)},
+		{date(2025, 5, 2), 0, next, date(2025, 5, 2)},
+		{date(2025, 5, 2), 1, next, date(2025, 5, 7)},
+		{date(2025, 5, 2), 2, next, date(2025, 5, 8)},
+		{date(2025, 5, 2), 3, next, date(2025, 5, 9)},
+		// 営業日から、出発日を 1 営業日目とする
+		{date(2025, 5, 2), -3, same, date(2025, 4, 30)},
+		{date(2025, 5, 2), -2, same, date(2025, 5, 1)},
+		{date(2025, 5, 2), -1, same, date(2025, 5, 2)},
+		{date(2025, 5, 2), 0, same, date(2025, 5, 2)},
+		{date(2025, 5, 2), 1, same, date(2025, 5, 2)},
+		{date(2025, 5, 2), 2, same, date(2025, 5, 7)},
+		{date(2025, 5, 2), 3, same, date(2025, 5, 8)},
+		// 連休明けの営業日から
+		{date(2025, 5, 7), -2, next, date(2025, 5, 1)},
+		{date(2025, 5, 7), -1, next, date(2025, 5, 2)},
+		{date(2025, 5, 7), 1, next, date(2025, 5, 8)},
+		{date(2025, 5, 7), 3, next, date(2025, 5, 12)},
+		{date(2025, 5, 7), -2, same, date(2025, 5, 2)},
+		{date(2025, 5, 7), -1, same, date(2025, 5, 7)},
+		{date(2025, 5, 7), 1, same, date(2025, 5, 7)},
+		{date(2025, 5, 7), 3, same, date(2025, 5, 9)},
+		// 休業日からは、どちらの数え方でも前後の最初の営業日が ±1 営業日目
+		{date(2025, 5, 4), -3, next, date(2025, 4, 30)},
+		{date(2025, 5, 4), -2, next, date(2025, 5, 1)},
+		{date(2025, 5, 4), -1, next, date(2025, 5, 2)},
+		{date(2025, 5, 4), 0, next, date(2025, 5, 4)},
+		{date(2025, 5, 4), 1, next, date(2025, 5, 7)},
+		{date(2025, 5, 4), 2, next, date(2025, 5, 8)},
+		{date(2025, 5, 4), 3, next, date(2025, 5, 9)},
+		{date(2025, 5, 4), -3, same, date(2025, 4, 30)},
+		{date(2025, 5, 4), -1, same, date(2025, 5, 2)},
+		{date(2025, 5, 4), 0, same, date(2025, 5, 4)},
+		{date(2025, 5, 4), 1, same, date(2025, 5, 7)},
+		{date(2025, 5, 4), 3, same, date(2025, 5, 9)},
+		// 祝日 1 日 (4/29) をまたぐ
+		{date(2025, 4, 28), 1, next, date(2025, 4, 30)},
+		{date(2025, 4, 29), 1, same, date(2025, 4, 30)},
+		{date(2025, 4, 29), -1, same, date(2025, 4, 28)},
+		// n = 0 は ZeroRoll でずらす (営業日ならずらさない)
+		{date(2025, 5, 4), 0, AddOptions{ZeroRoll: RollFollowing}, date(2025, 5, 7)},
+		{date(2025, 5, 4), 0, AddOptions{ZeroRoll: RollPreceding}, date(2025, 5, 2)},
+		{date(2025, 5, 4), 0, AddOptions{From: CountFromSame, ZeroRoll: RollFollowing}, date(2025, 5, 7)},
+		{date(2025, 5, 2), 0, AddOptions{ZeroRoll: RollFollowing}, date(2025, 5, 2)},
+		{date(2025, 5, 31), 0, AddOptions{ZeroRoll: RollModifiedFollowing}, date(2025, 5, 30)},
+		// ZeroRoll は n が 0 のときだけ
+		{date(2025, 5, 4), 1, AddOptions{ZeroRoll: RollPreceding}, date(2025, 5, 7)},
+	}
+	for _, tt := range tests {
+		if got, err := cal.AddBusinessDaysWith(tt.from, tt.n, tt.opt); err != nil || !got.Equal(tt.want) {
+			t.Errorf("AddBusinessDaysWith(%s, %d, %+v) = %s, want %s", tt.from.Format("2006-01-02"), tt.n, tt.opt,
+				got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+
+	// 4~6 月のすべての日と -10~10 営業日で、数え方どおりの営業日数になっているか
+	for d := date(2025, 4, 1); d.Before(date(2025, 7, 1)); d = d.AddDate(0, 0, 1) {
+		for n := -10; n <= 10; n++ {
+			want, _ := cal.AddBusinessDays(d, n)
+			if got, _ := cal.AddBusinessDaysWith(d, n, next); !got.Equal(want) {
+				t.Errorf("CountFromNext(%s, %d) = %s が AddBusinessDays と違う", d.Format("2006-01-02"), n, got.Format("2006-01-02"))
+			}
+			got, _ := cal.AddBusinessDaysWith(d, n, same)
+			if n != 0 && !cal.IsBusinessDay(got) {
+				t.Errorf("CountFromSame(%s, %d) = %s が休業日", d.Format("2006-01-02"), n, got.Format("2006-01-02"))
+			}
+			// 出発日を含めて数えると |n| 営業日になる
+			var count int
+			switch {
+			case n > 0:
+				count, _ = cal.CountBusinessDays(d, got)
+			case n < 0:
+				count, _ = cal.CountBusinessDays(got, d)
+				count = -count
+			}
+			if count != n {
+				t.Errorf("CountFromSame(%s, %d) = %s までの営業日数 (両端含む) = %d", d.Format("2006-01-02"), n, got.Format("2006-01-02"), count)
+			}
+		}
+	}
+	if v, err := ParseStartCountingFrom(" Same "); err != nil || v != CountFromSame {
+		t.Errorf("ParseStartCountingFrom(same) = %q, %v", v, err)
+	}
+	if _, err := ParseStartCountingFrom("today"); err == nil {
+		t.Error("ParseStartCountingFrom(today) がエラーにならない")
+	}
+}
+
+func TestNextPrevBusinessDay(t *testing.T) {
+	cal := mustJapan(t)
+	if got, _ := cal.NextBusinessDay(date(2025, 5, 2)); !got.Equal(date(2025, 5, 7)) {
+		t.Errorf("NextBusinessDay(2025-05-02) = %s, want 2025-05-07", got.Format("2006-01-02"))
+	}
+	if got, _ := cal.PrevBusinessDay(date(2025, 5, 7)); !got.Equal(date(2025, 5, 2)) {
+		t.Errorf("PrevBusinessDay(2025-05-07) = %s, want 2025-05-02", got.Format("2006-01-02"))
+	}
+	// 定休日が 7 曜日すべてなら、探し続けずにエラーを返す
+	closed := cal.WithWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	if _, err := closed.AddBusinessDays(date(2025, 5, 2), 1); !errors.Is(err, ErrNoBusinessDay) {
+		t.Errorf("営業日のないカレンダーの AddBusinessDays のエラー = %v, want ErrNoBusinessDay", err)
+	}
+	if _, err := closed.Roll(date(2025, 5, 3), RollModifiedFollowing); !errors.Is(err, ErrNoBusinessDay) {
+		t.Errorf("営業日のないカレンダーの Roll のエラー = %v, want ErrNoBusinessDay", err)
+	}
+	if _, err := ParseWeekend("sun,mon,tue,wed,thu,fri,sat"); err == nil {
+		t.Error("ParseWeekend で 7 曜日すべてを定休日にしてもエラーにならない")
+	}
+}
+
+func TestWithExtra(t *testing.T) {
+	cal := mustJapan(t).WithExtra([]HolidayEntry{
+		{Date: date(2025, 8, 13), Name: "夏季休業"},
+		{Date: date(2025, 5, 5), Name: "会社休業"},
+		{Date: date(2025, 5, 10), Workday: true},
+	})
+	if cal.IsBusinessDay(date(2025, 8, 13)) {
+		t.Error("会社の休業日が営業日になる")
+	}
+	if !cal.IsBusinessDay(date(2025, 5, 10)) {
+		t.Error("出勤日の土曜が営業日にならない")
+	}
+	if h, _ := cal.holidayOn(date(2025, 5, 5)); h.Name != "こどもの日" {
+		t.Errorf("祝日と重なる休業日の名前 = %s, want こどもの日", h.Name)
+	}
+	if b, _ := cal.Breakdown(date(2025, 8, 1), date(2025, 8, 31)); b.Closures != 1 || b.Holidays != 1 {
+		t.Errorf("8 月の休業日・祝日 = %d/%d, want 1/1", b.Closures, b.Holidays)
+	}
+	// 時点を変えても重ねた休業日は残る
+	if cal.AsOf(date(2025, 1, 1)).IsBusinessDay(date(2025, 8, 13)) {
+		t.Error("AsOf で会社の休業日が消える")
+	}
+	if !mustJapan(t).IsBusinessDay(date(2025, 8, 13)) {
+		t.Error("元の Calendar が書き換わる")
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		a, b time.Time
+		want int
+	}{
+		{date(2025, 4, 28), date(2025, 5, 8), 5},
+		{date(2025, 5, 8), date(2025, 4, 28), -5},
+		{date(2025, 5, 7), date(2025, 5, 7), 0},
+		{date(2025, 5, 2), date(2025, 5, 6), 0}, // 連休中は 0
+		{date(2025, 4, 10), date(2025, 3, 25), -12},
+	}
+	for _, tt := range tests {
+		if got := cal.BusinessDaysBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("BusinessDaysBetween(%s, %s) = %d, want %d", tt.a.Format("2006-01-02"), tt.b.Format("2006-01-02"), got, tt.want)
+		}
+	}
+	for n := -7; n <= 7; n++ {
+		d, _ := cal.AddBusinessDays(date(2025, 5, 3), n)
+		if got := cal.BusinessDaysBetween(date(2025, 5, 3), d); got != n {
+			t.Errorf("AddBusinessDays で %d 営業日進めた日までの営業日数 = %d", n, got)
+		}
+	}
+}
+
+func TestPlannedHours(t *testing.T) {
+	cal := mustJapan(t)
+	h := DayHours{Default: 8 * time.Hour, ByWeekday: map[time.Weekday]time.Duration{time.Friday: 6 * time.Hour}}
+	tests := []struct {
+		h          DayHours
+		start, end time.Time
+		want       time.Duration
+	}{
+		// 2025 年 5 月: 営業日 20 日のうち金曜は 5/2, 9, 16, 23, 30 の 5 日
+		{h, date(2025, 5, 1), date(2025, 5, 31), 150 * time.Hour},
+		{h.Scale(0.5), date(2025, 5, 2), date(2025, 5, 2), 3 * time.Hour}, // 稼働率 0.5 の金曜
+		{h, date(2025, 5, 2), date(2025, 5, 1), 0},                        // end < start
+	}
+	for _, tt := range tests {
+		if got, err := cal.PlannedHours(tt.h, tt.start, tt.end); err != nil || got != tt.want {
+			t.Errorf("PlannedHours(%s, %s) = %v, %v, want %v", tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"), got, err, tt.want)
+		}
+	}
+	// 合計が time.Duration (約 256 万時間) を超えるなら、桁あふれした値ではなくエラーを返す
+	if got, err := cal.PlannedHours(UniformDayHours(20000*time.Hour), date(2025, 1, 1), date(2025, 12, 31)); err == nil {
+		t.Errorf("桁あふれする期間の PlannedHours = %v, want エラー", got)
+	}
+}
+
+func TestEstimateWorkload(t *testing.T) {
+	cal := mustJapan(t)
+	h := DayHours{Default: 8 * time.Hour, ByWeekday: map[time.Weekday]time.Duration{time.Friday: 6 * time.Hour}}
+	// 2025-05-07 (水)~05-10 (土): 営業日は 7, 8, 9 日で 8+8+6 時間
+	w := cal.EstimateWorkload(date(2025, 5, 7), date(2025, 5, 10), WorkloadOptions{
+		Hours:    h,
+		HalfDays: []time.Time{date(2025, 5, 9)},
+		TimeOff: []TimeOff{
+			{Date: date(2025, 5, 8), Half: true},
+			{Date: date(2025, 5, 8)},             // 全休が優先
+			{Date: date(2025, 5, 9), Half: true}, // 半日営業の残り 3 時間の半分
+			{Date: date(2025, 5, 10)},            // 土曜は数えない
+		},
+		Meetings: []Meeting{
+			{Name: "定例", Weekdays: []time.Weekday{time.Wednesday}, Duration: time.Hour},
+			{Name: "休暇の日", Date: date(2025, 5, 8), Duration: 2 * time.Hour},
+			{Name: "合宿", Date: date(2025, 5, 7), Duration: 10 * time.Hour}, // 残りの 7 時間まで
+		},
+	})
+	want := Workload{Start: date(2025, 5, 7), End: date(2025, 5, 10), BusinessDays: 3, Planned: 22 * time.Hour,
+		HalfDays: 1, HalfDayHours: 3 * time.Hour, TimeOffDays: 1.5, TimeOffHours: 9*time.Hour + 30*time.Minute,
+		Meetings: 2, MeetingHours: 8 * time.Hour, Available: 90 * time.Minute}
+	if w != want {
+		t.Errorf("EstimateWorkload = %+v\nwant %+v", w, want)
+	}
+	if w := cal.EstimateWorkload(date(2025, 5, 1), date(2025, 5, 31), WorkloadOptions{Hours: h}); w.Available != 150*time.Hour || w.Planned != w.Available {
+		t.Errorf("予定なし = %v/%v, want 150h", w.Planned, w.Available)
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	start, end, year := FiscalYear(date(2026, 2, 10), time.April)
+	if year != 2025 || !start.Equal(date(2025, 4, 1)) || !end.Equal(date(2026, 3, 31)) {
+		t.Errorf("FiscalYear(2026-02-10) = %s~%s (%d 年度)", start.Format("2006-01-02"), end.Format("2006-01-02"), year)
+	}
+	q, start, end := FiscalQuarter(date(2026, 2, 10), time.April)
+	if q != 4 || !start.Equal(date(2026, 1, 1)) || !end.Equal(date(2026, 3, 31)) {
+		t.Errorf("FiscalQuarter(2026-02-10) = Q%d %s~%s", q, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	if q, _, _ := FiscalQuarter(date(2025, 12, 31), time.January); q != 4 {
+		t.Errorf("1 月始まりの 12 月 = Q%d, want Q4", q)
+	}
+}
+
+func TestPeriodStats(t *testing.T) {
+	cal := mustJapan(t)
+	s := cal.PeriodStats(date(2025, 5, 1), date(2025, 5, 31), date(2025, 5, 7))
+	if s.BusinessDays != 20 || s.Elapsed != 3 || s.Remaining != 17 {
+		t.Errorf("PeriodStats = %d/%d/%d, want 20/3/17", s.BusinessDays, s.Elapsed, s.Remaining)
+	}
+	if s := cal.PeriodStats(date(2025, 5, 1), date(2025, 5, 31), date(2025, 4, 1)); s.Elapsed != 0 || s.Remaining != 20 {
+		t.Errorf("期間より前の時点 = %d/%d, want 0/20", s.Elapsed, s.Remaining)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	cal := mustJapan(t)
+	anchor := date(2025, 5, 7)
+	pct := func(n, total int) float64 { return float64(n) / float64(total) * 100 }
+	tests := []struct {
+		name   string
+		period Period
+		want   Summary
+	}{
+		{"月", MonthPeriod, Summary{Start: date(2025, 5, 1), End: date(2025, 5, 31), Total: 20, Elapsed: 3, Remaining: 17, Percent: 15, CalendarDays: 31, CalendarElapsed: 7}},
+		// 5/5・5/6 は祝日
+		{"週", WeekPeriod, Summary{Start: date(2025, 5, 5), End: date(2025, 5, 11), Total: 3, Elapsed: 1, Remaining: 2, Percent: pct(1, 3), CalendarDays: 7, CalendarElapsed: 3}},
+		{"四半期", QuarterPeriod(time.April), Summary{Start: date(2025, 4, 1), End: date(2025, 6, 30), Total: 62, Elapsed: 24, Remaining: 38, Percent: pct(24, 62), CalendarDays: 91, CalendarElapsed: 37}},
+		// 期間より後の時点はすべて経過
+		{"期間指定", RangePeriod(date(2025, 3, 1), date(2025, 3, 31)), Summary{Start: date(2025, 3, 1), End: date(2025, 3, 31), Total: 20, Elapsed: 20, Remaining: 0, Percent: 100, CalendarDays: 31, CalendarElapsed: 31}},
+		// 期間より前の時点はすべて残り
+		{"期間指定 (前)", RangePeriod(date(2025, 6, 1), date(2025, 6, 7)), Summary{Start: date(2025, 6, 1), End: date(2025, 6, 7), Total: 5, Elapsed: 0, Remaining: 5, Percent: 0, CalendarDays: 7, CalendarElapsed: 0}},
+	}
+	for _, tt := range tests {
+		if got := cal.Summary(anchor, tt.period); got != tt.want {
+			t.Errorf("%s: Summary = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+	if s := cal.Summary(anchor, FiscalYearPeriod(time.April)); !s.Start.Equal(date(2025, 4, 1)) || !s.End.Equal(date(2026, 3, 31)) || s.CalendarDays != 365 {
+		t.Errorf("会計年度: %+v", s)
+	}
+}
+
+func TestSprint(t *testing.T) {
+	tests := []struct {
+		t          time.Time
+		n          int
+		start, end time.Time
+	}{
+		{date(2025, 4, 7), 1, date(2025, 4, 7), date(2025, 4, 20)},
+		{date(2025, 4, 20), 1, date(2025, 4, 7), date(2025, 4, 20)},
+		{date(2025, 6, 4), 5, date(2025, 6, 2), date(2025, 6, 15)},
+		{date(2025, 4, 6), 0, date(2025, 3, 24), date(2025, 4, 6)}, // 初回より前
+	}
+	for _, tt := range tests {
+		n, start, end := Sprint(tt.t, date(2025, 4, 7), 14)
+		if n != tt.n || !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("Sprint(%s) = %d %s~%s, want %d %s~%s", tt.t.Format("2006-01-02"),
+				n, start.Format("2006-01-02"), end.Format("2006-01-02"),
+				tt.n, tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	start, end := ISOWeek(date(2025, 6, 4))
+	if !start.Equal(date(2025, 6, 2)) || !end.Equal(date(2025, 6, 8)) {
+		t.Errorf("ISOWeek(2025-06-04) = %s~%s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2025-W23", date(2025, 6, 2)},
+		{"2025-W01", date(2024, 12, 30)}, // 第 1 週は前年の 12 月から始まる
+		{"2020-W53", date(2020, 12, 28)},
+	}
+	for _, tt := range tests {
+		got, err := ParseISOWeek(tt.in, time.UTC)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseISOWeek(%s) = %s, %v, want %s", tt.in, got.Format("2006-01-02"), err, tt.want.Format("2006-01-02"))
+		}
+	}
+	for _, in := range []string{"2025-W53", "2025-23", "2025-W5"} {
+		if _, err := ParseISOWeek(in, time.UTC); err == nil {
+			t.Errorf("ParseISOWeek(%s) でエラーにならない", in)
+		}
+	}
+}
+
+func TestRoll(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		t    time.Time
+		conv RollConvention
+		want time.Time
+	}{
+		{date(2025, 5, 7), RollFollowing, date(2025, 5, 7)}, // 営業日はそのまま
+		{date(2025, 5, 3), RollFollowing, date(2025, 5, 7)},
+		{date(2025, 5, 3), RollPreceding, date(2025, 5, 2)},
+		{date(2025, 5, 3), RollModifiedFollowing, date(2025, 5, 7)},
+		// 2025-05-31 (土) の次の営業日は 6 月なので前の営業日に戻す
+		{date(2025, 5, 31), RollFollowing, date(2025, 6, 2)},
+		{date(2025, 5, 31), RollModifiedFollowing, date(2025, 5, 30)},
+	}
+	for _, tt := range tests {
+		if got, err := cal.Roll(tt.t, tt.conv); err != nil || !got.Equal(tt.want) {
+			t.Errorf("Roll(%s, %s) = %s, want %s", tt.t.Format("2006-01-02"), tt.conv, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+	if _, err := ParseRollConvention("nearest"); err == nil {
+		t.Error("未知のずらし方でエラーにならない")
+	}
+}
+
+func TestNewCombinedCalendar(t *testing.T) {
+	us, _ := Lookup("us")
+	sa, _ := Lookup("sa")
+	cal := NewCombinedCalendar(mustJapan(t), us)
+	tests := []struct {
+		d    time.Time
+		want bool
+	}{
+		{date(2025, 5, 7), true},
+		{date(2025, 5, 5), false},  // 日本のこどもの日
+		{date(2025, 7, 4), false},  // 米国の独立記念日
+		{date(2025, 5, 26), false}, // 米国の Memorial Day
+		{date(2025, 5, 10), false}, // 土曜
+	}
+	for _, tt := range tests {
+		if got := cal.IsBusinessDay(tt.d); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+	if _, name := cal.Classify(date(2025, 1, 1)); name != "元日 / New Year's Day" {
+		t.Errorf("2025-01-01 の祝日名 = %q", name)
+	}
+	// 定休日は両方の定休日を合わせたもの (金・土・日)
+	if NewCombinedCalendar(mustJapan(t), sa).IsBusinessDay(date(2025, 5, 9)) {
+		t.Error("サウジアラビアと組み合わせて金曜が営業日になる")
+	}
+
+	// 片方の振替出勤日は、もう片方でも営業日なら営業日
+	a := NewCalendarAsOf([]HolidayEntry{{Date: date(2025, 6, 7), Workday: true}}, time.Time{})
+	if !NewCombinedCalendar(a, NewCalendar(nil).WithWeekend()).IsBusinessDay(date(2025, 6, 7)) {
+		t.Error("振替出勤日が営業日にならない")
+	}
+	if NewCombinedCalendar(a, NewCalendar(nil)).IsBusinessDay(date(2025, 6, 7)) {
+		t.Error("もう片方の定休日が振替出勤日で営業日になる")
+	}
+}
+
+func TestCombinedCalendarCustomRule(t *testing.T) {
+	typhoon := date(2025, 6, 10)
+	closed := NewCalendar(nil).WithCustomRule(func(d time.Time) DayClass {
+		if d.Equal(typhoon) {
+			return ClassHoliday
+		}
+		return ""
+	})
+	c := NewCombinedCalendar(closed, NewCalendar(nil))
+	// 元のカレンダーの WithCustomRule で休業日にした日は、組み合わせても休業日
+	if c.IsBusinessDay(typhoon) {
+		t.Error("元のカレンダーで休業日にした日が営業日になる")
+	}
+	if class, _ := c.Classify(typhoon); class != ClassHoliday {
+		t.Errorf("Classify = %s, want %s", class, ClassHoliday)
+	}
+	if n, _ := c.CountBusinessDays(date(2025, 6, 9), date(2025, 6, 13)); n != 4 {
+		t.Errorf("CountBusinessDays = %d, want 4", n)
+	}
+	if b, _ := c.Breakdown(date(2025, 6, 9), date(2025, 6, 13)); b.Closures != 1 || b.BusinessDays != 4 {
+		t.Errorf("Breakdown = %+v, want 1 closure and 4 business days", b)
+	}
+	if c.AsOf(date(2025, 1, 1)).IsBusinessDay(typhoon) {
+		t.Error("AsOf で作り直すと元のカレンダーの分類が消える")
+	}
+}
+
+func TestCombinedCalendarCustomWorkday(t *testing.T) {
+	sat := date(2025, 6, 14)
+	open := func(d time.Time) DayClass {
+		if d.Equal(sat) {
+			return ClassWorkday
+		}
+		return ""
+	}
+	// どの元のカレンダーでも営業日にした土曜は、組み合わせても営業日
+	c := NewCombinedCalendar(NewCalendar(nil).WithCustomRule(open), NewCalendar(nil).WithCustomRule(open))
+	if !c.IsBusinessDay(sat) {
+		t.Error("元のカレンダーで営業日にした土曜が営業日にならない")
+	}
+	if class, _ := c.Classify(sat); class != ClassWorkday {
+		t.Errorf("Classify = %s, want %s", class, ClassWorkday)
+	}
+	// 片方だけなら休業日のまま
+	if NewCombinedCalendar(NewCalendar(nil).WithCustomRule(open), NewCalendar(nil)).IsBusinessDay(sat) {
+		t.Error("片方だけで営業日にした土曜が営業日になる")
+	}
+}
+
+func TestParseHolidayCalendars(t *testing.T) {
+	data := []byte(`
+holidays:
+  - {date: "2025-01-01", name: 元日}
+calendars:
+  us:
+    holidays:
+      - {date: "2025-07-04", name: Independence Day}
+    workdays:
+      - "2025-07-05"
+`)
+	cals, err := ParseHolidayCalendars(data)
+	if err != nil {
+		t.Fatalf("ParseHolidayCalendars: %v", err)
+	}
+	us := cals["us"]
+	if len(cals) != 1 || len(us) != 2 || us[0].Name != "Independence Day" || !us[1].Workday {
+		t.Errorf("calendars = %+v", cals)
+	}
+	if entries, _ := ParseHolidays(data); len(entries) != 1 {
+		t.Errorf("ParseHolidays に calendars の祝日が混ざる: %+v", entries)
+	}
+}
+
+func TestValidateHolidays(t *testing.T) {
+	data := []byte(`holidays:
+  - {date: "2023-01-02", name: 振替休日}
+  - {date: "2026-01-01", name: 元日}
+  - {date: "2026-13-01", name: 誤記}
+  - {date: "2026-01-01", name: 元日}
+  - {date: "2026-01-10", name: 土曜}
+  - {date: "2026-01-05", name: 仕事始め}
+  - date: "2026-02-11"
+    name: 建国記念の日
+    valid_to: "2026-01-31"
+  - date: "2026-02-11"
+    name: 建国記念の日
+    valid_from: "2026-02-01"
+overrides:
+  2027:
+    - {date: "2028-01-04", name: 年始休み}
+calendars:
+  us:
+    holidays:
+      - {date: "2026-07-03", name: Independence Day (observed)}
+      - {date: "2026-07-03", name: Independence Day (observed)}
+`)
+	problems, err := ValidateHolidays(data)
+	if err != nil {
+		t.Fatalf("ValidateHolidays: %v", err)
+	}
+	want := []HolidayProblem{
+		{Line: 0, Section: "holidays", Message: "2024 年の祝日がありません (2023~2028 年のうち)", Warning: true},
+		{Line: 0, Section: "holidays", Message: "2025 年の祝日がありません (2023~2028 年のうち)", Warning: true},
+		{Line: 4, Section: "holidays", Message: `祝日のパースに失敗: 日付の形式が不正です: "2026-13-01" (2006-01-02, 2006/01/02, 20060102, RFC3339 (2006-01-02T15:04:05Z07:00) のいずれかで指定してください)`},
+		{Line: 5, Section: "holidays", Message: "2026-01-01 が重複しています"},
+		{Line: 6, Section: "holidays", Message: "2026-01-10 (土曜) は土曜日です", Warning: true},
+		{Line: 7, Section: "holidays", Message: "2026-01-05 が前の日付 2026-01-10 より前にあります"},
+		{Line: 16, Section: "overrides.2027", Message: "2027 年の差し替えに別の年の日付があります: 2028-01-04"},
+		{Line: 21, Section: "calendars.us.holidays", Message: "2026-07-03 が重複しています"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("問題の数 = %d, want %d: %v", len(problems), len(want), problems)
+	}
+	for i := range want {
+		if problems[i] != want[i] {
+			t.Errorf("problems[%d] = %+v, want %+v", i, problems[i], want[i])
+		}
+	}
+
+	if _, err := ValidateHolidays([]byte("holidays: [")); err == nil {
+		t.Error("YAML として読めないデータがエラーにならない")
+	}
+	jp, _ := Dataset("jp")
+	problems, err = ValidateHolidays(jp)
+	if err != nil {
+		t.Fatalf("ValidateHolidays(holidays.yaml): %v", err)
+	}
+	for _, p := range problems {
+		if !p.Warning {
+			t.Errorf("埋め込みの holidays.yaml に誤りがある: %s", p)
+		}
+	}
+}
+
+func TestHolidayName(t *testing.T) {
+	cal := mustJapan(t)
+	if name, ok := cal.HolidayName(date(2025, 5, 6)); !ok || name != "振替休日" {
+		t.Errorf("HolidayName(2025-05-06) = %q, %v", name, ok)
+	}
+	if _, ok := cal.HolidayName(date(2025, 5, 7)); ok {
+		t.Error("平日が祝日になる")
+	}
+	// 規則で算出する祝日にも名前がある
+	us, _ := Lookup("us")
+	if name, ok := us.HolidayName(date(2025, 7, 4)); !ok || name != "Independence Day" {
+		t.Errorf("HolidayName(2025-07-04) = %q, %v", name, ok)
+	}
+
+	entries, err := ParseHolidays([]byte(`holidays: [{"2025-05-06": 振替休日}, "2025-08-13", {date: "2025-08-14", name: 夏季休業}]`))
+	if err != nil {
+		t.Fatalf("ParseHolidays: %v", err)
+	}
+	want := []string{"振替休日", "", "夏季休業"}
+	for i, e := range entries {
+		if e.Name != want[i] {
+			t.Errorf("entries[%d].Name = %q, want %q", i, e.Name, want[i])
+		}
+	}
+}
+
+func TestBusinessDaysIter(t *testing.T) {
+	cal := mustJapan(t)
+	var got []time.Time
+	for d := range cal.BusinessDays(date(2025, 5, 1), date(2025, 5, 9)) {
+		got = append(got, d)
+	}
+	want := []time.Time{date(2025, 5, 1), date(2025, 5, 2), date(2025, 5, 7), date(2025, 5, 8), date(2025, 5, 9)}
+	if len(got) != len(want) {
+		t.Fatalf("BusinessDays = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("BusinessDays[%d] = %s, want %s", i, got[i].Format("2006-01-02"), want[i].Format("2006-01-02"))
+		}
+	}
+	// 途中で打ち切れる (終わりのない範囲でも止まる)
+	n := 0
+	for range cal.BusinessDays(date(2025, 1, 1), date(9999, 12, 31)) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("打ち切りまでの件数 = %d", n)
+	}
+}
+
+func TestBusinessDayOrdinal(t *testing.T) {
+	cal := mustJapan(t)
+	// 2025 年は 1/1~1/3 が年始の休業日、1/4・1/5 が土日なので 1/6 (月) が第 1 営業日
+	for _, tt := range []struct {
+		d    time.Time
+		want int
+	}{{date(2025, 1, 1), 0}, {date(2025, 1, 5), 0}, {date(2025, 1, 6), 1}, {date(2025, 1, 7), 2}, {date(2025, 1, 11), 5}} {
+		if got := cal.BusinessDayOfYear(tt.d); got != tt.want {
+			t.Errorf("BusinessDayOfYear(%s) = %d, want %d", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+	days, _ := cal.CountBusinessDays(date(2025, 1, 1), date(2025, 12, 31))
+	if d, err := cal.DateOfBusinessDayOrdinal(2025, -1); err != nil || d.Format("2006-01-02") != "2025-12-31" {
+		t.Errorf("DateOfBusinessDayOrdinal(2025, -1) = %v, %v, want 2025-12-31", d, err)
+	}
+	if _, err := cal.DateOfBusinessDayOrdinal(2025, days+1); err == nil {
+		t.Errorf("DateOfBusinessDayOrdinal(2025, %d) でエラーにならない", days+1)
+	}
+	if _, err := cal.DateOfBusinessDayOrdinal(2025, 0); err == nil {
+		t.Error("DateOfBusinessDayOrdinal(2025, 0) でエラーにならない")
+	}
+
+	// 年の中の番号と日付は互いに逆、通し番号は営業日ごとに 1 ずつ増える (年をまたいでも、epoch より前でも)
+	epoch := date(2025, 1, 1)
+	prev := cal.BusinessDaysSince(epoch, date(2024, 11, 30))
+	for d := date(2024, 12, 1); d.Before(date(2026, 2, 1)); d = d.AddDate(0, 0, 1) {
+		n := cal.BusinessDaysSince(epoch, d)
+		step := 0
+		if cal.IsBusinessDay(d) {
+			step = 1
+			got, err := cal.DateOfBusinessDayOrdinal(d.Year(), cal.BusinessDayOfYear(d))
+			if err != nil || got.Format("2006-01-02") != d.Format("2006-01-02") {
+				t.Errorf("DateOfBusinessDayOrdinal(BusinessDayOfYear(%s)) = %v, %v", d.Format("2006-01-02"), got, err)
+			}
+		}
+		if n-prev != step {
+			t.Errorf("BusinessDaysSince(%s) = %d, 前日は %d", d.Format("2006-01-02"), n, prev)
+		}
+		prev = n
+	}
+	if n := cal.BusinessDaysSince(epoch, epoch); n != 0 {
+		t.Errorf("BusinessDaysSince(epoch, epoch) = %d, want 0", n)
+	}
+	if n := cal.BusinessDaysSince(epoch, date(2025, 12, 31)); n != days {
+		t.Errorf("BusinessDaysSince(2025-01-01, 2025-12-31) = %d, want %d", n, days)
+	}
+}
+
+func TestSpreadsheetCompat(t *testing.T) {
+	// 期待値は Excel・Google スプレッドシートの NETWORKDAYS・WORKDAY が同じ引数で返す値
+	// (2012~2013 年・2008~2009 年の行は Microsoft の NETWORKDAYS・WORKDAY の説明の例と同じ)
+	for _, tt := range []struct {
+		start, end time.Time
+		holidays   []time.Time
+		want       int
+	}{
+		{date(2012, 10, 1), date(2013, 3, 1), nil, 110},
+		{date(2012, 10, 1), date(2013, 3, 1), []time.Time{date(2012, 11, 22)}, 109},
+		{date(2012, 10, 1), date(2013, 3, 1), []time.Time{date(2012, 11, 22), date(2012, 12, 4), date(2013, 1, 21)}, 107},
+		{date(2025, 5, 1), date(2025, 5, 31), nil, 22},
+		{date(2025, 5, 1), date(2025, 5, 31), []time.Time{date(2025, 5, 3), date(2025, 5, 4), date(2025, 5, 5), date(2025, 5, 6)}, 20},
+		{date(2025, 5, 31), date(2025, 5, 1), nil, -22},                                          // 逆順は負
+		{date(2025, 5, 2), date(2025, 5, 2), nil, 1},                                             // 同じ日は両端を含む
+		{date(2025, 5, 3), date(2025, 5, 3), nil, 0},                                             // 土曜だけなら 0
+		{date(2025, 5, 1), date(2025, 5, 9), []time.Time{date(2025, 5, 6), date(2025, 5, 6)}, 6}, // 重複は 1 日
+		{date(2025, 5, 1), date(2025, 5, 9), []time.Time{date(2025, 4, 30), date(2025, 5, 10)}, 7},
+		{time.Date(2025, 5, 1, 23, 59, 0, 0, time.UTC), time.Date(2025, 5, 2, 0, 1, 0, 0, time.UTC), nil, 2},
+	} {
+		if got := Networkdays(tt.start, tt.end, tt.holidays); got != tt.want {
+			t.Errorf("Networkdays(%s, %s, %d 件) = %d, want %d", tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"), len(tt.holidays), got, tt.want)
+		}
+	}
+
+	gw := []time.Time{date(2025, 5, 5), date(2025, 5, 6)}
+	for _, tt := range []struct {
+		start    time.Time
+		days     int
+		holidays []time.Time
+		want     string
+	}{
+		{date(2008, 10, 1), 151, nil, "2009-04-30"},
+		{date(2008, 10, 1), 151, []time.Time{date(2008, 11, 26), date(2008, 12, 4), date(2009, 1, 21)}, "2009-05-05"},
+		{date(2025, 5, 2), 1, gw, "2025-05-07"},
+		{date(2025, 5, 7), -1, gw, "2025-05-02"},
+		{date(2025, 5, 3), 1, nil, "2025-05-05"},  // 土曜から数え始める
+		{date(2025, 5, 4), -1, nil, "2025-05-02"}, // 日曜から前へ
+		{date(2025, 5, 3), 0, nil, "2025-05-03"},  // 0 なら土曜のまま
+		{date(2025, 5, 5), 0, gw, "2025-05-05"},   // 0 なら祝日のまま
+		{date(2025, 5, 5), 1, gw, "2025-05-07"},   // 祝日の start は数えない
+		{time.Date(2025, 5, 2, 18, 30, 0, 0, time.UTC), 5, nil, "2025-05-09"},
+	} {
+		got := Workday(tt.start, tt.days, tt.holidays)
+		if got.Format("2006-01-02") != tt.want || got.Hour() != 0 || got.Minute() != 0 {
+			t.Errorf("Workday(%s, %d, %d 件) = %v, want %s 0:00", tt.start.Format("2006-01-02"), tt.days, len(tt.holidays), got, tt.want)
+		}
+	}
+}
+
+func TestPaydayAdjusted(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		month time.Month
+		day   int
+		conv  RollConvention
+		want  time.Time
+	}{
+		{time.May, 25, RollPreceding, time.Date(2025, 5, 23, 0, 0, 0, 0, time.Local)}, // 日曜
+		{time.May, 25, RollFollowing, time.Date(2025, 5, 26, 0, 0, 0, 0, time.Local)},
+		{time.June, 25, RollPreceding, time.Date(2025, 6, 25, 0, 0, 0, 0, time.Local)},     // 営業日
+		{time.February, 31, RollPreceding, time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local)}, // 月末
+		{time.May, 6, RollPreceding, time.Date(2025, 5, 2, 0, 0, 0, 0, time.Local)},        // 連休
+	}
+	for _, tt := range tests {
+		got, err := cal.PaydayAdjusted(2025, tt.month, tt.day, tt.conv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("PaydayAdjusted(%d月%d日, %s) = %s, want %s", tt.month, tt.day, tt.conv, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+	if _, err := cal.PaydayAdjusted(2025, time.May, 0, RollPreceding); err == nil {
+		t.Error("0 日でエラーにならない")
+	}
+}
+
+func TestNthBusinessDay(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		month time.Month
+		n     int
+		want  time.Time
+	}{
+		{time.May, 1, date(2025, 5, 1)},
+		{time.May, 3, date(2025, 5, 7)}, // 連休明け
+		{time.May, -1, date(2025, 5, 30)},
+		{time.May, -20, date(2025, 5, 1)},
+		{time.June, 5, date(2025, 6, 6)},
+	}
+	for _, tt := range tests {
+		got, err := cal.NthBusinessDay(2025, tt.month, tt.n)
+		if err != nil || !isSameDay(got, tt.want) {
+			t.Errorf("NthBusinessDay(2025, %d, %d) = %s, %v, want %s", tt.month, tt.n, got.Format("2006-01-02"), err, tt.want.Format("2006-01-02"))
+		}
+	}
+	for _, n := range []int{0, 21, -21} {
+		if _, err := cal.NthBusinessDay(2025, time.May, n); err == nil {
+			t.Errorf("NthBusinessDay(2025, 5, %d) でエラーにならない", n)
+		}
+	}
+	if got, _ := cal.LastBusinessDayOfMonth(2025, time.August); !isSameDay(got, date(2025, 8, 29)) {
+		t.Errorf("LastBusinessDayOfMonth(2025, 8) = %s", got.Format("2006-01-02"))
+	}
+}
+
+func TestWithClosureRules(t *testing.T) {
+	cal := mustJapan(t).WithClosureRules(
+		ClosureRule{Name: "メンテナンス休業", Weekday: time.Wednesday, Nth: 2},
+		ClosureRule{Weekday: time.Friday, Nth: -1, Months: []time.Month{time.March, time.September}},
+	)
+	tests := []struct {
+		d    time.Time
+		want bool
+	}{
+		{date(2025, 5, 14), false}, // 第 2 水曜
+		{date(2025, 5, 7), true},   // 第 1 水曜
+		{date(2031, 1, 8), false},  // 一覧にない年にも当てはまる
+		{date(2025, 3, 28), false}, // 3 月の最終金曜
+		{date(2025, 4, 25), true},  // 対象外の月
+	}
+	for _, tt := range tests {
+		if got := cal.IsBusinessDay(tt.d); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+	if name, _ := cal.HolidayName(date(2025, 5, 14)); name != "メンテナンス休業" {
+		t.Errorf("2025-05-14 の名前 = %q", name)
+	}
+	// 規則による休業日は祝日ではなく休業日として数える
+	if b, _ := cal.Breakdown(date(2025, 5, 1), date(2025, 5, 31)); b.Closures != 1 {
+		t.Errorf("5 月の休業日 = %d, want 1", b.Closures)
+	}
+	if cal.AsOf(date(2025, 1, 1)).IsBusinessDay(date(2025, 5, 14)) {
+		t.Error("AsOf で規則が消える")
+	}
+	if !cal.Expand(2025, 2025).IsHoliday(date(2025, 9, 26)) {
+		t.Error("Expand で規則の休業日が展開されない")
+	}
+	if err := (ClosureRule{Weekday: time.Monday, Nth: 6}).Validate(); err == nil {
+		t.Error("nth が範囲外でもエラーにならない")
+	}
+}
+
+func TestWithCustomRule(t *testing.T) {
+	// 5/14 (水) は台風で臨時休業、5/6 (振替休日) と 5/10 (土) は営業にする
+	cal := mustJapan(t).WithCustomRule(func(d time.Time) DayClass {
+		switch d.Format("2006-01-02") {
+		case "2025-05-14":
+			return ClassHoliday
+		case "2025-05-06":
+			return ClassBusiness
+		case "2025-05-10":
+			return ClassWorkday
+		}
+		return ""
+	})
+	// 後から重ねた関数は、先に重ねた関数が分類を返さない日だけに効く
+	layered := cal.WithCustomRule(func(time.Time) DayClass { return ClassWeekend })
+	tests := []struct {
+		d    time.Time
+		want DayClass
+	}{
+		{date(2025, 5, 14), ClassHoliday},
+		{date(2025, 5, 6), ClassBusiness},
+		{date(2025, 5, 10), ClassWorkday},
+		{date(2025, 5, 15), ClassWeekend},
+	}
+	for _, tt := range tests {
+		if got, _ := layered.Classify(tt.d); got != tt.want {
+			t.Errorf("Classify(%s) = %s, want %s", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+
+	if cal.IsBusinessDay(date(2025, 5, 14)) || !cal.IsBusinessDay(date(2025, 5, 6)) || !cal.IsBusinessDay(date(2025, 5, 10)) {
+		t.Error("IsBusinessDay が上書きした分類と合わない")
+	}
+	// 5 月の営業日 20 日から 5/14 を除き、5/6・5/10 を足す
+	from, to := date(2025, 5, 1), date(2025, 5, 31)
+	for name, c := range map[string]*Calendar{"rule": cal, "bitmap": cal.WithBitmapCache(), "asof": cal.AsOf(date(2025, 1, 1)), "expand": cal.Expand(2025, 2025)} {
+		if n, _ := c.CountBusinessDays(from, to); n != 21 {
+			t.Errorf("%s: 5 月の営業日 = %d, want 21", name, n)
+		}
+	}
+	want := RangeBreakdown{TotalDays: 31, WeekendDays: 8, Holidays: 1, Closures: 1, Workdays: 1, BusinessDays: 21}
+	if b, _ := cal.Breakdown(from, to); b != want {
+		t.Errorf("Breakdown = %+v, want %+v", b, want)
+	}
+	if days, _ := cal.NonBusinessDays(date(2025, 5, 14), date(2025, 5, 14)); len(days) != 1 || !days[0].Closure {
+		t.Errorf("NonBusinessDays(2025-05-14) = %+v, want 休業日 1 件", days)
+	}
+}
+func TestCountBusinessDaysWith(t *testing.T) {
+	cal := mustJapan(t)
+	tests := []struct {
+		name       string
+		start, end time.Time
+		opt        CountOptions
+		want       int
+	}{
+		// 2025-05-01 (木)~05-09 (金): 営業日は 1, 2, 7, 8, 9 日
+		{"両端含む", date(2025, 5, 1), date(2025, 5, 9), Inclusive, 5},
+		{"始点を除く", date(2025, 5, 1), date(2025, 5, 9), CountOptions{IncludeEnd: true}, 4},
+		{"終点を除く", date(2025, 5, 1), date(2025, 5, 9), CountOptions{IncludeStart: true}, 4},
+		{"両端除く", date(2025, 5, 1), date(2025, 5, 9), CountOptions{}, 3},
+		// 休業日の端は含めても除いても同じ (05-03 (土)~05-07 (水))
+		{"休業日の始点を除く", date(2025, 5, 3), date(2025, 5, 7), CountOptions{IncludeEnd: true}, 1},
+		{"休業日の始点を含む", date(2025, 5, 3), date(2025, 5, 7), Inclusive, 1},
+		{"同じ日 (両端含む)", date(2025, 5, 7), date(2025, 5, 7), Inclusive, 1},
+		{"同じ日 (始点を除く)", date(2025, 5, 7), date(2025, 5, 7), CountOptions{IncludeEnd: true}, 0},
+		{"同じ日 (終点を除く)", date(2025, 5, 7), date(2025, 5, 7), CountOptions{IncludeStart: true}, 0},
+	}
+	for _, tt := range tests {
+		got, err := cal.CountBusinessDaysWith(tt.start, tt.end, tt.opt)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: CountBusinessDaysWith = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+	}
+	if _, err := cal.CountBusinessDaysWith(date(2025, 5, 8), date(2025, 5, 7), Inclusive); err == nil {
+		t.Error("end < start でエラーにならない")
+	}
+}
+
+func TestCountBusinessDaysRanges(t *testing.T) {
+	cal := mustJapan(t)
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo を読み込めない: %v", err)
+	}
+	rules := NewJapanCalendarAsOf(nil, date(2025, 6, 1))
+	tests := []struct {
+		name       string
+		cal        *Calendar
+		start, end time.Time
+		want       int
+	}{
+		// 時刻は無視して日付だけで数える
+		{"23:59 から 0:00", cal, time.Date(2025, 5, 7, 23, 59, 0, 0, jst), time.Date(2025, 5, 9, 0, 0, 0, 0, jst), 3},
+		{"同じ日の時刻違い", cal, time.Date(2025, 5, 7, 18, 0, 0, 0, jst), time.Date(2025, 5, 7, 9, 0, 0, 0, jst), 1},
+		// 年末年始をまたぐ (2026-01-01~03 は休み)
+		{"年をまたぐ", cal, date(2025, 12, 29), date(2026, 1, 6), 5},
+		{"複数年", cal, date(2025, 1, 1), date(2026, 12, 31), 487},
+		// うるう日 (2028-02-29 は火曜)
+		{"うるう年の 2 月", rules, date(2028, 2, 1), date(2028, 2, 29), 19},
+		{"うるう日をまたぐ", rules, date(2028, 2, 28), date(2028, 3, 1), 3},
+		{"平年の 2 月", rules, date(2027, 2, 1), date(2027, 2, 28), 18},
+	}
+	for _, tt := range tests {
+		got, err := tt.cal.CountBusinessDays(tt.start, tt.end)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: CountBusinessDays = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+	}
+	if _, err := cal.CountBusinessDays(date(2025, 5, 8), date(2025, 5, 7)); err == nil {
+		t.Error("end が start より前でもエラーにならない")
+	}
+}
+
+func TestUncoveredYears(t *testing.T) {
+	cal := mustJapan(t) // 埋め込みデータは 2020・2021・2025・2026 年
+	us, _ := Lookup("us")
+	sa, _ := Lookup("sa")
+	tests := []struct {
+		name string
+		cal  *Calendar
+		want []int
+	}{
+		{"一覧のみ", cal, []int{2022, 2023, 2024, 2027}},
+		{"会社の休業日だけの年は含めない", cal.WithExtra([]HolidayEntry{{Date: date(2027, 8, 13)}}), []int{2022, 2023, 2024, 2027}},
+		{"規則で算出", us, nil},
+		{"定休日のみ", sa, nil},
+		{"組み合わせ", NewCombinedCalendar(cal, us), []int{2022, 2023, 2024, 2027}},
+	}
+	for _, tt := range tests {
+		got := tt.cal.UncoveredYears(date(2021, 6, 1), date(2027, 3, 31))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: UncoveredYears = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckCoverage(t *testing.T) {
+	cal := mustJapan(t)
+	us, _ := Lookup("us")
+	start, end := date(2021, 6, 1), date(2027, 3, 31)
+	err := cal.CheckCoverage(end, start)
+	var uncovered *UncoveredError
+	if !errors.As(err, &uncovered) || !slices.Equal(uncovered.Years, []int{2022, 2023, 2024, 2027}) {
+		t.Fatalf("CheckCoverage = %v, want 2022~2024・2027 年の UncoveredError", err)
+	}
+	if err := cal.CheckCoverage(date(2025, 1, 1), date(2026, 12, 31)); err != nil {
+		t.Errorf("データのある年の CheckCoverage = %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		cal  *Calendar
+	}{
+		{"一覧のみ", cal.WithJapaneseFallback()},
+		{"組み合わせ", NewCombinedCalendar(cal, us).WithJapaneseFallback()},
+	} {
+		if err := tt.cal.CheckCoverage(start, end); err != nil {
+			t.Errorf("%s: 規則で補った後の CheckCoverage = %v", tt.name, err)
+		}
+		for _, d := range []time.Time{
+			date(2024, 5, 6), // データのない年は規則で算出 (振替休日)
+			date(2027, 1, 1), // 元日
+			date(2025, 1, 2), // データのある年は一覧のまま (年始休み)
+		} {
+			if tt.cal.IsBusinessDay(d) {
+				t.Errorf("%s: IsBusinessDay(%s) = true, want false", tt.name, d.Format("2006-01-02"))
+			}
+		}
+	}
+	if sa, _ := Lookup("sa"); sa.WithJapaneseFallback() != sa {
+		t.Error("祝日のないカレンダーの WithJapaneseFallback が元のカレンダーを返さない")
+	}
+}
+
+func TestParseWorkHours(t *testing.T) {
+	h, err := ParseWorkHours("9:30-17:30", "12:00-12:45")
+	if err != nil {
+		t.Fatalf("ParseWorkHours: %v", err)
+	}
+	if h.Start != 9*time.Hour+30*time.Minute || h.End != 17*time.Hour+30*time.Minute || h.Duration() != 7*time.Hour+15*time.Minute {
+		t.Errorf("ParseWorkHours = %+v (%s)", h, h.Duration())
+	}
+	if h, _ := ParseWorkHours("10:00-16:00", ""); h.Duration() != 6*time.Hour {
+		t.Errorf("休憩なしの Duration = %s", h.Duration())
+	}
+	for _, tt := range [][2]string{{"18:00-9:00", ""}, {"9:00", ""}, {"9:00-18:00", "8:00-9:30"}} {
+		if _, err := ParseWorkHours(tt[0], tt[1]); err == nil {
+			t.Errorf("ParseWorkHours(%q, %q) でエラーにならない", tt[0], tt[1])
+		}
+	}
+}
+
+func TestWithBitmapCache(t *testing.T) {
+	plain := mustJapan(t).WithClosureRules(ClosureRule{Weekday: time.Wednesday, Nth: 2})
+	cached := plain.WithBitmapCache()
+	jst := time.FixedZone("JST", 9*60*60)
+	for d := date(2019, 12, 1); d.Before(date(2028, 1, 31)); d = d.AddDate(0, 0, 1) {
+		local := time.Date(d.Year(), d.Month(), d.Day(), 23, 30, 0, 0, jst)
+		if got, want := cached.IsBusinessDay(local), plain.IsBusinessDay(local); got != want {
+			t.Fatalf("IsBusinessDay(%s) = %v, want %v", local.Format("2006-01-02"), got, want)
+		}
+	}
+	ranges := [][2]time.Time{
+		{date(2025, 1, 1), date(2025, 12, 31)},
+		{date(2025, 3, 3), date(2025, 3, 3)},
+		{date(2024, 12, 28), date(2026, 1, 5)},
+		{date(2020, 2, 27), date(2020, 3, 2)}, // うるう日をまたぐ
+		{date(2025, 1, 1), date(2027, 12, 31)},
+	}
+	for _, r := range ranges {
+		got, _ := cached.CountBusinessDays(r[0], r[1])
+		want, _ := plain.CountBusinessDays(r[0], r[1])
+		if got != want {
+			t.Errorf("CountBusinessDays(%s, %s) = %d, want %d", r[0].Format("2006-01-02"), r[1].Format("2006-01-02"), got, want)
+		}
+	}
+	// 重ねたカレンダーは元のビットマップを使わない
+	if s := date(2025, 6, 7); !cached.WithWeekend().IsBusinessDay(s) {
+		t.Errorf("WithWeekend() の土曜が休業日のまま")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	for _, s := range []string{
+		"2025-05-07", "2025-5-7", "2025/05/07", "2025/5/7", "20250507", " 2025-05-07 ",
+		"2025-05-07T23:30:00+09:00", "2025-05-07T00:00:00Z", "2025-05-07T12:00:00.123-05:00",
+	} {
+		got, err := ParseDate(s, jst)
+		if err != nil {
+			t.Errorf("ParseDate(%q) = %v", s, err)
+			continue
+		}
+		if want := time.Date(2025, 5, 7, 0, 0, 0, 0, jst); !got.Equal(want) || got.Location() != jst {
+			t.Errorf("ParseDate(%q) = %v, want %v", s, got, want)
+		}
+	}
+	for _, s := range []string{"", "2025-13-01", "2025-02-30", "2025.05.07", "250507", "2025-05-07 10:00", "明日"} {
+		_, err := ParseDate(s, jst)
+		if err == nil {
+			t.Errorf("ParseDate(%q) でエラーにならない", s)
+			continue
+		}
+		// エラーには受け付ける書式を並べる
+		if !strings.Contains(err.Error(), "2006/01/02") || !strings.Contains(err.Error(), "RFC3339") {
+			t.Errorf("ParseDate(%q) のエラーに書式がない: %v", s, err)
+		}
+	}
+}
+
+func TestCivilDay(t *testing.T) {
+	// time パッケージでの計算と、紀元前を含む広い範囲で一致する
+	for d := date(-500, 1, 1); d.Before(date(3000, 1, 1)); d = d.AddDate(0, 0, 1) {
+		y, m, dd := d.Date()
+		want := int32(d.Unix() / 86400)
+		if d.Unix() < 0 && d.Unix()%86400 != 0 {
+			want--
+		}
+		if got := civilDay(y, m, dd); got != want {
+			t.Fatalf("civilDay(%s) = %d, want %d", d.Format("2006-01-02"), got, want)
+		}
+		if got := weekdayOf(want); got != d.Weekday() {
+			t.Fatalf("weekdayOf(%s) = %v, want %v", d.Format("2006-01-02"), got, d.Weekday())
+		}
+		if dd == 1 {
+			if got, want := daysIn(y, m), d.AddDate(0, 1, -1).Day(); got != want {
+				t.Fatalf("daysIn(%d, %d) = %d, want %d", y, m, got, want)
+			}
+		}
+	}
+}
+
+// TestHotPathAllocs は営業日の判定と数え上げがメモリを確保しないことを確かめる (大量に呼ぶバッチ処理の性能の退行の防止)
+func TestHotPathAllocs(t *testing.T) {
+	cal := mustJapan(t)
+	for _, bc := range []struct {
+		name string
+		cal  *Calendar
+	}{{"loop", cal}, {"bitmap", cal.WithBitmapCache()}} {
+		// 初回に年ごとのキャッシュを作る分は数えない
+		bc.cal.CountBusinessDays(date(2020, 1, 1), date(2029, 12, 31))
+		if n := testing.AllocsPerRun(100, func() { bc.cal.IsBusinessDay(date(2025, 5, 7)) }); n != 0 {
+			t.Errorf("%s: IsBusinessDay のメモリ確保 = %v 回, want 0", bc.name, n)
+		}
+		if n := testing.AllocsPerRun(100, func() { bc.cal.CountBusinessDays(date(2020, 1, 1), date(2029, 12, 31)) }); n != 0 {
+			t.Errorf("%s: CountBusinessDays のメモリ確保 = %v 回, want 0", bc.name, n)
+		}
+	}
+}
+
+func BenchmarkIsBusinessDay(b *testing.B) {
+	entries, err := DefaultHolidays()
+	if err != nil {
+		b.Fatal(err)
+	}
+	cal := NewJapanCalendarAsOf(entries, date(2025, 6, 1))
+	for _, bc := range []struct {
+		name string
+		cal  *Calendar
+	}{{"loop", cal}, {"bitmap", cal.WithBitmapCache()}} {
+		b.Run(bc.name, func(b *testing.B) {
+			// 日付の生成を計測に含めないよう 2 年分を先に作っておく
+			days := make([]time.Time, 730)
+			for i := range days {
+				days[i] = date(2025, 1, 1).AddDate(0, 0, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bc.cal.IsBusinessDay(days[i%len(days)])
+			}
+		})
+	}
+}
+
+func BenchmarkCountBusinessDays(b *testing.B) {
+	entries, err := DefaultHolidays()
+	if err != nil {
+		b.Fatal(err)
+	}
+	cal := NewJapanCalendarAsOf(entries, date(2025, 6, 1))
+	for _, bc := range []struct {
+		name string
+		cal  *Calendar
+	}{{"loop", cal}, {"bitmap", cal.WithBitmapCache()}} {
+		for _, r := range []struct {
+			name     string
+			from, to time.Time
+		}{
+			{"1y", date(2025, 1, 1), date(2025, 12, 31)},
+			{"10y", date(2020, 1, 1), date(2029, 12, 31)},
+		} {
+			b.Run(bc.name+"/"+r.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					bc.cal.CountBusinessDays(r.from, r.to)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkLoadHolidays(b *testing.B) {
+	b.Run("parse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := DefaultHolidays(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	entries, err := DefaultHolidays()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("calendar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewJapanCalendarAsOf(entries, date(2025, 6, 1))
+		}
+	})
+}
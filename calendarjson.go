@@ -0,0 +1,219 @@
+package bizday
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// calendarJSON は Calendar の JSON 表現
+//
+// フィールドの並びは構造体の定義順で固定し、祝日・振替出勤日は日付順に書き出す
+//
+//	{
+//	  "schema_version": 1,
+//	  "holidays": [{"date": "2025-01-01", "name": "元日"}],
+//	  "workdays": ["2025-01-04"],
+//	  "weekend": ["sat", "sun"],
+//	  "hours": {"start": "09:00", "end": "18:00", "break_start": "12:00", "break_end": "13:00"}
+//	}
+type calendarJSON struct {
+	SchemaVersion int           `json:"schema_version"`
+	Holidays      []holidayJSON `json:"holidays"`
+	Workdays      []string      `json:"workdays,omitempty"`
+	Weekend       []string      `json:"weekend,omitempty"`
+	Hours         hoursJSON     `json:"hours"`
+}
+
+type holidayJSON struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+type hoursJSON struct {
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	BreakStart string `json:"break_start"`
+	BreakEnd   string `json:"break_end"`
+}
+
+// weekdayNames は JSON で使う曜日の略称
+var weekdayNames = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Expand は Generate の規則で from~to 年の祝日を算出して祝日一覧に展開した Calendar を返す
+// 繰り返しの休業日の規則 (WithClosureRules) に当てはまる日も同じく展開し、WithCustomRule で分類を変えた日は祝日か振替出勤日にする
+// NewCombinedCalendar で組み合わせたカレンダーは、組み合わせた元のカレンダーで判定した営業日のとおりに展開する
+// 展開後の Calendar は Generate を持たないので、JSON にしてそのまま受け渡せる
+func (c *Calendar) Expand(from, to int) *Calendar {
+	n := *c
+	n.bitmaps = nil
+	n.Generate = nil
+	n.genCache = nil
+	n.entries = nil
+	n.extra = nil
+	n.closures = nil
+	n.closureRules = nil
+	n.customRules = nil
+	n.names = map[int32]string{}
+	for k, v := range c.names {
+		n.names[k] = v
+	}
+	n.holidays = c.holidays.clone()
+	if c.Generate != nil {
+		for y := from; y <= to; y++ {
+			for _, h := range c.Generate(y) {
+				if n.IsHoliday(h.Date) {
+					continue
+				}
+				n.holidays[epochDay(h.Date)] = struct{}{}
+				if h.Name != "" {
+					n.names[epochDay(h.Date)] = h.Name
+				}
+			}
+		}
+	}
+	if c.closureRules != nil {
+		for d := date(from, time.January, 1); d.Year() <= to; d = d.AddDate(0, 0, 1) {
+			if r, ok := c.closureRuleOn(d); ok && !n.IsHoliday(d) {
+				n.holidays[epochDay(d)] = struct{}{}
+				if r.Name != "" {
+					n.names[epochDay(d)] = r.Name
+				}
+			}
+		}
+	}
+	// 組み合わせたカレンダーは元のカレンダーそれぞれの判定 (元のカレンダーの WithCustomRule など) で営業日が決まるので、
+	// 元のカレンダーで判定した結果に合わせて祝日か振替出勤日にする
+	if c.customRules != nil || c.members != nil {
+		n.members = nil
+		n.workdays = n.workdays.clone()
+		for d := date(from, time.January, 1); d.Year() <= to; d = d.AddDate(0, 0, 1) {
+			business := c.IsBusinessDay(d)
+			if business == n.IsBusinessDay(d) {
+				continue
+			}
+			if business {
+				n.workdays[epochDay(d)] = struct{}{}
+			} else {
+				n.holidays[epochDay(d)] = struct{}{}
+				delete(n.workdays, epochDay(d))
+			}
+		}
+	}
+	return &n
+}
+
+// formatDay は epochDay の day を 2025-05-07 の形式にする
+func formatDay(day int32) string {
+	y, m, d := dayFromEpoch(day)
+	return fmt.Sprintf("%04d-%02d-%02d", y, m, d)
+}
+
+// MarshalJSON は祝日・振替出勤日・定休日・営業時間を JSON にする
+// 生成規則 (Generate) は関数なので書き出せない。先に Expand で対象の年を展開しておくこと
+func (c *Calendar) MarshalJSON() ([]byte, error) {
+	if c.Generate != nil {
+		return nil, errors.New("生成規則を持つカレンダーは Expand で年を展開してから JSON にしてください")
+	}
+
+	v := calendarJSON{
+		SchemaVersion: SchemaVersion,
+		Holidays:      make([]holidayJSON, 0, len(c.holidays)),
+		Hours: hoursJSON{
+			Start:      formatClock(c.Hours.Start),
+			End:        formatClock(c.Hours.End),
+			BreakStart: formatClock(c.Hours.BreakStart),
+			BreakEnd:   formatClock(c.Hours.BreakEnd),
+		},
+	}
+	for _, day := range c.holidays.sorted() {
+		v.Holidays = append(v.Holidays, holidayJSON{Date: formatDay(day), Name: c.names[day]})
+	}
+	for _, day := range c.workdays.sorted() {
+		v.Workdays = append(v.Workdays, formatDay(day))
+	}
+	for _, w := range c.Weekend {
+		v.Weekend = append(v.Weekend, weekdayNames[w])
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON は MarshalJSON の形式の JSON から Calendar を組み立てる
+// weekend を省略すると土日、hours を省略すると DefaultWorkHours になる
+func (c *Calendar) UnmarshalJSON(data []byte) error {
+	var v calendarJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(v.SchemaVersion); err != nil {
+		return err
+	}
+
+	n := Calendar{Hours: DefaultWorkHours, holidays: daySet{}, workdays: daySet{}, names: map[int32]string{}}
+	for _, h := range v.Holidays {
+		d, err := time.Parse("2006-01-02", h.Date)
+		if err != nil {
+			return fmt.Errorf("祝日のパースに失敗: %s", h.Date)
+		}
+		n.holidays[epochDay(d)] = struct{}{}
+		if h.Name != "" {
+			n.names[epochDay(d)] = h.Name
+		}
+	}
+	for _, s := range v.Workdays {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return fmt.Errorf("振替出勤日のパースに失敗: %s", s)
+		}
+		n.workdays[epochDay(d)] = struct{}{}
+	}
+	for _, s := range v.Weekend {
+		w, err := parseWeekdayName(s)
+		if err != nil {
+			return err
+		}
+		n.Weekend = append(n.Weekend, w)
+	}
+	if v.Hours != (hoursJSON{}) {
+		var err error
+		if n.Hours, err = v.Hours.workHours(); err != nil {
+			return err
+		}
+	}
+	*c = n
+	return nil
+}
+
+func (h hoursJSON) workHours() (WorkHours, error) {
+	var w WorkHours
+	fields := []struct {
+		s   string
+		dst *time.Duration
+	}{
+		{h.Start, &w.Start}, {h.End, &w.End}, {h.BreakStart, &w.BreakStart}, {h.BreakEnd, &w.BreakEnd},
+	}
+	for _, f := range fields {
+		t, err := time.Parse("15:04", f.s)
+		if err != nil {
+			return WorkHours{}, fmt.Errorf("営業時間のパースに失敗: %s", f.s)
+		}
+		*f.dst = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return w, nil
+}
+
+func parseWeekdayName(s string) (time.Weekday, error) {
+	for i, name := range weekdayNames {
+		if s == name {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("未知の曜日: %s (sun, mon, tue, wed, thu, fri, sat)", s)
+}
+
+// formatClock は 0:00 からの経過時間を "26:00" のような 24 時超えも許す表記にする
+func formatClock(d time.Duration) string {
+	m := int(d.Minutes())
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
@@ -0,0 +1,82 @@
+package bizday
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarJSONRoundTrip(t *testing.T) {
+	us, _ := Lookup("us")
+	if _, err := json.Marshal(us); err == nil {
+		t.Fatal("生成規則を持つカレンダーの Marshal がエラーにならない")
+	}
+
+	b, err := json.Marshal(us.Expand(2025, 2026))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"schema_version":1`) {
+		t.Errorf("schema_version がない: %s", b)
+	}
+
+	var got Calendar
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.IsBusinessDay(date(2026, 7, 3)) || !got.IsBusinessDay(date(2026, 7, 6)) {
+		t.Error("復元したカレンダーの祝日が違う")
+	}
+	again, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(b) {
+		t.Errorf("再度 Marshal した結果が一致しない\n%s\n%s", b, again)
+	}
+}
+
+func TestExpandCombined(t *testing.T) {
+	typhoon := date(2025, 6, 10)
+	closed := NewCalendar(nil).WithCustomRule(func(d time.Time) DayClass {
+		if d.Equal(typhoon) {
+			return ClassHoliday
+		}
+		return ""
+	})
+	us, _ := Lookup("us")
+	b, err := json.Marshal(NewCombinedCalendar(closed, us).Expand(2025, 2025))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Calendar
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	// 元のカレンダーの WithCustomRule で休業日にした日も、展開した JSON に祝日として残る
+	for _, d := range []time.Time{typhoon, date(2025, 7, 4)} {
+		if got.IsBusinessDay(d) {
+			t.Errorf("展開した組み合わせのカレンダーで %s が営業日になる", d.Format(time.DateOnly))
+		}
+	}
+	if !got.IsBusinessDay(date(2025, 6, 11)) {
+		t.Error("展開した組み合わせのカレンダーで 2025-06-11 が休業日になる")
+	}
+}
+
+func TestCalendarJSONDefaults(t *testing.T) {
+	var cal Calendar
+	if err := json.Unmarshal([]byte(`{"holidays": [], "weekend": ["fri", "sat"]}`), &cal); err != nil {
+		t.Fatal(err)
+	}
+	if cal.Hours != DefaultWorkHours {
+		t.Errorf("hours を省略したときの営業時間 = %+v", cal.Hours)
+	}
+	if !cal.IsWeekend(date(2025, 5, 2)) {
+		t.Error("weekend の指定が反映されない")
+	}
+	if err := json.Unmarshal([]byte(`{"schema_version": 99}`), &cal); err == nil {
+		t.Error("未対応の schema_version でエラーにならない")
+	}
+}
@@ -0,0 +1,37 @@
+package bizday
+
+import "time"
+
+// DayClass は日の分類
+type DayClass string
+
+const (
+	ClassBusiness DayClass = "business" // 営業日
+	ClassWeekend  DayClass = "weekend"  // 定休日
+	ClassHoliday  DayClass = "holiday"  // 祝日
+	ClassWorkday  DayClass = "workday"  // 振替出勤日 (定休日・祝日だが営業日)
+)
+
+// Classify は t の日付の分類と、祝日ならその名前を返す
+// 定休日に重なる祝日は ClassWeekend になるが、name には祝日名が入る
+// WithCustomRule の関数が分類を返した日はその分類になる
+func (c *Calendar) Classify(t time.Time) (class DayClass, name string) {
+	h, holiday := c.holidayOn(t)
+	if class, ok := c.customClass(t); ok {
+		return class, h.Name
+	}
+	if class, ok := c.memberClass(t); ok {
+		return class, h.Name
+	}
+	switch {
+	case c.IsWorkday(t) && (holiday || c.IsWeekend(t)):
+		class = ClassWorkday
+	case c.IsWeekend(t):
+		class = ClassWeekend
+	case holiday:
+		class = ClassHoliday
+	default:
+		class = ClassBusiness
+	}
+	return class, h.Name
+}
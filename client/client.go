@@ -0,0 +1,362 @@
+// Package client は bizday serve の HTTP API を呼ぶ Go のクライアント
+// 応答の型と各メソッドは serve が /openapi.json で公開する定義 (cmd/bizday/openapi.json) に合わせている
+//
+//	c := client.New("http://localhost:8080")
+//	res, err := c.IsBusinessDay(ctx, time.Now())
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// Client は bizday serve の API のクライアント
+type Client struct {
+	// BaseURL は serve の URL (例: http://localhost:8080)
+	BaseURL string
+	// Calendar は問い合わせるカレンダー名 (空なら serve の --calendar)
+	Calendar string
+	// HTTPClient は要求に使う http.Client (nil なら http.DefaultClient)
+	HTTPClient *http.Client
+	// APIKey は Authorization: Bearer で送る serve --keys のキー (空なら送らない)
+	APIKey string
+}
+
+// New は baseURL の serve を呼ぶ Client を作る
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// APIError は serve がエラーの応答を返したときのエラー
+type APIError struct {
+	StatusCode int
+	Message    string // 応答の error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bizday: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsBusinessDay は /v1/is-business-day の応答
+type IsBusinessDay struct {
+	SchemaVersion int             `json:"schema_version"`
+	Calendar      string          `json:"calendar"`
+	Date          string          `json:"date"`
+	BusinessDay   bool            `json:"business_day"`
+	Class         bizday.DayClass `json:"class"`
+	Name          string          `json:"name,omitempty"`
+}
+
+// Add は /v1/add の応答
+type Add struct {
+	SchemaVersion int    `json:"schema_version"`
+	Calendar      string `json:"calendar"`
+	Date          string `json:"date"`
+	N             int    `json:"n"`
+	Result        string `json:"result"`
+}
+
+// Range は /v1/count の応答
+type Range struct {
+	SchemaVersion int        `json:"schema_version"`
+	Calendar      string     `json:"calendar"`
+	From          string     `json:"from"`
+	To            string     `json:"to"`
+	BusinessDays  int        `json:"business_days"`
+	Hours         float64    `json:"hours"`
+	Breakdown     *Breakdown `json:"breakdown,omitempty"`
+}
+
+// Breakdown は期間の日数の内訳 (bizday.RangeBreakdown と同じ項目)
+type Breakdown struct {
+	TotalDays    int `json:"total_days"`
+	WeekendDays  int `json:"weekend_days"`
+	Holidays     int `json:"holidays"`
+	Closures     int `json:"closures"`
+	Workdays     int `json:"workdays"`
+	BusinessDays int `json:"business_days"`
+}
+
+// Holiday は祝日 1 件
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// MonthSummary は /v1/month-summary の応答 (bizday summary --json と同じ)
+type MonthSummary struct {
+	SchemaVersion          int        `json:"schema_version"`
+	Calendar               string     `json:"calendar"`
+	Date                   string     `json:"date"`
+	Month                  string     `json:"month"`
+	BusinessDayIndex       int        `json:"business_day_index"`
+	BusinessDayIndexLabel  string     `json:"business_day_index_label"`
+	BusinessDaysTotal      int        `json:"business_days_total"`
+	BusinessDaysRemaining  int        `json:"business_days_remaining"`
+	PercentElapsed         float64    `json:"percent_elapsed"`
+	RemainingHours         float64    `json:"remaining_hours"`
+	WorkedHours            float64    `json:"worked_hours"`
+	CalendarDaysTotal      int        `json:"calendar_days_total"`
+	CalendarDaysElapsed    int        `json:"calendar_days_elapsed"`
+	CalendarDaysRemaining  int        `json:"calendar_days_remaining"`
+	CalendarPercentElapsed float64    `json:"calendar_percent_elapsed"`
+	Holidays               []Holiday  `json:"holidays"`
+	UpcomingHolidays       []Holiday  `json:"upcoming_holidays,omitempty"`
+	Breakdown              *Breakdown `json:"breakdown,omitempty"`
+}
+
+// BatchItem は Batch でまとめて送る問い合わせ 1 件 (Op ごとに同じ名前のメソッドと同じ項目を使う)
+type BatchItem struct {
+	Op        string `json:"op"`                 // is-business-day, add, count, month-summary
+	Calendar  string `json:"calendar,omitempty"` // 空なら Client の Calendar
+	Date      string `json:"date,omitempty"`
+	N         int    `json:"n,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	Breakdown bool   `json:"breakdown,omitempty"`
+	Month     string `json:"month,omitempty"`
+}
+
+// BatchResult は問い合わせ 1 件の結果 (Op に応じた項目か、問い合わせに誤りがあれば Error のどれか 1 つだけ)
+type BatchResult struct {
+	IsBusinessDay *IsBusinessDay `json:"is_business_day,omitempty"`
+	Add           *Add           `json:"add,omitempty"`
+	Count         *Range         `json:"count,omitempty"`
+	MonthSummary  *MonthSummary  `json:"month_summary,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// Batch は /v1/batch の応答 (Results は送った問い合わせと同じ順)
+type Batch struct {
+	SchemaVersion int           `json:"schema_version"`
+	Results       []BatchResult `json:"results"`
+}
+
+// Reload は /reload の応答
+type Reload struct {
+	SchemaVersion int      `json:"schema_version"`
+	Source        string   `json:"source"`
+	Calendars     []string `json:"calendars"`
+}
+
+// Override は admin API で実行中に足す休業日・営業日 1 件
+type Override struct {
+	Date     string `json:"date"`
+	Calendar string `json:"calendar,omitempty"` // 空なら全カレンダー
+	Kind     string `json:"kind"`               // holiday (休業日) か workday (営業日)
+	Name     string `json:"name,omitempty"`
+}
+
+// Overrides は /admin/v1/overrides の応答
+type Overrides struct {
+	SchemaVersion int        `json:"schema_version"`
+	Overrides     []Override `json:"overrides"`
+}
+
+// IsBusinessDay は date が営業日かどうかを問い合わせる (date がゼロ値なら serve の今日)
+func (c *Client) IsBusinessDay(ctx context.Context, date time.Time) (*IsBusinessDay, error) {
+	q := c.query()
+	setDate(q, "date", date)
+	var out IsBusinessDay
+	if err := c.do(ctx, http.MethodGet, "/v1/is-business-day", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Add は date の n 営業日後 (負なら前) の日付を問い合わせる (date がゼロ値なら serve の今日)
+func (c *Client) Add(ctx context.Context, date time.Time, n int) (*Add, error) {
+	q := c.query()
+	setDate(q, "date", date)
+	q.Set("n", strconv.Itoa(n))
+	var out Add
+	if err := c.do(ctx, http.MethodGet, "/v1/add", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Count は from~to (両端含む) の営業日数を問い合わせる (breakdown なら内訳も)
+func (c *Client) Count(ctx context.Context, from, to time.Time, breakdown bool) (*Range, error) {
+	if from.IsZero() || to.IsZero() {
+		return nil, errors.New("bizday: from と to は両方指定してください")
+	}
+	q := c.query()
+	setDate(q, "from", from)
+	setDate(q, "to", to)
+	if breakdown {
+		q.Set("breakdown", "true")
+	}
+	var out Range
+	if err := c.do(ctx, http.MethodGet, "/v1/count", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MonthSummary は month を含む月の営業日の経過状況を問い合わせる (month がゼロ値なら serve の今月)
+func (c *Client) MonthSummary(ctx context.Context, month time.Time) (*MonthSummary, error) {
+	q := c.query()
+	if !month.IsZero() {
+		q.Set("month", month.Format("2006-01"))
+	}
+	var out MonthSummary
+	if err := c.do(ctx, http.MethodGet, "/v1/month-summary", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EffectiveCalendar は serve が実際に使うカレンダー (admin API で足した休業日・営業日を含む) を取得する
+// 規則で算出する祝日は from~to 年の分が入る (from がゼロなら serve の今年、to がゼロなら from と同じ年)
+// 返ったカレンダーは serve に問い合わせずに営業日の判定に使える
+func (c *Client) EffectiveCalendar(ctx context.Context, from, to int) (*bizday.Calendar, error) {
+	q := c.query()
+	if from != 0 {
+		q.Set("from", strconv.Itoa(from))
+	}
+	if to != 0 {
+		q.Set("to", strconv.Itoa(to))
+	}
+	var out bizday.Calendar
+	if err := c.do(ctx, http.MethodGet, "/v1/calendar", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Batch は items の問い合わせをまとめて送る (serve は並行に答え、1 回に送れるのは 10000 件まで)
+// 誤りのある問い合わせはその結果の Error に入り、ほかの問い合わせの結果は返る
+func (c *Client) Batch(ctx context.Context, items []BatchItem) (*Batch, error) {
+	req := struct {
+		Requests []BatchItem `json:"requests"`
+	}{Requests: make([]BatchItem, len(items))}
+	for i, it := range items {
+		if it.Calendar == "" {
+			it.Calendar = c.Calendar
+		}
+		req.Requests[i] = it
+	}
+	var out Batch
+	if err := c.do(ctx, http.MethodPost, "/v1/batch", url.Values{}, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Reload は serve に祝日データを読み込み直させる
+func (c *Client) Reload(ctx context.Context) (*Reload, error) {
+	var out Reload
+	if err := c.do(ctx, http.MethodPost, "/reload", url.Values{}, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Overrides は admin API で足した休業日・営業日の一覧を返す (APIKey に admin のキーが必要)
+func (c *Client) Overrides(ctx context.Context) (*Overrides, error) {
+	var out Overrides
+	if err := c.do(ctx, http.MethodGet, "/admin/v1/overrides", url.Values{}, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetOverride は休業日・営業日 o を足し (同じカレンダーと日付のものは置き換え)、足した後の一覧を返す
+func (c *Client) SetOverride(ctx context.Context, o Override) (*Overrides, error) {
+	var out Overrides
+	if err := c.do(ctx, http.MethodPost, "/admin/v1/overrides", url.Values{}, o, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteOverride は calendar (空なら全カレンダー) に足した date の休業日・営業日を取り除き、取り除いた後の一覧を返す
+func (c *Client) DeleteOverride(ctx context.Context, calendar string, date time.Time) (*Overrides, error) {
+	q := url.Values{}
+	if calendar != "" {
+		q.Set("calendar", calendar)
+	}
+	var out Overrides
+	if err := c.do(ctx, http.MethodDelete, "/admin/v1/overrides/"+date.Format("2006-01-02"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// query は Calendar を入れたクエリを返す
+func (c *Client) query() url.Values {
+	q := url.Values{}
+	if c.Calendar != "" {
+		q.Set("calendar", c.Calendar)
+	}
+	return q
+}
+
+// setDate は t がゼロ値でなければ、t の日付をクエリの key に入れる
+func setDate(q url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(key, t.Format("2006-01-02"))
+	}
+}
+
+// do は path に要求 (in が nil でなければその JSON を本文に) を送り、2xx なら応答を out に読み込む (それ以外は *APIError を返す)
+func (c *Client) do(ctx context.Context, method, path string, q url.Values, in, out any) error {
+	u := c.BaseURL + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(b, &e) != nil || e.Error == "" {
+			e.Error = strings.TrimSpace(string(b))
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: e.Error}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("bizday: %s の応答を読めません: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/count":
+			w.Write([]byte(`{"schema_version":1,"calendar":"us","from":"2025-05-01","to":"2025-05-31","business_days":21,"hours":168,"breakdown":{"total_days":31,"weekend_days":9,"holidays":1,"closures":0,"workdays":0,"business_days":21}}`))
+		case "/v1/add":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"n には営業日数を整数で指定してください"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL + "/")
+	c.Calendar = "us"
+	ctx := context.Background()
+	r, err := c.Count(ctx, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), true)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if q := got.URL.Query(); q.Get("calendar") != "us" || q.Get("from") != "2025-05-01" || q.Get("to") != "2025-05-31" || q.Get("breakdown") != "true" {
+		t.Errorf("Count のクエリ = %s", got.URL.RawQuery)
+	}
+	if r.BusinessDays != 21 || r.Breakdown == nil || r.Breakdown.Holidays != 1 {
+		t.Errorf("Count = %+v", r)
+	}
+
+	_, err = c.Add(ctx, time.Time{}, 3)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "n には営業日数を整数で指定してください" {
+		t.Errorf("Add のエラー = %v, want 400 の APIError", err)
+	}
+	if q := got.URL.Query(); q.Has("date") || q.Get("n") != "3" {
+		t.Errorf("Add のクエリ = %s (date を省略して n=3)", got.URL.RawQuery)
+	}
+
+	if _, err := c.Reload(ctx); err == nil || got.Method != http.MethodPost {
+		t.Errorf("Reload: %v (method %s)", err, got.Method)
+	}
+}
+
+func TestClientOverride(t *testing.T) {
+	var got *http.Request
+	var body Override
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		if r.Body != nil {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schema_version":1,"overrides":[{"date":"2025-09-01","kind":"holiday","name":"臨時休業"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	c.APIKey = "secret"
+	ctx := context.Background()
+	r, err := c.SetOverride(ctx, Override{Date: "2025-09-01", Kind: "holiday", Name: "臨時休業"})
+	if err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+	if got.Method != http.MethodPost || got.URL.Path != "/admin/v1/overrides" || got.Header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("SetOverride の要求 = %s %s (Authorization %q)", got.Method, got.URL.Path, got.Header.Get("Authorization"))
+	}
+	if body.Date != "2025-09-01" || body.Kind != "holiday" || len(r.Overrides) != 1 {
+		t.Errorf("SetOverride の本文 = %+v, 応答 = %+v", body, r)
+	}
+
+	if _, err := c.DeleteOverride(ctx, "jp", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("DeleteOverride: %v", err)
+	}
+	if got.Method != http.MethodDelete || got.URL.Path != "/admin/v1/overrides/2025-09-01" || got.URL.Query().Get("calendar") != "jp" {
+		t.Errorf("DeleteOverride の要求 = %s %s", got.Method, got.URL)
+	}
+}
+
+func TestClientBatch(t *testing.T) {
+	var body struct {
+		Requests []BatchItem `json:"requests"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/batch" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schema_version":1,"results":[{"is_business_day":{"schema_version":1,"calendar":"us","date":"2025-07-04","business_day":false,"class":"holiday","name":"Independence Day"}},{"error":"n には営業日数を整数で指定してください"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	c.Calendar = "us"
+	res, err := c.Batch(context.Background(), []BatchItem{
+		{Op: "is-business-day", Date: "2025-07-04"},
+		{Op: "add", Calendar: "jp", Date: "2025-05-02", N: 1},
+	})
+	if err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+	if len(body.Requests) != 2 || body.Requests[0].Calendar != "us" || body.Requests[1].Calendar != "jp" {
+		t.Errorf("Batch の本文 = %+v (calendar を省略したら Client の Calendar)", body.Requests)
+	}
+	if len(res.Results) != 2 || res.Results[0].IsBusinessDay == nil || res.Results[0].IsBusinessDay.BusinessDay || res.Results[1].Error == "" {
+		t.Errorf("Batch = %+v", res.Results)
+	}
+}
+
+func TestClientEffectiveCalendar(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schema_version":1,"holidays":[{"date":"2025-07-04","name":"Independence Day"}],"weekend":["sat","sun"],"hours":{"start":"09:00","end":"18:00","break_start":"12:00","break_end":"13:00"}}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	c.Calendar = "us"
+	cal, err := c.EffectiveCalendar(context.Background(), 2025, 0)
+	if err != nil {
+		t.Fatalf("EffectiveCalendar: %v", err)
+	}
+	if q := got.URL.Query(); got.URL.Path != "/v1/calendar" || q.Get("calendar") != "us" || q.Get("from") != "2025" || q.Has("to") {
+		t.Errorf("EffectiveCalendar の要求 = %s?%s", got.URL.Path, got.URL.RawQuery)
+	}
+	if cal.IsBusinessDay(time.Date(2025, 7, 4, 0, 0, 0, 0, time.Local)) || !cal.IsBusinessDay(time.Date(2025, 7, 7, 0, 0, 0, 0, time.Local)) {
+		t.Error("取得したカレンダーの祝日が違う")
+	}
+}
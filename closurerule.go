@@ -0,0 +1,70 @@
+package bizday
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ClosureRule は「毎月第 2 水曜はメンテナンス休業」「毎月第 1 月曜は休み」のような繰り返しの休業日の規則
+// 日付を列挙せずに、問い合わせのあった日ごとに当てはまるかを判定するので、どの年にも使える
+type ClosureRule struct {
+	Name    string
+	Weekday time.Weekday
+	// Nth はその月の何回目の Weekday か (1~5、-1 なら最後、0 なら毎週)
+	Nth int
+	// Months は対象の月 (空なら毎月)
+	Months []time.Month
+}
+
+// Validate は規則の値が範囲内かを確認する
+func (r ClosureRule) Validate() error {
+	if r.Nth < -1 || r.Nth > 5 {
+		return fmt.Errorf("nth には 1~5、-1 (最後)、0 (毎週) のいずれかを指定してください: %d", r.Nth)
+	}
+	if r.Weekday < time.Sunday || r.Weekday > time.Saturday {
+		return fmt.Errorf("曜日が不正です: %d", r.Weekday)
+	}
+	for _, m := range r.Months {
+		if m < time.January || m > time.December {
+			return fmt.Errorf("月には 1~12 を指定してください: %d", m)
+		}
+	}
+	return nil
+}
+
+// Matches は t の日付が規則に当てはまるかどうかを判定
+func (r ClosureRule) Matches(t time.Time) bool {
+	if t.Weekday() != r.Weekday {
+		return false
+	}
+	if len(r.Months) > 0 && !slices.Contains(r.Months, t.Month()) {
+		return false
+	}
+	switch {
+	case r.Nth == 0:
+		return true
+	case r.Nth < 0:
+		return t.AddDate(0, 0, 7).Month() != t.Month()
+	}
+	return (t.Day()-1)/7+1 == r.Nth
+}
+
+// WithClosureRules は繰り返しの休業日の規則 rules を重ねた Calendar を返す
+// 規則に当てはまる日は WithExtra の休業日と同じく休業日として数える (Breakdown では祝日と重ならない日を Closures に数える)
+func (c *Calendar) WithClosureRules(rules ...ClosureRule) *Calendar {
+	n := *c
+	n.bitmaps = nil
+	n.closureRules = append(append([]ClosureRule{}, c.closureRules...), rules...)
+	return &n
+}
+
+// closureRuleOn は t の日付に当てはまる最初の繰り返しの休業日の規則を返す
+func (c *Calendar) closureRuleOn(t time.Time) (ClosureRule, bool) {
+	for _, r := range c.closureRules {
+		if r.Matches(t) {
+			return r, true
+		}
+	}
+	return ClosureRule{}, false
+}
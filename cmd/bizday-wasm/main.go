@@ -0,0 +1,114 @@
+//go:build js && wasm
+
+// bizday-wasm は営業日の計算を WebAssembly から JavaScript に公開する
+// バックエンドと同じ祝日データ・規則をブラウザのダッシュボードで使うためのもの
+//
+//	GOOS=js GOARCH=wasm go build -o bizday.wasm ./cmd/bizday-wasm
+//
+// 読み込むと globalThis.bizday に次の関数ができる (日付は "2025-05-02"・"2025/05/02"・"20250502" の形式、calendar は省略時 "jp")
+//
+//	bizday.isBusinessDay(date, calendar)         → boolean
+//	bizday.countBusinessDays(from, to, calendar) → number (from~to の両端を含む)
+//	bizday.addBusinessDays(date, n, calendar)    → string (n 営業日後の日付、負なら前)
+//	bizday.calendars()                           → string[]
+//
+// 引数の誤りは Error のオブジェクトを返す (例外は投げない)
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+	"time"
+
+	"bizday"
+	_ "bizday/data/jp"
+)
+
+func main() {
+	jp, err := bizday.Load("jp")
+	if err != nil {
+		js.Global().Get("console").Call("error", "bizday: 祝日データの読み込みに失敗しました: "+err.Error())
+		return
+	}
+	bizday.Replace("jp", jp)
+
+	js.Global().Set("bizday", js.ValueOf(map[string]any{
+		"isBusinessDay": export(1, func(cal *bizday.Calendar, args []js.Value) (any, error) {
+			d, err := argDate(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			return cal.IsBusinessDay(d), nil
+		}),
+		"countBusinessDays": export(2, func(cal *bizday.Calendar, args []js.Value) (any, error) {
+			from, err := argDate(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			to, err := argDate(args, 1)
+			if err != nil {
+				return nil, err
+			}
+			return cal.CountBusinessDays(from, to)
+		}),
+		"addBusinessDays": export(2, func(cal *bizday.Calendar, args []js.Value) (any, error) {
+			d, err := argDate(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			if args[1].Type() != js.TypeNumber {
+				return nil, fmt.Errorf("営業日数は数値で指定してください")
+			}
+			r, err := cal.AddBusinessDays(d, args[1].Int())
+			if err != nil {
+				return nil, err
+			}
+			return r.Format("2006-01-02"), nil
+		}),
+		"calendars": js.FuncOf(func(js.Value, []js.Value) any {
+			names := bizday.CalendarNames()
+			out := make([]any, len(names))
+			for i, n := range names {
+				out[i] = n
+			}
+			return out
+		}),
+	}))
+	select {}
+}
+
+// export は必須の引数が nargs 個で、その次にカレンダー名を省略可能な引数として取る JavaScript の関数を作る
+// f のエラーは JavaScript の Error にして返す
+func export(nargs int, f func(cal *bizday.Calendar, args []js.Value) (any, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) < nargs {
+			return jsError(fmt.Errorf("引数が足りません (%d 個必要)", nargs))
+		}
+		name := "jp"
+		if len(args) > nargs && args[nargs].Type() == js.TypeString {
+			name = args[nargs].String()
+		}
+		cal, ok := bizday.Lookup(name)
+		if !ok {
+			return jsError(fmt.Errorf("未知のカレンダー: %s", name))
+		}
+		v, err := f(cal, args)
+		if err != nil {
+			return jsError(err)
+		}
+		return v
+	})
+}
+
+// argDate は args[i] を bizday.ParseDate の書式の日付として読む
+func argDate(args []js.Value, i int) (time.Time, error) {
+	if args[i].Type() != js.TypeString {
+		return time.Time{}, fmt.Errorf("日付は \"2025-05-02\" のような文字列で指定してください")
+	}
+	return bizday.ParseDate(args[i].String(), time.UTC)
+}
+
+// jsError は err を JavaScript の Error オブジェクトにする
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runAdd は指定日 (省略時は今日) から n 営業日後 (負なら前) の日付を表示する
+// 支払期日のような「X の N 営業日後」を求めるのに使う
+// 既定では起点の日を数えず、-n 0 なら起点の日をそのまま表示する (--count-from same・--zero-roll で変えられる)
+func runAdd(fs *flag.FlagSet) func() error {
+	n := fs.Int("n", 1, "進める営業日数 (負の値なら前へ戻る)")
+	date := fs.String("date", "", "起点の日付 (例: 2025-04-28)、省略時は今日")
+	countFrom := fs.String("count-from", "next", "数え始める日 (next: 起点の翌営業日を 1 営業日目とする, same: 起点が営業日ならその日を 1 営業日目とする)")
+	zeroRoll := fs.String("zero-roll", "", "-n 0 で起点が休業日のときのずらし方 (following, preceding, modified-following)、省略時はずらさない")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		from, err := bizday.ParseStartCountingFrom(*countFrom)
+		if err != nil {
+			return err
+		}
+		if err := checkAddBusinessDays(*n); err != nil {
+			return err
+		}
+		opt := bizday.AddOptions{From: from}
+		if *zeroRoll != "" {
+			if opt.ZeroRoll, err = bizday.ParseRollConvention(*zeroRoll); err != nil {
+				return err
+			}
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *date != "" {
+			t, err = parseDateTime(*date)
+			if err != nil {
+				return err
+			}
+		}
+
+		d, err := cal.AddBusinessDaysWith(t, *n, opt)
+		if err != nil {
+			return err
+		}
+		if err := checkCoverage(cal, t, d); err != nil {
+			return err
+		}
+		fmt.Println(formatDate(d))
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// 実行中に足す休業日・営業日 (override) の種類
+const (
+	overrideHoliday = "holiday" // 休業日にする (臨時休業など)
+	overrideWorkday = "workday" // 祝日・定休日でも営業日にする (祝日を取り消すときも)
+)
+
+// maxOverrideBody は admin API の要求の本文の大きさの上限
+const maxOverrideBody = 1 << 16
+
+// overrideKey は override を区別するカレンダーの名前と日付
+type overrideKey struct {
+	calendar string // 空なら全カレンダー
+	date     string // 2025-09-01 の形式
+}
+
+// overrideJSON は admin API で足す休業日・営業日 1 件 (要求と応答の両方に使う)
+type overrideJSON struct {
+	Date     string `json:"date"`
+	Calendar string `json:"calendar,omitempty"` // 省略時は全カレンダー
+	Kind     string `json:"kind"`               // holiday か workday
+	Name     string `json:"name,omitempty"`     // 休業の理由など
+}
+
+// overridesJSON は GET /admin/v1/overrides などの応答
+type overridesJSON struct {
+	SchemaVersion int            `json:"schema_version"`
+	Overrides     []overrideJSON `json:"overrides"`
+}
+
+// key は o の overrideKey
+func (o overrideJSON) key() overrideKey {
+	return overrideKey{calendar: o.Calendar, date: o.Date}
+}
+
+// entry は o を祝日データの定義にする
+func (o overrideJSON) entry() bizday.HolidayEntry {
+	d, _ := time.Parse(time.DateOnly, o.Date) // checkOverride で確かめてある
+	return bizday.HolidayEntry{Date: d, Name: o.Name, Workday: o.Kind == overrideWorkday}
+}
+
+// appliesTo は o が calendarKey で正規化した名前 name のカレンダーに効くかどうか
+// カレンダーを指定した override は、そのカレンダーを含む組み合わせ (jp,us など) にも効く
+func (o overrideJSON) appliesTo(name string) bool {
+	if o.Calendar == "" {
+		return true
+	}
+	for _, n := range strings.Split(name, ",") {
+		if n == o.Calendar {
+			return true
+		}
+	}
+	return false
+}
+
+// String は監査ログに書く o の説明
+func (o overrideJSON) String() string {
+	cal := o.Calendar
+	if cal == "" {
+		cal = "*"
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s %s", cal, o.Date, o.Kind, o.Name))
+}
+
+// checkOverride は o の項目を確かめ、日付を 2025-09-01 の形式にそろえる (s.mu を持った状態か、起動中に呼ぶ)
+func (s *server) checkOverride(o *overrideJSON) error {
+	t, err := paramDate("date", o.Date, time.Time{})
+	if err != nil {
+		return err
+	}
+	if t.IsZero() {
+		return errors.New("date を指定してください")
+	}
+	o.Date = t.Format(time.DateOnly)
+	if o.Kind != overrideHoliday && o.Kind != overrideWorkday {
+		return fmt.Errorf("kind には %s か %s を指定してください: %s", overrideHoliday, overrideWorkday, o.Kind)
+	}
+	if o.Calendar != "" && !s.known[o.Calendar] {
+		return fmt.Errorf("未知のカレンダー: %s", o.Calendar)
+	}
+	return nil
+}
+
+// overridesFor は calendarKey で正規化した名前 name のカレンダーに重ねる override の定義 (s.mu を持った状態で呼ぶ)
+// 同じ日に全カレンダーのものとカレンダーを指定したものがあれば、カレンダーを指定したものを後に重ねる
+func (s *server) overridesFor(name string) []bizday.HolidayEntry {
+	var all, specific []bizday.HolidayEntry
+	for _, o := range s.overrides {
+		switch {
+		case o.Calendar == "":
+			all = append(all, o.entry())
+		case o.appliesTo(name):
+			specific = append(specific, o.entry())
+		}
+	}
+	return append(all, specific...)
+}
+
+// overrideList は override の一覧の応答 (s.mu を持った状態で呼ぶ)
+func (s *server) overrideList() overridesJSON {
+	return overridesJSON{SchemaVersion: bizday.SchemaVersion, Overrides: sortedOverrides(s.overrides)}
+}
+
+// sortedOverrides は m の override を日付・カレンダーの順に並べる
+func sortedOverrides(m map[overrideKey]overrideJSON) []overrideJSON {
+	out := make([]overrideJSON, 0, len(m))
+	for _, o := range m {
+		out = append(out, o)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.Calendar < b.Calendar
+	})
+	return out
+}
+
+// editOverrides は操作 op (journalSet か journalDelete) で override の o を書き換え、組み立て済みのカレンダーをすべて作り直して差し替える
+// --overrides-journal があれば操作を書き足してから差し替える。作り直しや書き足しに失敗したときは書き換える前に戻す
+// 監査ログには actor と操作の内容を書く
+func (s *server) editOverrides(actor, op string, o overrideJSON) (overridesJSON, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := make(map[overrideKey]overrideJSON, len(s.overrides))
+	for k, o := range s.overrides {
+		old[k] = o
+	}
+	err := s.audited(auditAdmin, actor, op+" "+o.String(), func() error {
+		applyJournalOp(s.overrides, op, o)
+		cals, err := s.rebuild()
+		if err == nil {
+			err = s.journal.append(journalEntry{Time: time.Now().Format(time.RFC3339), Actor: actor, Op: op, Override: o})
+		}
+		if err != nil {
+			s.overrides = old
+			return err
+		}
+		s.cals = cals
+		return nil
+	})
+	if err != nil {
+		return overridesJSON{}, err
+	}
+	return s.overrideList(), nil
+}
+
+// handleListOverrides は実行中に足した休業日・営業日の一覧を返す
+func (s *server) handleListOverrides(w http.ResponseWriter, r *http.Request, actor string) {
+	s.mu.Lock()
+	out := s.overrideList()
+	s.mu.Unlock()
+	writeResponse(w, http.StatusOK, out)
+}
+
+// handleSetOverride は本文の overrideJSON の休業日・営業日を足し (同じカレンダーと日付のものがあれば置き換え)、一覧を返す
+// すべての問い合わせにすぐに効く
+func (s *server) handleSetOverride(w http.ResponseWriter, r *http.Request, actor string) {
+	var o overrideJSON
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxOverrideBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&o); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("本文を読めません: %w", err))
+		return
+	}
+	s.mu.Lock()
+	err := s.checkOverride(&o)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	out, err := s.editOverrides(actor, journalSet, o)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
+
+// handleDeleteOverride はパスの日付 (と calendar) の休業日・営業日を取り除き、一覧を返す (なければ 404)
+func (s *server) handleDeleteOverride(w http.ResponseWriter, r *http.Request, actor string) {
+	t, err := paramDate("date", r.PathValue("date"), time.Time{})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	k := overrideKey{calendar: r.URL.Query().Get("calendar"), date: t.Format(time.DateOnly)}
+	s.mu.Lock()
+	o, ok := s.overrides[k]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s の休業日・営業日は足されていません", k.date))
+		return
+	}
+	out, err := s.editOverrides(actor, journalDelete, o)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"bizday"
+)
+
+// 監査ログの event に書く、カレンダーを差し替えようとしたきっかけ
+const (
+	auditSignal  = "signal"  // SIGHUP
+	auditHTTP    = "http"    // POST /reload
+	auditRefresh = "refresh" // serve --refresh で取得し直したとき
+	auditAdmin   = "admin"   // admin API で休業日・営業日を足したり取り除いたりしたとき
+)
+
+// auditHashYears は監査ログのハッシュに含める年数 (去年から数えて)
+// 去年から 5 年先までの全日の分類が同じなら、そのカレンダーは変わっていないとみなす
+const auditHashYears = 7
+
+// auditLog は serve --audit-log のファイル (1 行 1 件の JSON、追記のみ)
+// 祝日データの再読み込みと admin API での書き換えのたびに 1 件書き足す
+type auditLog struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// auditEntry は監査ログの 1 件 (カレンダーの差し替え 1 回)
+type auditEntry struct {
+	Time         string          `json:"time"`
+	Event        string          `json:"event"`
+	Actor        string          `json:"actor,omitempty"`  // admin API を呼んだキーの name
+	Detail       string          `json:"detail,omitempty"` // admin API の操作の内容
+	SourceBefore string          `json:"source_before"`
+	SourceAfter  string          `json:"source_after,omitempty"`
+	Calendars    []auditCalendar `json:"calendars"`
+	Changed      bool            `json:"changed"`         // どれかのカレンダーのハッシュが変わったか
+	Error        string          `json:"error,omitempty"` // 差し替えに失敗したときのエラー (カレンダーは変わっていない)
+}
+
+// auditCalendar は差し替えの前後のカレンダー 1 件のハッシュ (calendarHash)
+type auditCalendar struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after,omitempty"`
+}
+
+// openAuditLog は監査ログのファイルを追記用に開く (なければ作る)
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("監査ログを開けません: %w", err)
+	}
+	return &auditLog{f: f}, nil
+}
+
+// write は e を 1 行の JSON として書き足す (nil の auditLog では何もしない)
+func (a *auditLog) write(e auditEntry) error {
+	if a == nil {
+		return nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("監査ログに書けません: %w", err)
+	}
+	return a.f.Sync()
+}
+
+// Close はファイルを閉じる (nil の auditLog では何もしない)
+func (a *auditLog) Close() error {
+	if a == nil {
+		return nil
+	}
+	return a.f.Close()
+}
+
+// calendarHashes は cals の各カレンダーの calendarHash を Before に入れて名前の順に返す
+func calendarHashes(cals map[string]*bizday.Calendar, now time.Time) []auditCalendar {
+	out := make([]auditCalendar, 0, len(cals))
+	for name, cal := range cals {
+		out = append(out, auditCalendar{Name: name, Before: calendarHash(cal, now)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// calendarHash は now の去年から auditHashYears 年分の全日の分類と祝日名のハッシュを 16 進数で返す
+// 祝日データ・休業日の規則などのどれが変わっても、この期間の分類が変われば値が変わる
+func calendarHash(cal *bizday.Calendar, now time.Time) string {
+	h := sha256.New()
+	start := time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(auditHashYears, 0, -1)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		class, name := cal.Classify(d)
+		fmt.Fprintf(h, "%s\t%s\t%t\t%s\n", d.Format(time.DateOnly), class, cal.IsBusinessDay(d), name)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
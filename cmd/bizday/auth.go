@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API のキーの役割
+// read のキーは問い合わせだけ、admin のキーは問い合わせに加えて再読み込みと admin API (/admin/v1/...) を呼べる
+const (
+	roleRead  = "read"
+	roleAdmin = "admin"
+)
+
+// authorizes は役割 role のキーで、役割 need の要るエンドポイントを呼べるかどうか
+func authorizes(role, need string) bool {
+	return role == roleAdmin || role == need
+}
+
+// apiKeyYAML は serve --keys のファイルの要素 (API のキー 1 件)
+type apiKeyYAML struct {
+	Name string `yaml:"name"` // 監査ログに書く、キーの持ち主の名前
+	Key  string `yaml:"key"`  // Authorization: Bearer <key> で送る値
+	Role string `yaml:"role"` // read か admin
+}
+
+// loadAPIKeys は --keys のファイル (apiKeyYAML の YAML のリスト) を読み込む
+func loadAPIKeys(path string) ([]apiKeyYAML, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []apiKeyYAML
+	if err := yaml.Unmarshal(b, &keys); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for i, k := range keys {
+		switch {
+		case k.Name == "":
+			return nil, fmt.Errorf("%s: %d 件目の name を指定してください", path, i+1)
+		case len(k.Key) < 16:
+			return nil, fmt.Errorf("%s: %s: key は 16 文字以上にしてください", path, k.Name)
+		case k.Role != roleRead && k.Role != roleAdmin:
+			return nil, fmt.Errorf("%s: %s: role には %s か %s を指定してください: %s", path, k.Name, roleRead, roleAdmin, k.Role)
+		case seen[k.Key]:
+			return nil, fmt.Errorf("%s: %s: 同じ key が 2 回以上あります", path, k.Name)
+		}
+		seen[k.Key] = true
+	}
+	return keys, nil
+}
+
+// errUnauthorized は要求に有効なキーがないときのエラー
+var errUnauthorized = errors.New("Authorization: Bearer に有効な API のキーを指定してください")
+
+// authenticate は要求の Authorization: Bearer のキーを keys から探す (見つからなければ false)
+// キーの比較は一定時間で行い、どこまで一致したかが応答時間から分からないようにする
+func authenticate(keys []apiKeyYAML, r *http.Request) (apiKeyYAML, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return apiKeyYAML{}, false
+	}
+	var found apiKeyYAML
+	match := false
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(token)) == 1 {
+			found, match = k, true
+		}
+	}
+	return found, match
+}
+
+// errForbidden は要求のキーの役割ではそのエンドポイントを呼べないときのエラー
+var errForbidden = errors.New("この API のキーではこの操作はできません")
+
+// hasRole は keys に役割 role のキーがあるかどうか
+func hasRole(keys []apiKeyYAML, role string) bool {
+	for _, k := range keys {
+		if k.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// requireRole は役割 need (を含む役割) のキーを持つ要求だけを h に渡すハンドラを返す
+// キーがないか無効なら 401、役割が足りなければ 403 で、h にはキーの持ち主の名前を渡す
+func (s *server) requireRole(need string, h func(w http.ResponseWriter, r *http.Request, actor string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		k, ok := authenticate(s.keys, r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bizday"`)
+			writeError(w, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+		if !authorizes(k.Role, need) {
+			writeError(w, http.StatusForbidden, errForbidden)
+			return
+		}
+		h(w, r, k.Name)
+	}
+}
+
+// endpoint は役割 need のキーが要るときだけ requireRole で h を包む (要らなければ h のまま)
+func (s *server) endpoint(need string, h http.HandlerFunc) http.HandlerFunc {
+	if need == "" {
+		return h
+	}
+	return s.requireRole(need, func(w http.ResponseWriter, r *http.Request, actor string) { h(w, r) })
+}
+
+// queryRole は問い合わせ (/v1/...・/metrics・gRPC) に要る役割 (--keys に read のキーがなければ誰でも呼べるので空)
+func (s *server) queryRole() string {
+	if hasRole(s.keys, roleRead) {
+		return roleRead
+	}
+	return ""
+}
+
+// reloadRole は POST /reload に要る役割 (--keys にキーがなければ誰でも呼べるので空)
+func (s *server) reloadRole() string {
+	if len(s.keys) > 0 {
+		return roleAdmin
+	}
+	return ""
+}
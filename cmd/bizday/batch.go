@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bizday"
+)
+
+// 一括処理の量の上限
+const (
+	maxBatchRequests = 10000   // POST /v1/batch の 1 回の要求に含められる問い合わせの数
+	maxBatchBody     = 4 << 20 // POST /v1/batch の要求の本文の大きさの上限
+	batchChunkRows   = 4096    // batch が一度に読み込んで並行に処理する行数
+)
+
+// parallelMap は items のそれぞれに f を適用した結果を items と同じ順に返す
+// 同時に動かす f は workers 個まで (0 以下なら GOMAXPROCS)。カレンダーは並行に問い合わせてもよいので、
+// 同じカレンダーを workers 個の goroutine で共有する
+func parallelMap[T, R any](items []T, workers int, f func(T) R) []R {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+	out := make([]R, len(items))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				out[i] = f(items[i])
+			}
+		}()
+	}
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return out
+}
+
+// batchItemJSON は POST /v1/batch の問い合わせ 1 件 (op ごとに /v1/... の同名のエンドポイントと同じ項目を使う)
+type batchItemJSON struct {
+	Op        string `json:"op"` // is-business-day, add, count, month-summary
+	Calendar  string `json:"calendar,omitempty"`
+	Date      string `json:"date,omitempty"`
+	N         int    `json:"n,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	Breakdown bool   `json:"breakdown,omitempty"`
+	Month     string `json:"month,omitempty"`
+}
+
+// batchRequestJSON は POST /v1/batch の要求の本文
+type batchRequestJSON struct {
+	Requests []batchItemJSON `json:"requests"`
+}
+
+// batchResultJSON は問い合わせ 1 件の結果 (op に応じた項目か、要求の誤りなら error のどれか 1 つだけ)
+type batchResultJSON struct {
+	IsBusinessDay *isBusinessDayJSON `json:"is_business_day,omitempty"`
+	Add           *addJSON           `json:"add,omitempty"`
+	Count         *rangeJSON         `json:"count,omitempty"`
+	MonthSummary  *summaryJSON       `json:"month_summary,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// batchJSON は POST /v1/batch の応答 (results は requests と同じ順)
+type batchJSON struct {
+	SchemaVersion int               `json:"schema_version"`
+	Results       []batchResultJSON `json:"results"`
+}
+
+// batchItem は問い合わせ 1 件を /v1/... と共通の処理 (isBusinessDay など) で答える
+func (s *server) batchItem(it batchItemJSON) batchResultJSON {
+	var out batchResultJSON
+	var err error
+	switch it.Op {
+	case "is-business-day":
+		var v isBusinessDayJSON
+		v, err = s.isBusinessDay(it.Calendar, it.Date)
+		out.IsBusinessDay = &v
+	case "add":
+		var v addJSON
+		v, err = s.add(it.Calendar, it.Date, it.N)
+		out.Add = &v
+	case "count":
+		var v rangeJSON
+		v, err = s.count(it.Calendar, it.From, it.To, it.Breakdown)
+		out.Count = &v
+	case "month-summary":
+		var v summaryJSON
+		v, err = s.monthSummary(it.Calendar, it.Month)
+		out.MonthSummary = &v
+	default:
+		err = fmt.Errorf("op には is-business-day, add, count, month-summary のいずれかを指定してください: %q", it.Op)
+	}
+	if err != nil {
+		return batchResultJSON{Error: err.Error()}
+	}
+	return out
+}
+
+// handleBatch は本文の問い合わせをまとめて、serve の --batch-workers 個の goroutine で並行に答える
+// 誤りのある問い合わせはその結果の error に書き、ほかの問い合わせは答える (本文そのものの誤りだけ 400)
+func (s *server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequestJSON
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBatchBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("本文を読めません: %w", err))
+		return
+	}
+	if len(req.Requests) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("requests に問い合わせを 1 件以上指定してください"))
+		return
+	}
+	if len(req.Requests) > maxBatchRequests {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("requests は %d 件以内で指定してください: %d 件", maxBatchRequests, len(req.Requests)))
+		return
+	}
+	writeResponse(w, http.StatusOK, batchJSON{
+		SchemaVersion: bizday.SchemaVersion,
+		Results:       parallelMap(req.Requests, s.batchWorkers, s.batchItem),
+	})
+}
+
+// runBatch は CSV の各行の日付の列に、営業日かどうか・日の分類・祝日名・翌営業日の列を足して出力する
+// 行は batchChunkRows 行ずつ読み込んで --workers 個の goroutine で並行に処理し、入力と同じ順で書き出す
+func runBatch(fs *flag.FlagSet) func() error {
+	input := fs.String("input", "", "読み込む CSV のファイル (省略時は標準入力)")
+	column := fs.Int("column", 1, "日付の列の番号 (1 始まり)")
+	header := fs.Bool("header", false, "1 行目を見出しとして、足した列の見出しを付けてそのまま出力する")
+	workers := fs.Int("workers", runtime.GOMAXPROCS(0), "並行に処理する goroutine の数")
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if *column < 1 {
+			return fmt.Errorf("--column には 1 以上の列の番号を指定してください: %d", *column)
+		}
+		if *workers < 1 {
+			return fmt.Errorf("--workers には 1 以上を指定してください: %d", *workers)
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		in := io.Reader(os.Stdin)
+		if *input != "" {
+			f, err := os.Open(*input)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		r := csv.NewReader(in)
+		r.FieldsPerRecord = -1
+		w := csv.NewWriter(os.Stdout)
+		if *header {
+			row, err := r.Read()
+			if err != nil {
+				return fmt.Errorf("見出しの行を読めません: %w", err)
+			}
+			w.Write(append(row, "business_day", "class", "holiday", "next_business_day"))
+		}
+		enrich := func(row []string) batchRow {
+			if *column > len(row) {
+				return batchRow{err: fmt.Errorf("%d 列目がありません", *column)}
+			}
+			t, err := parseBatchDate(strings.TrimSpace(row[*column-1]))
+			if err != nil {
+				return batchRow{err: err}
+			}
+			next, err := cal.NextBusinessDay(t)
+			if err != nil {
+				return batchRow{err: err}
+			}
+			class, name := cal.Classify(t)
+			return batchRow{date: t, fields: append(row, strconv.FormatBool(cal.IsBusinessDay(t)), string(class), name, dateString(next))}
+		}
+
+		line := 0
+		if *header {
+			line = 1
+		}
+		// 祝日データのない年は、初めて出てきたときに一度だけ --coverage の扱いに従って確かめる
+		checked := map[int]bool{}
+		for {
+			rows, readErr := readRows(r, batchChunkRows)
+			outs := parallelMap(rows, *workers, enrich)
+			if err := checkRowsCoverage(cal, outs, checked); err != nil {
+				return err
+			}
+			for _, out := range outs {
+				line++
+				if out.err != nil {
+					w.Flush()
+					return fmt.Errorf("%d 行目: %w", line, out.err)
+				}
+				w.Write(out.fields)
+			}
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+			if readErr == io.EOF {
+				return nil
+			}
+			if readErr != nil {
+				return readErr
+			}
+		}
+	}
+}
+
+// batchRow は batch が処理した 1 行 (日付の列の日付と列を足した行か、その行のエラー)
+type batchRow struct {
+	date   time.Time
+	fields []string
+	err    error
+}
+
+// checkRowsCoverage は rows の日付のうち checked にない年に祝日データのない年があれば checkCoverage で確かめ、確かめた年を checked に足す
+func checkRowsCoverage(cal *bizday.Calendar, rows []batchRow, checked map[int]bool) error {
+	var start, end time.Time
+	for _, r := range rows {
+		if r.err != nil || checked[r.date.Year()] {
+			continue
+		}
+		if start.IsZero() || r.date.Before(start) {
+			start = r.date
+		}
+		if end.IsZero() || r.date.After(end) {
+			end = r.date
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+	for y := start.Year(); y <= end.Year(); y++ {
+		checked[y] = true
+	}
+	return checkCoverage(cal, start, end)
+}
+
+// readRows は r から最大 n 行を読み込む (読み終えたら読めた行と io.EOF を返す)
+func readRows(r *csv.Reader, n int) ([][]string, error) {
+	rows := make([][]string, 0, n)
+	for len(rows) < n {
+		row, err := r.Read()
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseBatchDate は batch の日付の列の値を読む (時刻があれば日付だけにする)
+func parseBatchDate(s string) (time.Time, error) {
+	t, err := parseDateTime(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return bizday.BeginningOfDay(t), nil
+}
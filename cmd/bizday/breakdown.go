@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+
+	"bizday"
+)
+
+// printBreakdown は内訳を表示する (会社独自の休業日は設定されている場合だけ表示する)
+func printBreakdown(b bizday.RangeBreakdown) {
+	closures := ""
+	if b.Closures > 0 {
+		closures = fmt.Sprintf(tr(" - 休業日 %d 日"), b.Closures)
+	}
+	fmt.Printf(tr("内訳: 暦日 %d 日 - 定休日 %d 日 - 祝日 %d 日%s + 振替出勤 %d 日 = 営業日 %d 日\n"),
+		b.TotalDays, b.WeekendDays, b.Holidays, closures, b.Workdays, b.BusinessDays)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bizday"
+)
+
+// teamYAML は capacity に渡すチームの定義ファイル
+//
+//	members:
+//	  - name: 佐藤
+//	    vacations: [2025-05-12, 2025-05-13]
+//	  - name: 鈴木
+//	    fte: 0.8
+//	    vacations:
+//	      - {from: 2025-08-12, to: 2025-08-15}
+type teamYAML struct {
+	Members []memberYAML `yaml:"members"`
+}
+
+// memberYAML はチームメンバー 1 人の稼働率と休暇
+type memberYAML struct {
+	Name      string         `yaml:"name"`
+	FTE       *float64       `yaml:"fte"` // 稼働率 (例: 週 4 日勤務なら 0.8)、省略時は 1
+	Vacations []vacationYAML `yaml:"vacations"`
+}
+
+// vacationYAML は休暇 1 件 (日付だけ、または from~to の期間)
+type vacationYAML struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// UnmarshalYAML は日付だけのスカラーを 1 日の休暇として読み込む
+func (v *vacationYAML) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		v.From, v.To = n.Value, n.Value
+		return nil
+	}
+	type plain vacationYAML
+	return n.Decode((*plain)(v))
+}
+
+// member は定義ファイルを解釈したメンバー
+type member struct {
+	name     string
+	fte      float64
+	absences map[string]bool // 休暇の日 (dateString の形式)
+}
+
+// loadTeam はチームの定義ファイルを読み込む
+func loadTeam(path string) ([]member, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t teamYAML
+	if err := yaml.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(t.Members) == 0 {
+		return nil, fmt.Errorf("%s: members にメンバーがいません", path)
+	}
+	var members []member
+	for _, m := range t.Members {
+		mem := member{name: m.Name, fte: 1, absences: map[string]bool{}}
+		if m.FTE != nil {
+			if *m.FTE <= 0 || *m.FTE > 1 {
+				return nil, fmt.Errorf("%s: %s: fte には 0 より大きく 1 以下の値を指定してください", path, m.Name)
+			}
+			mem.fte = *m.FTE
+		}
+		for _, v := range m.Vacations {
+			from, err := bizday.ParseDate(v.From, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: 休暇の日付のパースに失敗: %w", path, m.Name, err)
+			}
+			to := from
+			if v.To != "" {
+				if to, err = bizday.ParseDate(v.To, time.Local); err != nil {
+					return nil, fmt.Errorf("%s: %s: 休暇の日付のパースに失敗: %w", path, m.Name, err)
+				}
+			}
+			if to.Before(from) {
+				return nil, fmt.Errorf("%s: %s: 休暇の to には from 以降の日付を指定してください", path, m.Name)
+			}
+			for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+				mem.absences[dateString(d)] = true
+			}
+		}
+		members = append(members, mem)
+	}
+	return members, nil
+}
+
+// runCapacity はチームの定義ファイルから、今月 (--month・--from/--to があればその期間) の稼働可能な人日を表示する
+// メンバーごとに営業日から個人の休暇を除き、稼働率を掛けて合計する
+func runCapacity(fs *flag.FlagSet) func() error {
+	teamPath := fs.String("team", "", "チームの定義ファイル (members にメンバーの名前・fte・vacations を並べた YAML)")
+	monthStr := fs.String("month", "", "対象の月 (例: 2025-07、--year と合わせて 7 とも書ける)")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	fromStr := fs.String("from", "", "期間の開始日 (スプリントなど、--to と合わせて指定する)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-05-23)")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *teamPath == "" {
+			return fmt.Errorf("--team にチームの定義ファイルを指定してください")
+		}
+		members, err := loadTeam(*teamPath)
+		if err != nil {
+			return dataError(fmt.Errorf("チームの定義ファイルの読み込みに失敗しました: %w", err))
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		var from, to time.Time
+		switch {
+		case *fromStr != "" || *toStr != "":
+			if *fromStr == "" || *toStr == "" {
+				return fmt.Errorf("--from と --to は両方指定してください")
+			}
+			if *monthStr != "" || *year != 0 {
+				return fmt.Errorf("--month と --from/--to は同時に指定できません")
+			}
+			if from, err = parseDateTime(*fromStr); err != nil {
+				return err
+			}
+			if to, err = parseDateTime(*toStr); err != nil {
+				return err
+			}
+			from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+			if to.Before(from) {
+				return fmt.Errorf("--to には --from 以降の日付を指定してください")
+			}
+		default:
+			month := time.Now()
+			if *monthStr != "" || *year != 0 {
+				if month, err = parseMonth(*monthStr, *year); err != nil {
+					return err
+				}
+			}
+			from, to = bizday.BeginningOfMonth(month), bizday.BeginningOfDay(bizday.EndOfMonth(month))
+		}
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+
+		days, err := cal.CountBusinessDays(from, to)
+		if err != nil {
+			return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+		}
+		h := dayHours(*hoursPerDay)
+		fmt.Printf("期間 %s ~ %s の営業日は %d 日 です\n", formatDate(from), formatDate(to), days)
+		var totalDays float64
+		var totalHours time.Duration
+		for _, m := range members {
+			absent := 0
+			var hours time.Duration
+			for d := range cal.BusinessDays(from, to) {
+				if m.absences[dateString(d)] {
+					absent++
+					continue
+				}
+				hours += h.On(d)
+			}
+			personDays := float64(days-absent) * m.fte
+			hours = time.Duration(float64(hours) * m.fte)
+			totalDays += personDays
+			totalHours += hours
+			fmt.Printf("%s: %s 人日 (休暇 %d 日、%s 時間)\n", m.name, formatHours(personDays), absent, formatHours(hours.Hours()))
+		}
+		fmt.Printf("チームの稼働可能量は %s 人日 (%s 時間) です\n", formatHours(totalDays), formatHours(totalHours.Hours()))
+		return nil
+	}
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// calendarFlags はカレンダーを選択するサブコマンド共通のフラグ
+type calendarFlags struct {
+	country string
+	// region は --calendar の国の地域区分 (--region、uk なら sct・ni)
+	region   string
+	weekend  string
+	asOf     string
+	holidays string
+	tz       string
+	// vacations は個人の休暇のファイル (--vacations)
+	vacations string
+	// coverage は祝日データのない年の扱い (--coverage)
+	coverage string
+	// company は --vacations のとき、個人の休暇を重ねる前の会社のカレンダー (resolve で設定する)
+	company *bizday.Calendar
+}
+
+// addCalendarFlags は --country・--region・--weekend・--as-of・--holidays・--tz・--vacations・--coverage を fs に登録する
+func addCalendarFlags(fs *flag.FlagSet) *calendarFlags {
+	f := &calendarFlags{}
+	def := "jp"
+	if conf.Calendar != "" {
+		def = conf.Calendar
+	}
+	usage := "使用するカレンダー (" + strings.Join(calendarNames(), ", ") + " と祝日データの calendars の名前)、jp,us のようにカンマ区切りで複数指定するとすべてで営業日の日だけを営業日とする"
+	fs.StringVar(&f.country, "calendar", def, usage+"、省略時は設定ファイルの calendar")
+	fs.StringVar(&f.country, "country", def, usage+"、--calendar と同じ")
+	fs.StringVar(&f.region, "region", "", "--calendar の国の地域区分 (例: --calendar uk --region sct で uk-sct、--calendar us --region ma で us-ma のカレンダー)、地域区分のある国: "+strings.Join(regionalCountries(), ", "))
+	fs.StringVar(&f.weekend, "weekend", "", "定休日 (sat-sun, fri-sat などのプリセット、sun,mon のような曜日の並び、none)、省略時は設定ファイルの weekend かカレンダーの既定")
+	fs.StringVar(&f.asOf, "as-of", "", "この日付時点で有効だった祝日データで計算する (例: 2025-01-01)")
+	fs.StringVar(&f.holidays, "holidays", "", "祝日データの YAML ファイル (holidays.yaml と同じ形式)、埋め込みのデータの代わりに使う ($"+holidaysEnv+" でも指定可)")
+	fs.StringVar(&f.tz, "tz", "", "「今日」や日付の判定に使うタイムゾーン (例: Asia/Tokyo)、省略時は設定ファイルの timezone かホストのタイムゾーン")
+	fs.StringVar(&f.vacations, "vacations", "", "個人の休暇を書いた YAML ファイル (estimate の予定のファイルと同じ形式)、休暇の日 (半休を除く) も休業日として個人の営業日で数える")
+	coverageDef := string(bizday.CoverageWarn)
+	if conf.Coverage != "" {
+		coverageDef = conf.Coverage
+	}
+	fs.StringVar(&f.coverage, "coverage", coverageDef, "祝日データのない年を数えるときの扱い (warn: 警告して祝日を平日として数える, error: エラーにする, generate: 日本の祝日の規則で算出する)、省略時は設定ファイルの coverage")
+	return f
+}
+
+// resolve はフラグの指定に従って登録済みのカレンダーを選ぶ (起動時の祝日データはここで初めて読み込む)
+// --calendar に jp,us のように複数の名前をカンマ区切りで指定すると、すべてで営業日の日だけを営業日とするカレンダーになる
+// --region が指定されていれば、まず f.country の地域区分のある国をその地域のカレンダーの名前 (uk-sct など) に置き換える
+// --tz が指定されていれば、以降の日付の判定をそのタイムゾーンで行う
+// --holidays が指定されていれば、選んだカレンダーの祝日をそのファイルの内容に置き換える
+// --as-of が指定されていればその時点の祝日データに、--weekend (なければ設定ファイルの weekend) があればその定休日に差し替える
+// 設定ファイルの extra_holidays・workdays_override・closure_rules は祝日データ (と拠点の休業日) の上に重ねる
+// 拠点に weekend があれば、設定ファイルの weekend より拠点のものを使う
+// --coverage generate なら、祝日データのない年の祝日をカレンダーごとに日本の祝日の規則で補う
+// --vacations が指定されていれば、その休暇を休業日として重ねたカレンダーを返し、重ねる前のカレンダーを f.company に残す
+func (f *calendarFlags) resolve() (*bizday.Calendar, error) {
+	if err := checkCoveragePolicy(f.coverage); err != nil {
+		return nil, err
+	}
+	coveragePolicy = bizday.CoveragePolicy(f.coverage)
+	if err := ensureHolidays(); err != nil {
+		return nil, err
+	}
+	if err := f.applyRegion(); err != nil {
+		return nil, err
+	}
+	if f.tz != "" {
+		if err := setTimezone(f.tz); err != nil {
+			return nil, err
+		}
+	}
+	var file *holidayData
+	if f.holidays != "" {
+		d, err := loadHolidays(f.holidays)
+		if err != nil {
+			return nil, dataError(fmt.Errorf("祝日ファイルの読み込みに失敗しました: %w", err))
+		}
+		file = &d
+	}
+	var cals []*bizday.Calendar
+	officeWeekend := false
+	for _, name := range strings.Split(f.country, ",") {
+		name = strings.TrimSpace(name)
+		if conf.officeHasWeekend(name) {
+			officeWeekend = true
+		}
+		cal, err := lookupCalendar(name, file)
+		if err != nil {
+			return nil, err
+		}
+		if f.asOf != "" {
+			t, err := parseDateTime(f.asOf)
+			if err != nil {
+				return nil, err
+			}
+			cal = cal.AsOf(t)
+		}
+		if coveragePolicy == bizday.CoverageGenerate {
+			cal = cal.WithJapaneseFallback()
+		}
+		cals = append(cals, cal)
+	}
+	cal := bizday.NewCombinedCalendar(cals...)
+	if conf.extra != nil {
+		cal = cal.WithExtra(conf.extra)
+	}
+	if conf.rules != nil {
+		cal = cal.WithClosureRules(conf.rules...)
+	}
+	if conf.hours != nil {
+		cal = cal.WithHours(*conf.hours)
+	}
+	switch {
+	case f.weekend != "":
+		w, err := bizday.ParseWeekend(f.weekend)
+		if err != nil {
+			return nil, err
+		}
+		cal = cal.WithWeekend(w...)
+	case conf.weekend != nil && !officeWeekend:
+		cal = cal.WithWeekend(conf.weekend...)
+	}
+	f.company = nil
+	if f.vacations != "" {
+		entries, err := loadVacations(f.vacations)
+		if err != nil {
+			return nil, dataError(fmt.Errorf("休暇のファイルの読み込みに失敗しました: %w", err))
+		}
+		f.company, cal = cal, cal.WithExtra(entries)
+	}
+	slog.Debug("カレンダーを組み立てました", "calendar", f.country, "tz", time.Local.String(),
+		"as_of", f.asOf, "holidays", f.holidays, "weekend", f.weekend, "vacations", f.vacations)
+	return cal, nil
+}
+
+// lookupCalendar は name のカレンダーを返す
+// name が設定ファイルの offices の拠点なら、親のカレンダーに拠点独自の休業日・営業日・定休日を重ねる
+// 祝日データ (file があればそのファイル、なければ起動時に読み込んだデータ、まだなら読み込む) の calendars に name があれば、
+// その祝日で作り直す (定休日と営業時間は登録済みのカレンダーのものを引き継ぐ)
+func lookupCalendar(name string, file *holidayData) (*bizday.Calendar, error) {
+	if err := ensureHolidays(); err != nil {
+		return nil, err
+	}
+	if o, ok := conf.offices[name]; ok {
+		cal, err := lookupCalendar(o.parent, file)
+		if err != nil {
+			return nil, fmt.Errorf("拠点 %s: %w", name, err)
+		}
+		if o.extra != nil {
+			cal = cal.WithExtra(o.extra)
+		}
+		if o.rules != nil {
+			cal = cal.WithClosureRules(o.rules...)
+		}
+		if o.weekend != nil {
+			cal = cal.WithWeekend(o.weekend...)
+		}
+		return cal, nil
+	}
+	cal, registered := bizday.Lookup(name)
+	var entries []bizday.HolidayEntry
+	var ok bool
+	switch {
+	case file != nil:
+		entries, ok = file.entriesFor(name)
+	case name != "jp": // jp は起動時に calendars の内容で登録済み
+		entries, ok = holidayCalendars[name]
+	}
+	if !ok {
+		if !registered {
+			return nil, fmt.Errorf("未知のカレンダー: %s", name)
+		}
+		return cal, nil
+	}
+	n := bizday.NewCalendarAsOf(entries, time.Now())
+	if name == "jp" {
+		n = bizday.NewJapanCalendarAsOf(entries, time.Now())
+	}
+	if registered {
+		n.Hours, n.Weekend = cal.Hours, cal.Weekend
+	}
+	return n, nil
+}
+
+// calendarNames は --calendar に指定できる名前 (登録済みのカレンダー、祝日データの calendars、設定ファイルの offices) を昇順で返す
+// 祝日データの calendars は、起動時の祝日データを読み込んだ後 (ensureHolidays) だけ含める
+func calendarNames() []string {
+	names := bizday.CalendarNames()
+	if _, ok := bizday.Lookup("jp"); !ok {
+		names = append(names, "jp") // 祝日データを読み込むまでは登録されていない
+	}
+	for name := range holidayCalendars {
+		if _, ok := bizday.Lookup(name); !ok {
+			names = append(names, name)
+		}
+	}
+	for name := range conf.offices {
+		_, registered := bizday.Lookup(name)
+		if _, inData := holidayCalendars[name]; !registered && !inData {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// noArgs は位置引数をとらないサブコマンドで、fs.Parse の後に位置引数が残っていればエラーを返す
+// flag はフラグでない最初の引数で解析をやめるので、残った引数を黙って無視すると後ろのフラグまで無視されてしまう
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s は引数をとりません (値はフラグで指定してください): %s", fs.Name(), strings.Join(fs.Args(), " "))
+}
+
+// source は f で使う祝日データの出どころ (--holidays のファイルか、読み込んだ祝日データ) を返す
+func (f *calendarFlags) source() string {
+	if f.holidays != "" {
+		return f.holidays
+	}
+	return holidaySource
+}
+
+// applyRegion は --region が指定されていれば、f.country の名前のうち地域区分のある国 (uk,jp なら uk) を
+// その地域のカレンダーの名前 (uk-sct,jp) に置き換え、f.region を空にする (何度呼んでも 1 回だけ置き換える)
+// どの名前にもその地域のカレンダーがなければエラーにする
+func (f *calendarFlags) applyRegion() error {
+	if f.region == "" {
+		return nil
+	}
+	region := strings.ToLower(strings.TrimSpace(f.region))
+	known := map[string]bool{}
+	for _, name := range calendarNames() {
+		known[name] = true
+	}
+	names := strings.Split(f.country, ",")
+	found := false
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if sub := name + "-" + region; known[sub] {
+			names[i], found = sub, true
+		}
+	}
+	if !found {
+		return fmt.Errorf("--calendar %s に地域 %s のカレンダーがありません (地域区分のある国: %s)", f.country, f.region, strings.Join(regionalCountries(), ", "))
+	}
+	f.country, f.region = strings.Join(names, ","), ""
+	return nil
+}
+
+// regionalCountries は地域区分のカレンダー (uk-sct など、国の名前に -地域 を付けたもの) のある国の名前を、地域の一覧とともに昇順で返す (uk (sct, ni) など)
+func regionalCountries() []string {
+	known := map[string]bool{}
+	for _, name := range calendarNames() {
+		known[name] = true
+	}
+	regions := map[string][]string{}
+	for _, name := range calendarNames() {
+		country, region, ok := strings.Cut(name, "-")
+		if ok && known[country] {
+			regions[country] = append(regions[country], region)
+		}
+	}
+	out := make([]string, 0, len(regions))
+	for country, rs := range regions {
+		out = append(out, country+" ("+strings.Join(rs, ", ")+")")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// setTimezone は time.Local を name のタイムゾーンに差し替える
+// 現在時刻や日付のパースはすべて time.Local で行うので、ホストのタイムゾーンによらず同じ日付で判定される
+func setTimezone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("未知のタイムゾーン: %s", name)
+	}
+	time.Local = loc
+	return nil
+}
+
+// coveragePolicy は resolve で選んだ、祝日データのない年の扱い (checkCoverage で使う)
+var coveragePolicy = bizday.CoverageWarn
+
+// checkCoveragePolicy は --coverage・設定ファイルの coverage の値を確認する
+func checkCoveragePolicy(s string) error {
+	switch bizday.CoveragePolicy(s) {
+	case bizday.CoverageWarn, bizday.CoverageError, bizday.CoverageGenerate:
+		return nil
+	}
+	return fmt.Errorf("--coverage には warn, error, generate のいずれかを指定してください: %s", s)
+}
+
+// checkCoverage は start~end に祝日データのない年があれば、coveragePolicy に従ってエラーにするか標準エラー出力に警告する
+// 祝日一覧だけのカレンダー (--holidays で差し替えたものなど) は、データのない年の祝日を平日として数えてしまうため
+// (generate なら resolve で規則で補ってあるので、データのない年は残らない)
+// 期間を数えるサブコマンドはどれも最初にこれを呼ぶので、-debug の除いた日のログもここで書く
+func checkCoverage(cal *bizday.Calendar, start, end time.Time) error {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	traceNonBusinessDays(cal, start, end)
+	err := cal.CheckCoverage(start, end)
+	var uncovered *bizday.UncoveredError
+	if !errors.As(err, &uncovered) {
+		return err
+	}
+	if coveragePolicy == bizday.CoverageError {
+		return dataError(fmt.Errorf("%w (--coverage generate で日本の祝日の規則で算出するか、bizday update で祝日データを更新してください)", err))
+	}
+	s := make([]string, len(uncovered.Years))
+	for i, y := range uncovered.Years {
+		s[i] = strconv.Itoa(y)
+	}
+	fmt.Fprintf(os.Stderr, tr("警告: %s 年の祝日データがないため、その年の祝日は平日として数えています\n"), strings.Join(s, ", "))
+	return nil
+}
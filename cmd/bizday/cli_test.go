@@ -0,0 +1,328 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"bizday"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("未知のサブコマンド"), ExitUsage},
+		{dataError(errors.New("祝日ファイルが壊れています")), ExitData},
+		{networkError(errors.New("接続できません")), ExitNetwork},
+		{fmt.Errorf("serve: %w", dataError(errors.New("壊れています"))), ExitData},
+		{&exitError{code: 1}, 1},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		yaml    string
+		wantErr string // 空ならエラーにならない
+	}{
+		{"calendar: us\nweekend: [fri, sat]\nwork_hours: \"9:30-17:30\"\nbreak: \"12:00-13:00\"\n", ""},
+		{"extra_holidays:\n  - date: 2025-12-29\n    name: 年末休業\n", ""},
+		{"lang: fr\n", "lang には ja か en"},
+		{"date_style: us\n", "date_style には iso か ja"},
+		{"coverage: ignore\n", "coverage"},
+		{"break: \"12:00-13:00\"\n", "break は work_hours と合わせて"},
+		{"fiscal_year_start: 13\n", "fiscal_year_start"},
+		{"holidays: a.yaml\nholiday_sources: [{name: embedded}]\n", "同時に指定できません"},
+		{"offices:\n  a: {parent: b}\n  b: {parent: a}\n", "循環"},
+	}
+	dir := t.TempDir()
+	for i, tt := range tests {
+		path := filepath.Join(dir, fmt.Sprintf("config%d.yaml", i))
+		if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := loadConfig(path)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("loadConfig(%q): %v", tt.yaml, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("loadConfig(%q) = %v, want %q を含むエラー", tt.yaml, err, tt.wantErr)
+		}
+	}
+
+	c, err := loadConfig(filepath.Join(dir, "config0.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Calendar != "us" || len(c.weekend) != 2 || c.hours == nil {
+		t.Errorf("loadConfig = %+v, want calendar us, 2 weekend days and work hours", c)
+	}
+	if _, err := loadConfig(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("指定した設定ファイルがなくてもエラーにならない")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		country, region, weekend string
+		day                      time.Time
+		want                     bool
+	}{
+		{"jp", "", "", time.Date(2025, 5, 5, 0, 0, 0, 0, time.Local), false},
+		{"jp", "", "", time.Date(2025, 5, 7, 0, 0, 0, 0, time.Local), true},
+		{"jp", "", "sun", time.Date(2025, 5, 10, 0, 0, 0, 0, time.Local), true},
+		{"us", "", "", time.Date(2025, 7, 4, 0, 0, 0, 0, time.Local), false},
+		{"jp,us", "", "", time.Date(2025, 7, 4, 0, 0, 0, 0, time.Local), false},
+		{"jp, us", "", "", time.Date(2025, 5, 5, 0, 0, 0, 0, time.Local), false},
+		{"uk", "", "", time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local), true},
+		{"uk", "sct", "", time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local), false},
+	}
+	for _, tt := range tests {
+		f := &calendarFlags{country: tt.country, region: tt.region, weekend: tt.weekend, coverage: string(bizday.CoverageWarn)}
+		cal, err := f.resolve()
+		if err != nil {
+			t.Errorf("resolve(%s, region %q): %v", tt.country, tt.region, err)
+			continue
+		}
+		if got := cal.IsBusinessDay(tt.day); got != tt.want {
+			t.Errorf("resolve(%s, region %q, weekend %q).IsBusinessDay(%s) = %t, want %t",
+				tt.country, tt.region, tt.weekend, tt.day.Format(time.DateOnly), got, tt.want)
+		}
+	}
+
+	errTests := []calendarFlags{
+		{country: "xx"},
+		{country: "jp", region: "sct"},
+		{country: "jp", weekend: "someday"},
+		{country: "jp", coverage: "ignore"},
+		{country: "jp", holidays: "missing.yaml"},
+	}
+	for _, f := range errTests {
+		if f.coverage == "" {
+			f.coverage = string(bizday.CoverageWarn)
+		}
+		if _, err := f.resolve(); err == nil {
+			t.Errorf("resolve(%+v) がエラーにならない", f)
+		}
+	}
+	f := calendarFlags{country: "jp", holidays: "missing.yaml", coverage: string(bizday.CoverageWarn)}
+	if _, err := f.resolve(); exitCode(err) != ExitData {
+		t.Errorf("読めない --holidays の終了コード = %d, want %d", exitCode(err), ExitData)
+	}
+}
+
+func TestApplyRegion(t *testing.T) {
+	if err := ensureHolidays(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		country, region string
+		want            string // 空ならエラーになる
+	}{
+		{"uk", "", "uk"},
+		{"uk", "sct", "uk-sct"},
+		{"uk", "NI", "uk-ni"},
+		{"jp,uk", "sct", "jp,uk-sct"},
+		{"us", "MA", "us-ma"},
+		{"us,uk", "ni", "us,uk-ni"},
+		{"us", "zz", ""},
+		{"jp", "sct", ""},
+	}
+	for _, tt := range tests {
+		f := &calendarFlags{country: tt.country, region: tt.region}
+		err := f.applyRegion()
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("applyRegion(%s, %s) がエラーにならない", tt.country, tt.region)
+		case tt.want != "" && err != nil:
+			t.Errorf("applyRegion(%s, %s): %v", tt.country, tt.region, err)
+		case tt.want != "" && (f.country != tt.want || f.region != ""):
+			t.Errorf("applyRegion(%s, %s) = %s (region %q), want %s", tt.country, tt.region, f.country, f.region, tt.want)
+		}
+	}
+}
+
+func TestCoveragePolicy(t *testing.T) {
+	defer func(old bizday.CoveragePolicy) { coveragePolicy = old }(coveragePolicy)
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	yaml := "calendars:\n  acme:\n    holidays:\n      - date: 2025-01-01\n        name: 元日\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		command string
+		args    []string
+	}{
+		{"is", []string{"2024-05-07"}},
+		{"list", []string{"--from", "2024-12-27", "--next", "3"}},
+		{"roll", []string{"--date", "2024-05-06"}},
+		{"week", []string{"--date", "2024-05-07"}},
+		{"nth", []string{"--month", "2024-05", "-n", "1"}},
+	}
+	for _, tt := range tests {
+		c, ok := lookupCommand(tt.command)
+		if !ok {
+			t.Fatalf("%s が見つかりません", tt.command)
+		}
+		args := append([]string{"--holidays", path, "--calendar", "acme", "--coverage", "error"}, tt.args...)
+		err := c.exec(args)
+		var uncovered *bizday.UncoveredError
+		if !errors.As(err, &uncovered) || exitCode(err) != ExitData {
+			t.Errorf("%s %q = %v (終了コード %d), want 2024 年の UncoveredError", tt.command, args, err, exitCode(err))
+		}
+	}
+}
+
+func TestNoArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"-v"}, false},
+		{[]string{"extra"}, true},
+		{[]string{"2025-05-07", "-v"}, true},
+	}
+	for _, tt := range tests {
+		fs := newFlagSet("add")
+		fs.Parse(tt.args)
+		if err := noArgs(fs); (err != nil) != tt.wantErr {
+			t.Errorf("noArgs(%q) = %v, want error %t", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestVerifyData(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, other, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("- date: 2025-01-01\n  name: 元日\n")
+	sum := sha256.Sum256(data)
+	sumText := []byte(hex.EncodeToString(sum[:]) + "  holidays.yaml\n")
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, data)) + "\n")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		sumText []byte
+		sig     []byte
+		wantErr bool
+	}{
+		{"ok", data, sumText, sig, false},
+		{"改ざんされたデータ", []byte("- date: 2025-01-02\n"), sumText, sig, true},
+		{"空のチェックサム", data, nil, sig, true},
+		// 配布元でデータとチェックサムをそろえて差し替えても、署名で見抜ける
+		{"別の鍵の署名", data, sumText, []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(other, data))), true},
+		{"読めない署名", data, sumText, []byte("not base64"), true},
+	}
+	for _, tt := range tests {
+		err := verifyChecksum(tt.data, tt.sumText)
+		if err == nil {
+			err = verifySignature(pub, tt.data, tt.sig)
+		}
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %t", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if key, err := parsePublicKey(base64.StdEncoding.EncodeToString(pub)); err != nil || !key.Equal(pub) {
+		t.Errorf("parsePublicKey = %v, %v", key, err)
+	}
+	if key, err := parsePublicKey(""); key != nil || err != nil {
+		t.Errorf("parsePublicKey(\"\") = %v, %v, want nil, nil", key, err)
+	}
+	if _, err := parsePublicKey("c2hvcnQ="); err == nil {
+		t.Error("parsePublicKey(short) = nil error")
+	}
+}
+
+func TestCommandFlags(t *testing.T) {
+	for _, c := range commands {
+		fs := commandFlags(c)
+		if c.define == nil || c.hidden {
+			if fs != nil {
+				t.Errorf("%s: フラグのないサブコマンドの FlagSet が nil ではない", c.name)
+			}
+			continue
+		}
+		if fs == nil || fs.Name() != c.name {
+			t.Errorf("%s: commandFlags = %v", c.name, fs)
+			continue
+		}
+		if fs.Lookup("v") == nil || fs.Lookup("lang") == nil {
+			t.Errorf("%s: -v か --lang が定義されていない", c.name)
+		}
+	}
+	add, _ := lookupCommand("add")
+	if commandFlags(add).Lookup("count-from") == nil {
+		t.Error("add の --count-from が定義されていない")
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	defer func(old string) { lang = old }(lang)
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"ja", 5, "5"},
+		{"en", 1, "1st"},
+		{"en", 2, "2nd"},
+		{"en", 3, "3rd"},
+		{"en", 4, "4th"},
+		{"en", 11, "11th"},
+		{"en", 12, "12th"},
+		{"en", 13, "13th"},
+		{"en", 21, "21st"},
+		{"en", 22, "22nd"},
+		{"en", 101, "101st"},
+		{"en", 111, "111th"},
+		{"en", -1, "-1st"},
+	}
+	for _, tt := range tests {
+		lang = tt.lang
+		if got := ordinal(tt.n); got != tt.want {
+			t.Errorf("ordinal(%d) [%s] = %s, want %s", tt.n, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestHolidayLabel(t *testing.T) {
+	defer func(old string) { lang = old }(lang)
+	tests := []struct {
+		lang, name, want string
+	}{
+		{"ja", "こどもの日", "こどもの日"},
+		{"en", "こどもの日", "Children's Day"},
+		{"en", "元日 / New Year's Day", "New Year's Day"},
+		{"en", "振替休日 / Christmas Day", "Substitute Holiday / Christmas Day"},
+		{"en", "創立記念日", "創立記念日"},
+		{"en", "", ""},
+	}
+	for _, tt := range tests {
+		lang = tt.lang
+		if got := holidayLabel(tt.name); got != tt.want {
+			t.Errorf("holidayLabel(%q) [%s] = %q, want %q", tt.name, tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bizday"
+)
+
+// closeMilestoneYAML は月次決算の日程 1 件の定義 (設定ファイルの close_checklist と --template の要素)
+type closeMilestoneYAML struct {
+	Name string `yaml:"name"`
+	// Offset は月の最終営業日から数えた営業日数 (0 なら最終営業日、1 なら翌月第 1 営業日、負なら最終営業日より前)
+	Offset int `yaml:"offset"`
+}
+
+// defaultCloseChecklist は設定ファイルにも --template にも日程がないときの、最終営業日と翌月第 1~5 営業日
+var defaultCloseChecklist = []closeMilestoneYAML{
+	{Name: "最終営業日", Offset: 0},
+	{Name: "翌月第1営業日", Offset: 1},
+	{Name: "翌月第2営業日", Offset: 2},
+	{Name: "翌月第3営業日", Offset: 3},
+	{Name: "翌月第4営業日", Offset: 4},
+	{Name: "翌月第5営業日", Offset: 5},
+}
+
+// checkCloseChecklist は日程の定義に名前があるかを確認する
+func checkCloseChecklist(items []closeMilestoneYAML) error {
+	for i, m := range items {
+		if m.Name == "" {
+			return fmt.Errorf("%d 件目の name を指定してください", i+1)
+		}
+	}
+	return nil
+}
+
+// loadCloseTemplate は --template のファイル (closeMilestoneYAML の YAML のリスト) を読み込む
+func loadCloseTemplate(path string) ([]closeMilestoneYAML, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []closeMilestoneYAML
+	if err := yaml.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%s: 日程が 1 件もありません", path)
+	}
+	if err := checkCloseChecklist(items); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return items, nil
+}
+
+// closeJSON は close --format json の出力
+type closeJSON struct {
+	SchemaVersion   int                  `json:"schema_version"`
+	Calendar        string               `json:"calendar"`
+	Month           string               `json:"month"`
+	LastBusinessDay string               `json:"last_business_day"`
+	Milestones      []closeMilestoneJSON `json:"milestones"`
+}
+
+// closeMilestoneJSON は日程 1 件
+type closeMilestoneJSON struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"`
+	Label  string `json:"label"` // BD+1 のような、最終営業日からの営業日数の表記
+	Date   string `json:"date"`
+}
+
+// runClose は月次決算の日程 (最終営業日、翌月第 1~5 営業日など) の日付を表示する
+// 日程は月の最終営業日から数えた営業日数で、--template のファイル、設定ファイルの close_checklist、組み込みの既定の順に探す
+func runClose(fs *flag.FlagSet) func() error {
+	month := fs.String("month", "", "締める月 (例: 2025-04、--year と合わせて 4 とも書ける)、省略時は今月")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	template := fs.String("template", "", "日程の定義のファイル (name と offset の YAML のリスト)、省略時は設定ファイルの close_checklist")
+	format := fs.String("format", "text", "出力形式 (text, json)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *format != "text" && *format != "json" {
+			return fmt.Errorf("--format には text か json を指定してください: %s", *format)
+		}
+		items := defaultCloseChecklist
+		switch {
+		case *template != "":
+			t, err := loadCloseTemplate(*template)
+			if err != nil {
+				return err
+			}
+			items = t
+		case len(conf.CloseChecklist) > 0:
+			items = conf.CloseChecklist
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		m := time.Now()
+		if *month != "" || *year != 0 {
+			if m, err = parseMonth(*month, *year); err != nil {
+				return err
+			}
+		}
+		m = bizday.BeginningOfMonth(m)
+		last, err := cal.LastBusinessDayOfMonth(m.Year(), m.Month())
+		if err != nil {
+			return err
+		}
+		out := closeJSON{SchemaVersion: bizday.SchemaVersion, Calendar: calFlags.country, Month: m.Format("2006-01"), LastBusinessDay: dateString(last)}
+		dates := make([]time.Time, len(items))
+		from, to := m, m.AddDate(0, 1, -1)
+		for i, it := range items {
+			if dates[i], err = cal.AddBusinessDays(last, it.Offset); err != nil {
+				return err
+			}
+			if dates[i].Before(from) {
+				from = dates[i]
+			}
+			if dates[i].After(to) {
+				to = dates[i]
+			}
+			out.Milestones = append(out.Milestones, closeMilestoneJSON{Name: it.Name, Offset: it.Offset, Label: closeLabel(it.Offset), Date: dateString(dates[i])})
+		}
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+		if *format == "json" {
+			return writeJSON(out)
+		}
+
+		fmt.Printf(tr("%d年%d月の決算の日程\n"), m.Year(), m.Month())
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for i, ms := range out.Milestones {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", ms.Label, tr(ms.Name), formatDate(dates[i])) // 既定の日程の名前は訳し、設定の名前はそのまま
+		}
+		return w.Flush()
+	}
+}
+
+// closeLabel は最終営業日から offset 営業日の日程の表記 (BD+0, BD+1, BD-2 など)
+func closeLabel(offset int) string {
+	return fmt.Sprintf("BD%+d", offset)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// command はサブコマンド 1 つ
+type command struct {
+	name    string
+	aliases []string
+	summary string // help の一覧に表示する説明
+	// define は fs にサブコマンドのフラグを定義し、フラグを解析した後に呼ぶ本体を返す
+	// フラグの定義だけを使う補完でも、本体は呼ばずに fs を組み立てられる
+	define func(fs *flag.FlagSet) func() error
+	// run はフラグを自前で扱うサブコマンド (help・completion など) の本体 (define がないときだけ使う)
+	run    func(args []string) error
+	hidden bool // help の一覧と補完に出さない (シェルの補完スクリプトから呼ぶもの)
+}
+
+// commands はサブコマンドの一覧 (help の表示順)
+// run から commands を参照するもの (help・completion) があるので init で作る
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "summary", summary: "今月の営業日の経過状況を表示する (サブコマンドを省略したときの既定)", define: runSummary},
+		{name: "is-business-day", aliases: []string{"is"}, summary: "日付が営業日かどうかを終了コードで返す", define: runIsBusinessDay},
+		{name: "list", summary: "今後の営業日を一覧表示する", define: runList},
+		{name: "add", summary: "n 営業日後 (前) の日付を表示する", define: runAdd},
+		{name: "diff", summary: "2 つの日付の間の営業日数を表示する", define: runDiff},
+		{name: "nth", summary: "月の第 n 営業日・最終営業日を表示する", define: runNth},
+		{name: "roll", summary: "休業日を前後の営業日にずらす", define: runRoll},
+		{name: "until", summary: "期限までの残り営業日数と想定稼働時間を表示する", define: runUntil},
+		{name: "deadline", summary: "暦日で数えた期日を営業日に調整する", define: runDeadline},
+		{name: "holidays", summary: "祝日の一覧を表示する", define: runHolidays},
+		{name: "offdays", summary: "月の休業日を理由付きで一覧表示する", define: runOffdays},
+		{name: "week", summary: "今週の営業日数と経過・残りを表示する", define: runWeek},
+		{name: "year", summary: "年 (会計年度) の月ごとの営業日数を表示する", define: runYear},
+		{name: "fiscal", summary: "会計年度・四半期の営業日の経過状況を表示する", define: runFiscal},
+		{name: "sprint", summary: "スプリントの営業日の経過状況を表示する", define: runSprint},
+		{name: "progress", summary: "任意の期間に対する今日時点の進捗を表示する", define: runProgress},
+		{name: "payday", summary: "給料日 (休業日なら前の営業日) と締め日を表示する", define: runPayday},
+		{name: "close", summary: "月次決算の日程 (最終営業日・翌月第 n 営業日) の日付を表示する", define: runClose},
+		{name: "hours", summary: "想定稼働時間を表示する", define: runHours},
+		{name: "estimate", summary: "休暇・会議を差し引いた残りの稼働可能時間を見積もる", define: runEstimate},
+		{name: "capacity", summary: "チームの稼働可能な人日を表示する", define: runCapacity},
+		{name: "open", summary: "今が営業時間内かどうかを終了コードで返す", define: runOpen},
+		{name: "finish", summary: "残作業時間から完了見込み日時を表示する", define: runFinish},
+		{name: "sla", summary: "営業時間で数えた SLA の期限を表示する", define: runSLA},
+		{name: "compare-tz", summary: "同じ瞬間が各地域で営業時間内かを並べて表示する", define: runCompareTZ},
+		{name: "overlap", summary: "2 地域の営業時間が重なる時間帯を表示する", define: runOverlap},
+		{name: "cron", summary: "営業日の修飾子付きの cron 式の次の実行日時を表示する", define: runCron},
+		{name: "schedule", summary: "件数を期間の営業日に振り分ける", define: runSchedule},
+		{name: "schedule-gen", summary: "営業日だけ実行する systemd timer / launchd plist を生成する", define: runScheduleGen},
+		{name: "batch", summary: "CSV の日付の列に営業日かどうか・祝日名・翌営業日の列を足す (並行に処理)", define: runBatch},
+		{name: "dump", summary: "期間の全日の分類を CSV・JSON で書き出す", define: runDump},
+		{name: "export-ics", summary: "休業日を iCalendar (.ics) で書き出す", define: runExportICS},
+		{name: "snapshot", summary: "1 年分の全日の分類をハッシュ付きの JSON で書き出す", define: runSnapshot},
+		{name: "notify", summary: "今日の営業日の状況を Webhook に投稿する", define: runNotify},
+		{name: "watch", summary: "常駐して日付が変わるたびにサマリを出力・投稿する", define: runWatch},
+		{name: "serve", summary: "HTTP の API を提供する", define: runServe},
+		{name: "sources", summary: "設定ファイルの holiday_sources の取得元ごとの読み込み結果を表示する", define: runSources},
+		{name: "validate", summary: "祝日データのファイルを検証する", define: runValidate},
+		{name: "gen", summary: "翌年の祝日を holidays.yaml の形式で生成する", define: runGen},
+		{name: "diff-holidays", summary: "2 つの祝日データの違いを表示する", define: runDiffHolidays},
+		{name: "update", summary: "祝日データを取得してキャッシュを更新する", define: runUpdate},
+		{name: "self-update-data", summary: "祝日データを取得して埋め込みデータの代わりに使う", define: runSelfUpdateData},
+		{name: "completion", summary: "シェルの補完スクリプトを出力する (bash, zsh, fish)", run: runCompletion},
+		{name: "help", summary: "サブコマンドの一覧を表示する", run: runHelp},
+		{name: completeCommand, run: runComplete, hidden: true},
+	}
+}
+
+// exec は args を c のフラグとして解析し、c を実行する
+func (c command) exec(args []string) error {
+	if c.define == nil {
+		return c.run(args)
+	}
+	fs := newFlagSet(c.name)
+	run := c.define(fs)
+	fs.Parse(args)
+	if err := checkLang(); err != nil {
+		return err
+	}
+	return run()
+}
+
+// lookupCommand は名前か別名が name のサブコマンドを返す
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+		for _, a := range c.aliases {
+			if a == name {
+				return c, true
+			}
+		}
+	}
+	return command{}, false
+}
+
+// runHelp はサブコマンドの一覧を表示する (help サブコマンド名 ならそのサブコマンドのフラグを表示する)
+func runHelp(args []string) error {
+	if len(args) > 0 {
+		c, ok := lookupCommand(args[0])
+		if !ok {
+			return fmt.Errorf("未知のサブコマンド: %s", args[0])
+		}
+		return c.exec([]string{"-h"})
+	}
+	fmt.Println("使い方: bizday <サブコマンド> [フラグ] [引数]")
+	fmt.Println()
+	width := 0
+	for _, c := range commands {
+		if !c.hidden {
+			width = max(width, len(c.name))
+		}
+	}
+	for _, c := range commands {
+		if c.hidden {
+			continue
+		}
+		summary := c.summary
+		if len(c.aliases) > 0 {
+			summary += " (別名: " + strings.Join(c.aliases, ", ") + ")"
+		}
+		fmt.Printf("  %-*s  %s\n", width, c.name, summary)
+	}
+	fmt.Println()
+	fmt.Println("各サブコマンドのフラグは bizday help <サブコマンド> か bizday <サブコマンド> -h で表示する")
+	return nil
+}
+
+// newFlagSet はサブコマンド name のフラグの FlagSet を作る
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	addLogFlags(fs)
+	addLangFlag(fs)
+	return fs
+}
+
+// commandFlags はサブコマンド c のフラグを定義した FlagSet を返す (フラグのないサブコマンドなら nil)
+func commandFlags(c command) *flag.FlagSet {
+	if c.hidden || c.define == nil {
+		return nil
+	}
+	fs := newFlagSet(c.name)
+	c.define(fs)
+	return fs
+}
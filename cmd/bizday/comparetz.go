@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bizday"
+)
+
+// runCompareTZ は同じ瞬間が各地域で営業日・営業時間内かどうかを並べて表示する
+func runCompareTZ(fs *flag.FlagSet) func() error {
+	specs := fs.String("calendars", "", "カレンダー名:タイムゾーン のカンマ区切り (例: jp:Asia/Tokyo,us:America/New_York)")
+	at := fs.String("at", "", "判定する日時 (例: 2025-04-01T19:00)、省略時は現在時刻")
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+
+		zones, err := parseZonedCalendars(*specs)
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *at != "" {
+			t, err = parseDateTime(*at)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, z := range zones {
+			if err := checkCoverage(z.Calendar, t.In(z.Location), t.In(z.Location)); err != nil {
+				return err
+			}
+		}
+		fmt.Printf(tr("%s 時点\n"), t.Format(time.RFC3339))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, z := range zones {
+			local := t.In(z.Location)
+			day := tr("休業日")
+			if z.Calendar.IsBusinessDay(local) {
+				day = tr("営業日")
+			}
+			hours := tr("営業時間外")
+			if z.Calendar.IsOpen(local) {
+				hours = tr("営業時間内")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", z.Name, z.Location, formatDateTime(local), day, hours)
+		}
+		return w.Flush()
+	}
+}
+
+// parseZonedCalendars は "jp:Asia/Tokyo,us:America/New_York" 形式の指定を解釈する
+func parseZonedCalendars(specs string) ([]bizday.ZonedCalendar, error) {
+	if specs == "" {
+		return nil, fmt.Errorf("--calendars を指定してください")
+	}
+
+	var zones []bizday.ZonedCalendar
+	for _, spec := range strings.Split(specs, ",") {
+		name, tz, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		if !ok {
+			return nil, fmt.Errorf("カレンダー指定は 名前:タイムゾーン の形式にしてください: %s", spec)
+		}
+		cal, err := lookupCalendar(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("タイムゾーンの読み込みに失敗: %s", tz)
+		}
+		zones = append(zones, bizday.ZonedCalendar{Name: name, Location: loc, Calendar: cal})
+	}
+	return zones, nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// completeCommand は補完スクリプトから呼ぶ隠しサブコマンドの名前
+const completeCommand = "__complete"
+
+// completionScripts はシェルごとの補完スクリプト
+// どれも入力中の単語を bizday __complete に渡し、返ってきた候補 (1 行に 1 つ) を使う。候補がなければファイル名を補完する
+var completionScripts = map[string]string{
+	"bash": `# bizday の bash 補完 (~/.bashrc に eval "$(bizday completion bash)" を書く)
+_bizday() {
+	local IFS=$'\n'
+	COMPREPLY=($(bizday __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+	if [ ${#COMPREPLY[@]} -eq 0 ]; then
+		COMPREPLY=($(compgen -f -- "${COMP_WORDS[COMP_CWORD]}"))
+	fi
+}
+complete -F _bizday bizday
+`,
+	"zsh": `#compdef bizday
+# bizday の zsh 補完 (~/.zshrc に eval "$(bizday completion zsh)" を書くか、$fpath の _bizday に保存する)
+_bizday() {
+	local -a candidates
+	candidates=("${(@f)$(bizday __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	if [[ -n ${candidates[1]} ]]; then
+		compadd -Q -- "${candidates[@]}"
+	else
+		_files
+	fi
+}
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+	_bizday "$@"
+else
+	compdef _bizday bizday
+fi
+`,
+	"fish": `# bizday の fish 補完 (bizday completion fish > ~/.config/fish/completions/bizday.fish)
+function __bizday_complete
+	set -l tokens (commandline -opc) (commandline -ct)
+	bizday __complete $tokens[2..-1] 2>/dev/null
+end
+complete -c bizday -f -a '(__bizday_complete)'
+`,
+}
+
+// runCompletion は bash・zsh・fish の補完スクリプトを出力する
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("シェルを 1 つ指定してください (bash, zsh, fish)")
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return fmt.Errorf("未対応のシェル: %s (bash, zsh, fish)", args[0])
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// runComplete は bizday に続く単語 args (最後が入力中の単語) の補完の候補を 1 行に 1 つ出力する
+func runComplete(args []string) error {
+	for _, c := range completions(args, time.Now()) {
+		fmt.Println(c)
+	}
+	return nil
+}
+
+// completions は args の最後の単語の補完の候補を返す
+//   - 最初の単語: サブコマンドの名前
+//   - - で始まる単語: そのサブコマンドのフラグ
+//   - 値を取るフラグの次: 日付のフラグなら today からの日付、--month・--week なら月・週、
+//     説明に (a, b) の形で値の一覧があればその値
+//   - それ以外の引数: 日付 (数字で始まるか空のとき)
+func completions(args []string, today time.Time) []string {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	cur := args[len(args)-1]
+	if len(args) == 1 {
+		var names []string
+		for _, c := range commands {
+			if !c.hidden {
+				names = append(names, c.name)
+				names = append(names, c.aliases...)
+			}
+		}
+		return filterPrefix(names, cur)
+	}
+	c, ok := lookupCommand(args[0])
+	if !ok {
+		return nil
+	}
+	switch c.name {
+	case "help":
+		return completions(args[1:], today)
+	case "completion":
+		return filterPrefix([]string{"bash", "zsh", "fish"}, cur)
+	}
+	fs := commandFlags(c)
+	if fs == nil {
+		return nil
+	}
+	if strings.HasPrefix(cur, "-") && !strings.Contains(cur, "=") {
+		var names []string
+		fs.VisitAll(func(f *flag.Flag) { names = append(names, "--"+f.Name) })
+		return filterPrefix(names, cur)
+	}
+	if prev := args[len(args)-2]; strings.HasPrefix(prev, "-") && !strings.Contains(prev, "=") {
+		if f := fs.Lookup(strings.TrimLeft(prev, "-")); f != nil && !isBoolFlag(f) {
+			return filterPrefix(flagValues(f, today), cur)
+		}
+	}
+	if cur == "" || cur[0] >= '0' && cur[0] <= '9' {
+		return filterPrefix(dateCandidates(today), cur)
+	}
+	return nil
+}
+
+// dateFlags は値が日付のフラグの名前
+var dateFlags = map[string]bool{"date": true, "from": true, "to": true, "as-of": true, "at": true, "start": true}
+
+// flagChoices はフラグの説明の中の「(text, json)」「(forward: 翌営業日, backward: 前営業日)」のような値の一覧
+var flagChoices = regexp.MustCompile(`\(([a-z0-9-]+(?::[^,]*)?(?:, [a-z0-9-]+(?::[^,]*)?)+)\)`)
+
+// flagValues はフラグ f の値の候補を返す
+func flagValues(f *flag.Flag, today time.Time) []string {
+	switch {
+	case dateFlags[f.Name]:
+		return dateCandidates(today)
+	case f.Name == "month":
+		var months []string
+		for y := today.Year(); y <= today.Year()+1; y++ {
+			for m := 1; m <= 12; m++ {
+				months = append(months, fmt.Sprintf("%d-%02d", y, m))
+			}
+		}
+		return months
+	case f.Name == "week":
+		var weeks []string
+		for i := 0; i < 8; i++ {
+			y, w := today.AddDate(0, 0, 7*i).ISOWeek()
+			weeks = append(weeks, fmt.Sprintf("%d-W%02d", y, w))
+		}
+		return weeks
+	}
+	m := flagChoices.FindStringSubmatch(f.Usage)
+	if m == nil {
+		return nil
+	}
+	var values []string
+	for _, item := range strings.Split(m[1], ", ") {
+		values = append(values, strings.SplitN(item, ":", 2)[0])
+	}
+	return values
+}
+
+// dateCandidates は日付の引数の候補 (今日、明日、次の営業日、今月末、来月 1 日) を返す
+func dateCandidates(today time.Time) []string {
+	today = bizday.BeginningOfDay(today)
+	days := []time.Time{today, today.AddDate(0, 0, 1)}
+	// 祝日データを読めなければ埋め込みのデータの jp で数える (補完はエラーを出さない)
+	_ = ensureHolidays()
+	if cal, ok := bizday.Lookup("jp"); ok {
+		if next, err := cal.NextBusinessDay(today); err == nil {
+			days = append(days, next)
+		}
+	}
+	days = append(days, bizday.BeginningOfDay(bizday.EndOfMonth(today)), bizday.BeginningOfMonth(today).AddDate(0, 1, 0))
+	var out []string
+	seen := map[string]bool{}
+	for _, d := range days {
+		if s := dateString(d); !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// isBoolFlag は f が値を取らない (--json のような) フラグかを判定する
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// filterPrefix は候補のうち prefix で始まるものを返す
+func filterPrefix(candidates []string, prefix string) []string {
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
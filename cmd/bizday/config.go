@@ -0,0 +1,334 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bizday"
+)
+
+// configEnv は設定ファイルの場所を指定する環境変数
+const configEnv = "BIZDAY_CONFIG"
+
+// config は設定ファイル (既定では ~/.config/bizday/config.yaml) の内容
+// フラグ (と環境変数) で指定した値は設定ファイルより優先され、設定ファイルの値は組み込みの既定値より優先される
+type config struct {
+	// Calendar は既定のカレンダー (--calendar の既定値、例: us)、省略時は jp
+	Calendar string `yaml:"calendar"`
+	// Holidays は祝日データのファイル (--holidays と同じ形式)、$BIZDAY_HOLIDAYS が優先される
+	Holidays string `yaml:"holidays"`
+	// HolidaySources は祝日データの取得元を優先する順に並べたもの (例: [{path: company.ics}, {name: update}, {name: embedded}])
+	// 指定すると取得元を合わせて使う (mergeHolidaySources)。holidays とは同時に指定できない
+	HolidaySources []holidaySourceYAML `yaml:"holiday_sources"`
+	// Lang は出力の言語 (--lang の既定値、ja か en)、省略時は $LANG から決める
+	Lang string `yaml:"lang"`
+	// DateStyle は日付の出力形式 (--date-style の既定値、iso か ja)
+	DateStyle string `yaml:"date_style"`
+	// WorkHours は営業時間帯 (例: "9:30-17:30")、省略時は 9:00-18:00
+	WorkHours string `yaml:"work_hours"`
+	// Break は休憩時間帯 (例: "12:00-13:00")、work_hours を指定して break を省略すると休憩なし
+	Break string `yaml:"break"`
+	// Weekend は定休日の曜日 (例: [sun, mon])、省略時はカレンダーの既定
+	Weekend []string `yaml:"weekend"`
+	// HoursPerDay は営業日 1 日あたりの想定稼働時間 (時間、例: 7.5)、省略時は 8 時間
+	HoursPerDay float64 `yaml:"hours_per_day"`
+	// HoursPerWeekday は曜日ごとの想定稼働時間 (例: {fri: 6})、ない曜日は HoursPerDay を使う
+	HoursPerWeekday map[string]float64 `yaml:"hours_per_weekday"`
+	// FiscalYearStart は会計年度の始まりの月 (1~12)、省略時は 4 月
+	FiscalYearStart int `yaml:"fiscal_year_start"`
+	// Timezone は「今日」や日付の判定に使うタイムゾーン (例: Asia/Tokyo)、--tz で上書きできる
+	Timezone string `yaml:"timezone"`
+	// ExtraHolidays は会社独自の休業日 (年末年始・お盆休みなど)、国民の祝日に重ねて数える
+	ExtraHolidays []bizday.HolidayYAML `yaml:"extra_holidays"`
+	// WorkdaysOverride は土日や祝日でも営業日として数える日 (休日出勤の土曜など)
+	WorkdaysOverride []bizday.HolidayYAML `yaml:"workdays_override"`
+	// Payday は給料日 (1~31、月の日数を超えるなら月末)、payday サブコマンドの既定値
+	Payday int `yaml:"payday"`
+	// Sprint はスプリントの定義 (例: {start: 2025-04-07, length: 2w})、sprint サブコマンドの既定値
+	Sprint sprintYAML `yaml:"sprint"`
+	// ClosureRules は繰り返しの休業日 (例: {weekday: wed, nth: 2, name: メンテナンス休業} で毎月第 2 水曜)
+	ClosureRules []closureRuleYAML `yaml:"closure_rules"`
+	// Vacations は個人の休暇・半日営業・会議を書いたファイル (estimate の --vacations の既定値)
+	Vacations string `yaml:"vacations"`
+	// Coverage は祝日データのない年を数えるときの扱い (warn, error, generate)、--coverage の既定値
+	Coverage string `yaml:"coverage"`
+	// CloseChecklist は月次決算の日程 (例: [{name: 仮締め, offset: -2}, {name: 本締め, offset: 3}])、close サブコマンドの既定値
+	CloseChecklist []closeMilestoneYAML `yaml:"close_checklist"`
+	// Offices は拠点ごとのカレンダー (例: {tokyo: {parent: jp, extra_holidays: [...]}})、--calendar に名前で指定できる
+	Offices map[string]officeYAML `yaml:"offices"`
+
+	hours     *bizday.WorkHours              // WorkHours・Break を解釈したもの
+	weekend   []time.Weekday                 // Weekend を解釈したもの
+	extra     []bizday.HolidayEntry          // ExtraHolidays・WorkdaysOverride を解釈したもの
+	rules     []bizday.ClosureRule           // ClosureRules を解釈したもの
+	byWeekday map[time.Weekday]time.Duration // HoursPerWeekday を解釈したもの
+	offices   map[string]office              // Offices を解釈したもの
+}
+
+// officeYAML は拠点のカレンダー 1 件の定義
+// 親のカレンダー (登録済みのカレンダー、祝日データの calendars、他の拠点) の休業日に、拠点独自の休業日・営業日を重ねる
+type officeYAML struct {
+	Parent           string               `yaml:"parent"` // 省略時は jp
+	ExtraHolidays    []bizday.HolidayYAML `yaml:"extra_holidays"`
+	WorkdaysOverride []bizday.HolidayYAML `yaml:"workdays_override"`
+	ClosureRules     []closureRuleYAML    `yaml:"closure_rules"`
+	Weekend          []string             `yaml:"weekend"` // 省略時は親の定休日
+}
+
+// office は officeYAML を解釈したもの
+type office struct {
+	parent  string
+	extra   []bizday.HolidayEntry
+	rules   []bizday.ClosureRule
+	weekend []time.Weekday
+}
+
+// sprintYAML はスプリントの定義
+type sprintYAML struct {
+	Start  string `yaml:"start"`  // 最初のスプリントの初日 (例: 2025-04-07)
+	Length string `yaml:"length"` // 1 スプリントの長さ (例: 14d, 2w)
+}
+
+// closureRuleYAML は繰り返しの休業日の規則 1 件の定義
+type closureRuleYAML struct {
+	Weekday string `yaml:"weekday"` // 曜日の略称 (mon, wed など)
+	Nth     int    `yaml:"nth"`     // その月の何回目か (1~5、-1 なら最後、省略すると毎週)
+	Months  []int  `yaml:"months"`  // 対象の月 (省略すると毎月)
+	Name    string `yaml:"name"`
+}
+
+// rule は定義を bizday.ClosureRule にする
+func (r closureRuleYAML) rule() (bizday.ClosureRule, error) {
+	w, err := bizday.ParseWeekdays([]string{r.Weekday})
+	if err != nil {
+		return bizday.ClosureRule{}, err
+	}
+	rule := bizday.ClosureRule{Name: r.Name, Weekday: w[0], Nth: r.Nth}
+	for _, m := range r.Months {
+		rule.Months = append(rule.Months, time.Month(m))
+	}
+	return rule, rule.Validate()
+}
+
+// conf は起動時に読み込んだ設定
+var conf config
+
+// loadConfig は設定ファイルを読み込む
+// path が空なら既定の場所を読み、そこにファイルがなければ空の設定を返す
+func loadConfig(path string) (config, error) {
+	var c config
+	explicit := path != ""
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return c, nil
+		}
+		path = filepath.Join(dir, "bizday", "config.yaml")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			slog.Debug("設定ファイルがないので既定の設定を使います", "path", path)
+			return c, nil
+		}
+		return c, err
+	}
+	slog.Debug("設定ファイルを読み込みました", "path", path)
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("%s: %w", path, err)
+	}
+	switch c.Lang {
+	case "", "ja", "en":
+	default:
+		return c, fmt.Errorf("%s: lang には ja か en を指定してください: %s", path, c.Lang)
+	}
+	if c.Coverage != "" {
+		if err := checkCoveragePolicy(c.Coverage); err != nil {
+			return c, fmt.Errorf("%s: coverage: %w", path, err)
+		}
+	}
+	switch c.DateStyle {
+	case "", "iso", "ja":
+	default:
+		return c, fmt.Errorf("%s: date_style には iso か ja を指定してください: %s", path, c.DateStyle)
+	}
+	if len(c.HolidaySources) > 0 {
+		if c.Holidays != "" {
+			return c, fmt.Errorf("%s: holidays と holiday_sources は同時に指定できません", path)
+		}
+		if err := checkHolidaySources(c.HolidaySources); err != nil {
+			return c, fmt.Errorf("%s: holiday_sources: %w", path, err)
+		}
+	}
+	if c.WorkHours != "" {
+		h, err := bizday.ParseWorkHours(c.WorkHours, c.Break)
+		if err != nil {
+			return c, fmt.Errorf("%s: work_hours: %w", path, err)
+		}
+		c.hours = &h
+	} else if c.Break != "" {
+		return c, fmt.Errorf("%s: break は work_hours と合わせて指定してください", path)
+	}
+	if c.Weekend != nil {
+		if c.weekend, err = bizday.ParseWeekdays(c.Weekend); err != nil {
+			return c, fmt.Errorf("%s: weekend: %w", path, err)
+		}
+	}
+	if c.FiscalYearStart < 0 || c.FiscalYearStart > 12 {
+		return c, fmt.Errorf("%s: fiscal_year_start には 1~12 を指定してください", path)
+	}
+	if c.HoursPerDay < 0 || c.HoursPerDay > 24 {
+		return c, fmt.Errorf("%s: hours_per_day には 24 以下の正の値を指定してください", path)
+	}
+	for name, h := range c.HoursPerWeekday {
+		w, err := bizday.ParseWeekdays([]string{name})
+		if err != nil {
+			return c, fmt.Errorf("%s: hours_per_weekday: %w", path, err)
+		}
+		if h < 0 || h > 24 {
+			return c, fmt.Errorf("%s: hours_per_weekday: %s には 0~24 の値を指定してください", path, name)
+		}
+		if c.byWeekday == nil {
+			c.byWeekday = map[time.Weekday]time.Duration{}
+		}
+		c.byWeekday[w[0]] = hoursDuration(h)
+	}
+	for _, h := range c.ExtraHolidays {
+		e, err := h.Entry()
+		if err != nil {
+			return c, fmt.Errorf("%s: extra_holidays: %w", path, err)
+		}
+		c.extra = append(c.extra, e)
+	}
+	for _, h := range c.WorkdaysOverride {
+		e, err := h.Entry()
+		if err != nil {
+			return c, fmt.Errorf("%s: workdays_override: %w", path, err)
+		}
+		e.Workday = true
+		c.extra = append(c.extra, e)
+	}
+	if c.Payday < 0 || c.Payday > 31 {
+		return c, fmt.Errorf("%s: payday には 1~31 を指定してください", path)
+	}
+	if c.Sprint.Start != "" {
+		if _, err := bizday.ParseDate(c.Sprint.Start, time.Local); err != nil {
+			return c, fmt.Errorf("%s: sprint.start: %w", path, err)
+		}
+	}
+	if c.Sprint.Length != "" {
+		if _, err := parseSprintLength(c.Sprint.Length); err != nil {
+			return c, fmt.Errorf("%s: sprint.length: %w", path, err)
+		}
+	}
+	if err := checkCloseChecklist(c.CloseChecklist); err != nil {
+		return c, fmt.Errorf("%s: close_checklist: %w", path, err)
+	}
+	for _, r := range c.ClosureRules {
+		rule, err := r.rule()
+		if err != nil {
+			return c, fmt.Errorf("%s: closure_rules: %w", path, err)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	for name, o := range c.Offices {
+		off, err := o.office()
+		if err != nil {
+			return c, fmt.Errorf("%s: offices.%s: %w", path, name, err)
+		}
+		if c.offices == nil {
+			c.offices = map[string]office{}
+		}
+		c.offices[name] = off
+	}
+	names := make([]string, 0, len(c.offices))
+	for name := range c.offices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if chain, ok := c.officeCycle(name); ok {
+			return c, fmt.Errorf("%s: offices.%s: parent が循環しています (%s)", path, name, strings.Join(chain, " → "))
+		}
+	}
+	return c, nil
+}
+
+// office は定義を解釈する
+func (o officeYAML) office() (office, error) {
+	off := office{parent: o.Parent}
+	if off.parent == "" {
+		off.parent = "jp"
+	}
+	for _, h := range o.ExtraHolidays {
+		e, err := h.Entry()
+		if err != nil {
+			return off, fmt.Errorf("extra_holidays: %w", err)
+		}
+		off.extra = append(off.extra, e)
+	}
+	for _, h := range o.WorkdaysOverride {
+		e, err := h.Entry()
+		if err != nil {
+			return off, fmt.Errorf("workdays_override: %w", err)
+		}
+		e.Workday = true
+		off.extra = append(off.extra, e)
+	}
+	for _, r := range o.ClosureRules {
+		rule, err := r.rule()
+		if err != nil {
+			return off, fmt.Errorf("closure_rules: %w", err)
+		}
+		off.rules = append(off.rules, rule)
+	}
+	if o.Weekend != nil {
+		w, err := bizday.ParseWeekdays(o.Weekend)
+		if err != nil {
+			return off, fmt.Errorf("weekend: %w", err)
+		}
+		off.weekend = w
+	}
+	return off, nil
+}
+
+// officeCycle は name の拠点から parent をたどって同じ拠点に戻るなら、その経路を返す
+func (c config) officeCycle(name string) ([]string, bool) {
+	chain := []string{name}
+	seen := map[string]bool{name: true}
+	for {
+		o, ok := c.offices[chain[len(chain)-1]]
+		if !ok {
+			return nil, false
+		}
+		chain = append(chain, o.parent)
+		if seen[o.parent] {
+			return chain, true
+		}
+		seen[o.parent] = true
+	}
+}
+
+// officeHasWeekend は name の拠点か、その親の拠点に weekend の指定があるかを判定する
+func (c config) officeHasWeekend(name string) bool {
+	for i := 0; i <= len(c.offices); i++ {
+		o, ok := c.offices[name]
+		if !ok {
+			return false
+		}
+		if o.weekend != nil {
+			return true
+		}
+		name = o.parent
+	}
+	return false
+}
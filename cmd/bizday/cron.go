@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runCron は cron 式の次回以降の実行日時が営業日かどうかを表示する
+// 日のフィールドの営業日の修飾子 (B, B1, B-1 など) は --calendar のカレンダーの営業日で判定する
+func runCron(fs *flag.FlagSet) func() error {
+	from := fs.String("from", "", "この日時より後の実行を調べる (省略時は現在時刻)")
+	count := fs.Int("count", 5, "表示する実行回数")
+	shift := fs.Bool("shift", false, "営業日でない日の実行を次の営業日にずらして表示する")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("cron 式を 1 つ指定してください (例: bizday cron '30 9 * * *'、毎月最初の営業日なら '0 9 B1 * *')")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		sched, err := bizday.ParseCron(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		if *from != "" {
+			if start, err = parseDateTime(*from); err != nil {
+				return err
+			}
+		}
+
+		// 予定をすべて求めて祝日データの範囲を確かめてから表示する
+		var lines []string
+		t, last := start, start
+		for i := 0; i < *count; i++ {
+			next, ok := cal.NextCron(sched, t)
+			if !ok {
+				break
+			}
+			t, last = next, next
+			switch {
+			case cal.IsBusinessDay(next):
+				lines = append(lines, fmt.Sprintf(tr("%s 営業日"), formatDateTime(next)))
+			case *shift:
+				shifted, ok := cal.ShiftToBusinessDay(next)
+				if !ok {
+					return fmt.Errorf("%d 日以内に営業日がありません", bizday.CronSearchDays)
+				}
+				lines = append(lines, fmt.Sprintf(tr("%s 休業日 → %s に実行"), formatDateTime(next), formatDateTime(shifted)))
+				last = shifted
+			default:
+				lines = append(lines, fmt.Sprintf(tr("%s 休業日"), formatDateTime(next)))
+			}
+		}
+
+		next, ok := cal.NextBusinessFiring(sched, start)
+		if !ok {
+			return fmt.Errorf("%d 日以内に営業日の実行がありません", bizday.CronSearchDays)
+		}
+		if next.After(last) {
+			last = next
+		}
+		if err := checkCoverage(cal, start, last); err != nil {
+			return err
+		}
+		for _, l := range lines {
+			fmt.Println(l)
+		}
+		fmt.Printf(tr("次に営業日に実行されるのは %s です\n"), formatDateTime(next))
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runDeadline は開始日から N 営業日 (または N 暦日) 後の期日を表示する
+// 暦日で数えた期日が休業日に当たったときは --roll に従って営業日にずらす (Calendar.Roll)
+func runDeadline(fs *flag.FlagSet) func() error {
+	start := fs.String("start", "", "開始日 (例: 2025-04-01)、省略時は今日")
+	days := newSpanFlag(0, bizday.SpanBusinessDays)
+	fs.Var(days, "days", "期間 (例: 10 は 10 営業日、30d は 30 暦日、2w は 2 週間)")
+	includeStart := fs.Bool("include-start", false, "開始日が営業日なら 1 日目として数える")
+	roll := fs.String("roll", "forward", "期日が休業日に当たったときのずらし方 (forward, backward, modified-following, none: ずらさない)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		var conv bizday.RollConvention
+		if *roll != "none" {
+			c, err := bizday.ParseRollConvention(*roll)
+			if err != nil {
+				return err
+			}
+			conv = c
+		}
+		if days.span.Unit == bizday.SpanBusinessHours {
+			return fmt.Errorf("--days は営業日 (bd)・暦日 (d)・週 (w) で指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *start != "" {
+			if t, err = parseDateTime(*start); err != nil {
+				return err
+			}
+		}
+		t = bizday.BeginningOfDay(t)
+
+		n := int(days.span.Value)
+		var due time.Time
+		if days.span.Unit == bizday.SpanBusinessDays {
+			if err := checkAddBusinessDays(n); err != nil {
+				return err
+			}
+			if *includeStart && n > 0 && cal.IsBusinessDay(t) {
+				n--
+			}
+			if due, err = cal.AddBusinessDays(t, n); err != nil {
+				return err
+			}
+		} else {
+			if days.span.Unit == bizday.SpanWeeks {
+				n = int(days.span.Value * 7)
+			}
+			if *includeStart && n > 0 {
+				n--
+			}
+			due = t.AddDate(0, 0, n)
+		}
+
+		if conv != "" {
+			if due, err = cal.Roll(due, conv); err != nil {
+				return err
+			}
+		}
+		if err := checkCoverage(cal, t, due); err != nil {
+			return err
+		}
+		fmt.Println(formatDate(due))
+		return nil
+	}
+}
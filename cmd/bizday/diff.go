@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runDiff は 2 つの日付の間の営業日数を符号付きで表示する (2 つ目が 1 つ目より前なら負)
+// 1 つ目の日付は含めず、2 つ目の日付を含めて数える (bizday add -n の逆)
+func runDiff(fs *flag.FlagSet) func() error {
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if fs.NArg() != 2 {
+			return fmt.Errorf("日付を 2 つ指定してください (例: bizday diff 2025-04-10 2025-03-25)")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		a, err := parseDateTime(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		b, err := parseDateTime(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		if err := checkRange(a, b); err != nil {
+			return err
+		}
+		if err := checkCoverage(cal, a, b); err != nil {
+			return err
+		}
+		fmt.Println(cal.BusinessDaysBetween(a, b))
+		return nil
+	}
+}
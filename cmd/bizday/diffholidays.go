@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"bizday"
+)
+
+// holidayDiffJSON は diff-holidays --format json の出力
+type holidayDiffJSON struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Calendar      string              `json:"calendar"`
+	Old           string              `json:"old"`
+	New           string              `json:"new"`
+	AsOf          string              `json:"as_of"`
+	Changes       []holidayChangeJSON `json:"changes"`
+}
+
+// holidayChangeJSON は 1 日分の変更
+type holidayChangeJSON struct {
+	Kind    bizday.HolidayChangeKind `json:"kind"`
+	Date    string                   `json:"date"`
+	Workday bool                     `json:"workday,omitempty"`
+	OldName string                   `json:"old_name,omitempty"`
+	NewName string                   `json:"new_name,omitempty"`
+}
+
+// runDiffHolidays は 2 つの祝日データのファイルを比べ、追加・削除・名前の変わった祝日を表示する
+// 祝日の移動 (2020 年の海の日など) は元の日の削除と新しい日の追加として表示する
+// diff と同じく、違いがあれば終了コード 1、なければ 0 で終了する
+func runDiffHolidays(fs *flag.FlagSet) func() error {
+	calendar := fs.String("calendar", "jp", "比べるカレンダー (calendars の名前、jp なら先頭の holidays)")
+	asOf := fs.String("as-of", "", "この日付時点で有効な祝日どうしを比べる (省略時は今日)")
+	format := fs.String("format", "text", "出力形式 (text, json)")
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *format != "text" && *format != "json" {
+			return fmt.Errorf("--format には text か json を指定してください: %s", *format)
+		}
+		if fs.NArg() != 2 {
+			return fmt.Errorf("比べる祝日データのファイルを 2 つ指定してください (例: bizday diff-holidays old.yaml new.yaml)")
+		}
+		at := time.Now()
+		if *asOf != "" {
+			var err error
+			if at, err = parseDateTime(*asOf); err != nil {
+				return err
+			}
+		}
+		var sides [2][]bizday.HolidayEntry
+		for i := range sides {
+			path := fs.Arg(i)
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return dataError(err)
+			}
+			d, err := parseHolidayData(b, path)
+			if err != nil {
+				return dataError(fmt.Errorf("%s の読み込みに失敗しました: %w", path, err))
+			}
+			entries, ok := d.entriesFor(*calendar)
+			if !ok {
+				return dataError(fmt.Errorf("%s に %s の祝日がありません", path, *calendar))
+			}
+			sides[i] = entries
+		}
+
+		changes := bizday.DiffHolidays(sides[0], sides[1], at)
+		if *format == "json" {
+			out := holidayDiffJSON{SchemaVersion: bizday.SchemaVersion, Calendar: *calendar,
+				Old: fs.Arg(0), New: fs.Arg(1), AsOf: dateString(at), Changes: []holidayChangeJSON{}}
+			for _, c := range changes {
+				out.Changes = append(out.Changes, holidayChangeJSON{Kind: c.Kind, Date: dateString(c.Date),
+					Workday: c.Workday, OldName: c.OldName, NewName: c.NewName})
+			}
+			if err := encodeJSON(os.Stdout, out); err != nil {
+				return err
+			}
+		} else {
+			counts := map[bizday.HolidayChangeKind]int{}
+			for _, c := range changes {
+				counts[c.Kind]++
+				label := formatDate(c.Date)
+				if c.Workday {
+					label += " (振替出勤日)"
+				}
+				switch c.Kind {
+				case bizday.HolidayAdded:
+					fmt.Printf("+ %s %s\n", label, c.NewName)
+				case bizday.HolidayRemoved:
+					fmt.Printf("- %s %s\n", label, c.OldName)
+				case bizday.HolidayRenamed:
+					fmt.Printf("~ %s %s → %s\n", label, c.OldName, c.NewName)
+				}
+			}
+			fmt.Printf("追加 %d 件、削除 %d 件、名前の変更 %d 件\n",
+				counts[bizday.HolidayAdded], counts[bizday.HolidayRemoved], counts[bizday.HolidayRenamed])
+		}
+		if len(changes) > 0 {
+			return errFalse
+		}
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"bizday"
+)
+
+// runDump は期間の日ごとの分類を CSV (--format tsv ならタブ区切り) で 1 日 1 行書き出す
+// 列は date, weekday, is_business_day, holiday_name, cumulative_business_day_index で、
+// 表計算ソフトで勤怠データなどと突き合わせやすいよう日付は常に 2025-05-07 の形式にする
+func runDump(fs *flag.FlagSet) func() error {
+	fromStr := fs.String("from", "", "期間の開始日 (例: 2025-04-01)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-06-30)")
+	format := fs.String("format", "csv", "出力形式 (csv, tsv)")
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+
+		w := csv.NewWriter(os.Stdout)
+		switch *format {
+		case "csv":
+		case "tsv":
+			w.Comma = '\t'
+		default:
+			return fmt.Errorf("--format には csv か tsv を指定してください: %s", *format)
+		}
+		if *fromStr == "" || *toStr == "" {
+			return fmt.Errorf("--from と --to を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		from, err := parseDateTime(*fromStr)
+		if err != nil {
+			return err
+		}
+		to, err := parseDateTime(*toStr)
+		if err != nil {
+			return err
+		}
+		from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+		if to.Before(from) {
+			return fmt.Errorf("--to には --from 以降の日付を指定してください")
+		}
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+
+		w.Write([]string{"date", "weekday", "is_business_day", "holiday_name", "cumulative_business_day_index"})
+		index := 0
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			business := cal.IsBusinessDay(d)
+			if business {
+				index++
+			}
+			name, _ := cal.HolidayName(d)
+			w.Write([]string{dateString(d), d.Weekday().String()[:3], strconv.FormatBool(business), name, strconv.Itoa(index)})
+		}
+		w.Flush()
+		return w.Error()
+	}
+}
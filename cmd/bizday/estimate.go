@@ -0,0 +1,264 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bizday"
+)
+
+// scheduleYAML は estimate に渡す個人の予定のファイル
+//
+//	vacations:
+//	  - 2025-05-12
+//	  - {from: 2025-08-12, to: 2025-08-15}
+//	  - {date: 2025-05-20, half: true}
+//	half_days: [2025-12-26]
+//	meetings:
+//	  - {name: 定例, weekday: [mon], hours: 1}
+//	  - {name: 四半期レビュー, date: 2025-05-21, hours: 2}
+type scheduleYAML struct {
+	Vacations []timeOffYAML `yaml:"vacations"`
+	// HalfDays は半日だけ営業する日 (年末の短縮営業など)
+	HalfDays []string      `yaml:"half_days"`
+	Meetings []meetingYAML `yaml:"meetings"`
+}
+
+// timeOffYAML は休暇 1 件 (日付だけ、date の 1 日、または from~to の期間)
+type timeOffYAML struct {
+	Date string `yaml:"date"`
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+	Half bool   `yaml:"half"` // 半休
+}
+
+// UnmarshalYAML は日付だけのスカラーを 1 日の休暇として読み込む
+func (v *timeOffYAML) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		v.Date = n.Value
+		return nil
+	}
+	type plain timeOffYAML
+	return n.Decode((*plain)(v))
+}
+
+// meetingYAML は会議 1 件 (date の日だけ、または weekday の曜日に毎週)
+type meetingYAML struct {
+	Name    string   `yaml:"name"`
+	Date    string   `yaml:"date"`
+	Weekday []string `yaml:"weekday"`
+	Hours   float64  `yaml:"hours"`
+}
+
+// loadSchedule は個人の予定のファイルを読み込み、見積もりの条件に入れる
+func loadSchedule(path string, opt *bizday.WorkloadOptions) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var s scheduleYAML
+	if err := yaml.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	parse := func(section, v string) (time.Time, error) {
+		d, err := bizday.ParseDate(v, time.Local)
+		if err != nil {
+			return d, fmt.Errorf("%s: %s: %w", path, section, err)
+		}
+		return d, nil
+	}
+	for _, v := range s.Vacations {
+		from, to := v.From, v.To
+		if v.Date != "" {
+			if from != "" || to != "" {
+				return fmt.Errorf("%s: vacations: date と from/to は同時に指定できません", path)
+			}
+			from, to = v.Date, v.Date
+		}
+		if to == "" {
+			to = from
+		}
+		start, err := parse("vacations", from)
+		if err != nil {
+			return err
+		}
+		end, err := parse("vacations", to)
+		if err != nil {
+			return err
+		}
+		if end.Before(start) {
+			return fmt.Errorf("%s: vacations: to には from 以降の日付を指定してください", path)
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			opt.TimeOff = append(opt.TimeOff, bizday.TimeOff{Date: d, Half: v.Half})
+		}
+	}
+	for _, v := range s.HalfDays {
+		d, err := parse("half_days", v)
+		if err != nil {
+			return err
+		}
+		opt.HalfDays = append(opt.HalfDays, d)
+	}
+	for _, m := range s.Meetings {
+		if m.Hours <= 0 {
+			return fmt.Errorf("%s: meetings: %s: hours には正の値を指定してください", path, m.Name)
+		}
+		meeting := bizday.Meeting{Name: m.Name, Duration: hoursDuration(m.Hours)}
+		switch {
+		case m.Date != "" && m.Weekday != nil:
+			return fmt.Errorf("%s: meetings: %s: date と weekday は同時に指定できません", path, m.Name)
+		case m.Date != "":
+			if meeting.Date, err = parse("meetings", m.Date); err != nil {
+				return err
+			}
+		case m.Weekday != nil:
+			if meeting.Weekdays, err = bizday.ParseWeekdays(m.Weekday); err != nil {
+				return fmt.Errorf("%s: meetings: %s: %w", path, m.Name, err)
+			}
+		default:
+			return fmt.Errorf("%s: meetings: %s: date か weekday を指定してください", path, m.Name)
+		}
+		opt.Meetings = append(opt.Meetings, meeting)
+	}
+	return nil
+}
+
+// loadVacations は個人の予定のファイルから、1 日休む休暇を休業日として重ねる形で読み込む
+// 半休の日は営業日のまま数える (半日は働くので)
+func loadVacations(path string) ([]bizday.HolidayEntry, error) {
+	var opt bizday.WorkloadOptions
+	if err := loadSchedule(path, &opt); err != nil {
+		return nil, err
+	}
+	var entries []bizday.HolidayEntry
+	for _, t := range opt.TimeOff {
+		if !t.Half {
+			entries = append(entries, bizday.HolidayEntry{Date: t.Date, Name: vacationName})
+		}
+	}
+	return entries, nil
+}
+
+// vacationName は --vacations で重ねた休暇の日の名前
+const vacationName = "休暇"
+
+// workloadJSON は estimate --format json の出力
+type workloadJSON struct {
+	SchemaVersion  int     `json:"schema_version"`
+	Calendar       string  `json:"calendar"`
+	Start          string  `json:"start"`
+	End            string  `json:"end"`
+	BusinessDays   int     `json:"business_days"`
+	PlannedHours   float64 `json:"planned_hours"`
+	HalfDays       int     `json:"half_days"`
+	HalfDayHours   float64 `json:"half_day_hours"`
+	TimeOffDays    float64 `json:"time_off_days"`
+	TimeOffHours   float64 `json:"time_off_hours"`
+	Meetings       int     `json:"meetings"`
+	MeetingHours   float64 `json:"meeting_hours"`
+	AvailableHours float64 `json:"available_hours"`
+}
+
+// runEstimate は今月末 (--to があればその日) までの残りの稼働可能時間を見積もる
+// 残り営業日の想定稼働時間から、半日営業・個人の休暇 (半休を含む)・会議の時間を差し引く
+// 予定は --vacations (なければ設定ファイルの vacations) のファイルから読む
+// 休暇は営業日から差し引いて数えるので、営業日数と想定稼働時間は休暇を重ねる前の会社のカレンダーで数える
+func runEstimate(fs *flag.FlagSet) func() error {
+	toStr := fs.String("to", "", "見積もる期間の最後の日 (その日を含む、省略時は今月末)")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-05-07)")
+	includeToday := fs.Bool("include-today", false, "今日も残りの期間に含める")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	fte := fs.Float64("fte", 1, "稼働率 (例: 週 4 日勤務なら 0.8)、想定稼働時間に掛け合わせる (会議の時間には掛けない)")
+	format := fs.String("format", "text", "出力形式 (text, json)")
+	calFlags := addCalendarFlags(fs)
+	fs.Lookup("vacations").Usage = "個人の休暇・半日営業・会議を書いた YAML ファイル、省略時は設定ファイルの vacations"
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *format != "text" && *format != "json" {
+			return fmt.Errorf("--format には text か json を指定してください: %s", *format)
+		}
+		if *fte <= 0 {
+			return fmt.Errorf("--fte には正の値を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		if calFlags.company != nil {
+			cal = calFlags.company
+		}
+		vacations := calFlags.vacations
+		if vacations == "" {
+			vacations = conf.Vacations
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		today = bizday.BeginningOfDay(today)
+		to := bizday.BeginningOfDay(bizday.EndOfMonth(today))
+		if *toStr != "" {
+			if to, err = parseDateTime(*toStr); err != nil {
+				return err
+			}
+			to = bizday.BeginningOfDay(to)
+			if to.Before(today) {
+				return fmt.Errorf(tr("期限 %s は過ぎています"), formatDate(to))
+			}
+		}
+		from := today.AddDate(0, 0, 1)
+		if *includeToday {
+			from = today
+		}
+
+		opt := bizday.WorkloadOptions{Hours: dayHours(*hoursPerDay).Scale(*fte)}
+		if vacations != "" {
+			if err := loadSchedule(vacations, &opt); err != nil {
+				return dataError(fmt.Errorf("予定のファイルの読み込みに失敗しました: %w", err))
+			}
+		}
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+		w := cal.EstimateWorkload(from, to, opt)
+
+		if *format == "json" {
+			return writeJSON(workloadJSON{
+				SchemaVersion: bizday.SchemaVersion, Calendar: calFlags.country,
+				Start: dateString(w.Start), End: dateString(w.End), BusinessDays: w.BusinessDays,
+				PlannedHours: roundHours(w.Planned.Hours()),
+				HalfDays:     w.HalfDays, HalfDayHours: roundHours(w.HalfDayHours.Hours()),
+				TimeOffDays: w.TimeOffDays, TimeOffHours: roundHours(w.TimeOffHours.Hours()),
+				Meetings: w.Meetings, MeetingHours: roundHours(w.MeetingHours.Hours()),
+				AvailableHours: roundHours(w.Available.Hours()),
+			})
+		}
+		fmt.Printf(tr("期間 %s ~ %s の稼働見積もりです\n"), formatDate(from), formatDate(to))
+		fmt.Printf(tr("営業日 %d 日の想定稼働時間: %s 時間\n"), w.BusinessDays, formatHours(w.Planned.Hours()))
+		if w.HalfDays > 0 {
+			fmt.Printf(tr("半日営業 %d 日: -%s 時間\n"), w.HalfDays, formatHours(w.HalfDayHours.Hours()))
+		}
+		if w.TimeOffDays > 0 {
+			fmt.Printf(tr("休暇 %s 日: -%s 時間\n"), formatHours(w.TimeOffDays), formatHours(w.TimeOffHours.Hours()))
+		}
+		if w.Meetings > 0 {
+			fmt.Printf(tr("会議 %d 件: -%s 時間\n"), w.Meetings, formatHours(w.MeetingHours.Hours()))
+		}
+		fmt.Printf(tr("残りの稼働可能時間は %s 時間 です\n"), formatHours(w.Available.Hours()))
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 終了コード
+// ラッパースクリプトが「営業日ではない」と「祝日データの読み込みに失敗した」を区別できるように分けている
+const (
+	ExitOK      = 0 // 成功 / 判定が真
+	ExitFalse   = 1 // 判定が偽 (営業日でない・営業時間外など)
+	ExitUsage   = 2 // 引数・フラグの誤り
+	ExitData    = 3 // 祝日データの読み込み・検証の失敗
+	ExitNetwork = 4 // ネットワークの失敗
+)
+
+// exitError は終了コードを持つエラー
+type exitError struct {
+	code int
+	err  error // nil ならメッセージを出さずに終了する
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// errFalse は判定が偽だったことを表す (メッセージは出さず、終了コード 1 で終了する)
+var errFalse = &exitError{code: ExitFalse}
+
+// dataError は err を祝日データのエラー (終了コード 3) として包む
+func dataError(err error) error {
+	return &exitError{code: ExitData, err: err}
+}
+
+// networkError は err をネットワークのエラー (終了コード 4) として包む
+func networkError(err error) error {
+	return &exitError{code: ExitNetwork, err: err}
+}
+
+// exitCode は err に対応する終了コードを返す
+// 終了コードを持たないエラーは引数・入力の誤りとして ExitUsage を返す
+func exitCode(err error) int {
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return ExitUsage
+}
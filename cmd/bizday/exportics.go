@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"bizday"
+)
+
+// icsEvent は終日の予定 1 件
+type icsEvent struct {
+	date    time.Time
+	summary string
+	kind    string // UID の区別に使う種類 (holiday, business)
+}
+
+// runExportICS は祝日 (--business-days なら営業日も) を終日の予定にした iCalendar (.ics) を書き出す
+// Google カレンダーや Outlook で読み込んだり、URL で購読したりできる
+func runExportICS(fs *flag.FlagSet) func() error {
+	year := fs.Int("year", time.Now().Year(), "対象の年 (--from/--to を指定するとそちらを使う)")
+	fromStr := fs.String("from", "", "期間の開始日 (例: 2025-04-01)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2026-03-31)")
+	business := fs.Bool("business-days", false, "営業日も「第N営業日」の予定として含める")
+	out := fs.String("o", "", "書き出すファイル (省略時は標準出力)")
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		start := time.Date(*year, 1, 1, 0, 0, 0, 0, time.Local)
+		end := time.Date(*year, 12, 31, 0, 0, 0, 0, time.Local)
+		if *fromStr != "" || *toStr != "" {
+			if *fromStr == "" || *toStr == "" {
+				return fmt.Errorf("--from と --to は両方指定してください")
+			}
+			if start, err = parseDateTime(*fromStr); err != nil {
+				return err
+			}
+			if end, err = parseDateTime(*toStr); err != nil {
+				return err
+			}
+			start, end = bizday.BeginningOfDay(start), bizday.BeginningOfDay(end)
+			if end.Before(start) {
+				return fmt.Errorf("--to には --from 以降の日付を指定してください")
+			}
+		}
+
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		var events []icsEvent
+		for _, h := range cal.HolidaysBetween(start, end) {
+			name := h.Name
+			if name == "" {
+				name = "休日"
+			}
+			events = append(events, icsEvent{date: h.Date, summary: name, kind: "holiday"})
+		}
+		if *business {
+			index := 0
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				// 期間の途中の月から始まる場合も、月初からの営業日目で数える
+				if d.Equal(start) || d.Day() == 1 {
+					index = 0
+					if d.Day() > 1 {
+						index, _ = cal.CountBusinessDays(bizday.BeginningOfMonth(d), d.AddDate(0, 0, -1))
+					}
+				}
+				if cal.IsBusinessDay(d) {
+					index++
+					events = append(events, icsEvent{date: d, summary: indexLabel(index), kind: "business"})
+				}
+			}
+		}
+
+		title := fmt.Sprintf("bizday %s (%s~%s)", calFlags.country, dateString(start), dateString(end))
+		b := buildICS(title, calFlags.country, events, time.Now())
+		if *out == "" {
+			_, err = os.Stdout.Write(b)
+			return err
+		}
+		if err := os.WriteFile(*out, b, 0o644); err != nil {
+			return fmt.Errorf("iCalendar の書き出しに失敗: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "%s に %d 件の予定を書き出しました\n", *out, len(events))
+		return nil
+	}
+}
+
+// buildICS は events を終日の予定 (透過、空き時間扱い) にした iCalendar を組み立てる
+// UID は日付・種類・カレンダー名から決めるので、書き出し直しても購読側で重複しない
+func buildICS(title, calendar string, events []icsEvent, now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(s string) {
+		b.WriteString(foldICS(s))
+		b.WriteString("\r\n")
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//bizday//bizday//JA")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICS(title))
+	for _, e := range events {
+		day := e.date.Format("20060102")
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s-%s-%s@bizday", day, e.kind, calendar))
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + day)
+		line("DTEND;VALUE=DATE:" + e.date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICS(e.summary))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return b.Bytes()
+}
+
+// escapeICS は TEXT 型の値の特殊文字 (\ ; , 改行) をエスケープする
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICS は 75 オクテットを超える行を、UTF-8 の文字の途中で切らないように折り返す
+func foldICS(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 1 // 継続行の先頭の空白
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
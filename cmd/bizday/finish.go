@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runFinish は残作業時間から、営業時間に沿った完了見込み日時を表示する
+func runFinish(fs *flag.FlagSet) func() error {
+	hours := newSpanFlag(0, bizday.SpanBusinessHours)
+	fs.Var(hours, "hours", "残作業時間 (例: 12.5, 3bd)、単位を省略すると時間")
+	fte := fs.Float64("fte", 1, "稼働率 (例: 0.8)、作業に充てられる割合で完了見込みを延ばす")
+	from := fs.String("from", "", "作業を始める日時 (省略時は現在時刻)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		if *fte <= 0 {
+			return fmt.Errorf("--fte には正の値を指定してください")
+		}
+
+		t := time.Now()
+		if *from != "" {
+			t, err = parseDateTime(*from)
+			if err != nil {
+				return err
+			}
+		}
+
+		work := cal.SpanDuration(t, hours.span)
+		if work <= 0 {
+			return fmt.Errorf("--hours に正の値を指定してください")
+		}
+		done, err := cal.ProjectCompletion(t, time.Duration(float64(work) / *fte))
+		if err != nil {
+			return err
+		}
+		if err := checkCoverage(cal, t, done); err != nil {
+			return err
+		}
+		fmt.Printf(tr("完了見込みは %s です\n"), formatDateTime(done))
+		return nil
+	}
+}
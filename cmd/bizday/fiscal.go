@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runFiscal は今日を含む会計年度と各四半期の営業日の経過状況を表示する
+// 会計年度の始まりの月は --start-month か設定ファイルの fiscal_year_start (既定は 4 月)
+func runFiscal(fs *flag.FlagSet) func() error {
+	def := 4
+	if conf.FiscalYearStart != 0 {
+		def = conf.FiscalYearStart
+	}
+	startMonth := fs.Int("start-month", def, "会計年度の始まりの月 (1~12)")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-10-15)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *startMonth < 1 || *startMonth > 12 {
+			return fmt.Errorf("--start-month には 1~12 を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+
+		start, end, year := bizday.FiscalYear(today, time.Month(*startMonth))
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		st := cal.PeriodStats(start, end, today)
+		fmt.Printf(tr("%d年度 (%s ~ %s)\n"), year, formatDate(start), formatDate(end))
+		fmt.Printf(tr("今日は今年度の %s 営業日目 です (全 %d 日、残り %d 日、%.1f %% 経過)\n"),
+			ordinal(st.Elapsed), st.BusinessDays, st.Remaining, percent(st.Elapsed, st.BusinessDays))
+
+		current, _, _ := bizday.FiscalQuarter(today, time.Month(*startMonth))
+		for q := 1; q <= 4; q++ {
+			qs := start.AddDate(0, 3*(q-1), 0)
+			qe := qs.AddDate(0, 3, -1)
+			s := cal.PeriodStats(qs, qe, today)
+			mark := " "
+			if q == current {
+				mark = "*"
+			}
+			fmt.Printf(tr("%s 第%d四半期 (%d月~%d月): 営業日 %d 日、経過 %d 日、残り %d 日 (%.1f %% 経過)\n"),
+				mark, q, qs.Month(), qe.Month(), s.BusinessDays, s.Elapsed, s.Remaining, percent(s.Elapsed, s.BusinessDays))
+		}
+		return nil
+	}
+}
+
+// percent は n / total を百分率にする (total が 0 なら 0)
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// dateStyle は日付の出力形式 ("iso" または "ja")
+var dateStyle = "iso"
+
+// showRokuyo が true なら日付に六曜を添える
+var showRokuyo bool
+
+// weekdaysJA は曜日の漢字表記
+var weekdaysJA = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// addDateStyleFlag は出力の形式を切り替える --date-style・--rokuyo・--lang フラグを登録する
+func addDateStyleFlag(fs *flag.FlagSet) {
+	fs.StringVar(&dateStyle, "date-style", dateStyle, "日付の出力形式 (iso: 2025-05-07, ja: 2025年5月7日(水))")
+	fs.BoolVar(&showRokuyo, "rokuyo", showRokuyo, "日付に六曜 (大安・仏滅など) を添える")
+}
+
+// checkDateStyle は --date-style・--lang の値が対応しているかを確認する
+func checkDateStyle() error {
+	switch dateStyle {
+	case "iso", "ja":
+		return checkLang()
+	}
+	return fmt.Errorf("未対応の日付形式: %s (iso または ja を指定してください)", dateStyle)
+}
+
+// formatDate は dateStyle に従って日付を整形する
+func formatDate(t time.Time) string {
+	return annotate(plainDate(t), t)
+}
+
+// formatDateTime は dateStyle に従って日時 (分まで) を整形する
+func formatDateTime(t time.Time) string {
+	return annotate(plainDate(t)+" "+t.Format("15:04"), t)
+}
+
+// plainDate は dateStyle に従った日付のみの表記を返す
+func plainDate(t time.Time) string {
+	if dateStyle == "ja" {
+		return fmt.Sprintf("%d年%d月%d日(%s)", t.Year(), t.Month(), t.Day(), weekdaysJA[t.Weekday()])
+	}
+	return t.Format("2006-01-02")
+}
+
+// annotate は有効になっている注記 (六曜) を s の後ろに付ける
+func annotate(s string, t time.Time) string {
+	if showRokuyo {
+		s += " " + bizday.Rokuyo(t)
+	}
+	return s
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"bizday"
+)
+
+// runGen は -year 年の日本の祝日を holidays.yaml の形式で出力する
+// 祝日は規則 (国民の祝日に関する法律) で算出し、-csv を指定すれば内閣府の syukujitsu.csv から取る
+// -o のファイルがあれば、その holidays の一覧に日付順の位置で書き足す (コメントや他の項目はそのまま残す)
+func runGen(fs *flag.FlagSet) func() error {
+	year := fs.Int("year", time.Now().Year()+1, "生成する年")
+	out := fs.String("o", "", "書き込む YAML ファイル (あれば holidays に追記、省略時は標準出力)")
+	csvPath := fs.String("csv", "", "規則で算出する代わりに使う内閣府の祝日 CSV (syukujitsu.csv)")
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+
+		hs, err := genHolidays(*year, *csvPath)
+		if err != nil {
+			return err
+		}
+		if len(hs) == 0 {
+			return fmt.Errorf("%d 年の祝日がありません", *year)
+		}
+		var items bytes.Buffer
+		for _, h := range hs {
+			fmt.Fprintf(&items, "  - {date: %q, name: %s}\n", h.Date.Format("2006-01-02"), yamlFlowScalar(h.Name))
+		}
+
+		if *out == "" {
+			fmt.Print("holidays:\n" + items.String())
+			return nil
+		}
+		data, err := os.ReadFile(*out)
+		if os.IsNotExist(err) {
+			data = nil
+		} else if err != nil {
+			return err
+		}
+		merged, err := insertHolidays(data, *year, items.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", *out, err)
+		}
+		if err := os.WriteFile(*out, merged, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("%s に %d 年の祝日 %d 件を書き込みました\n", *out, *year, len(hs))
+		if *csvPath == "" {
+			fmt.Println("春分・秋分の日は近似式による予測値です。官報の公示 (前年 2 月) 後に確認してください")
+		}
+		return nil
+	}
+}
+
+// genHolidays は year 年の日本の祝日 (振替休日・国民の休日を含む) を日付順に返す
+// csvPath が空なら規則で算出する
+func genHolidays(year int, csvPath string) ([]bizday.Holiday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, -1)
+	if csvPath == "" {
+		return bizday.NewJapanCalendarAsOf(nil, time.Now()).HolidaysBetween(from, to), nil
+	}
+	b, err := os.ReadFile(csvPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := bizday.ParseSyukujitsuCSV(b)
+	if err != nil {
+		return nil, dataError(fmt.Errorf("%s: %w", csvPath, err))
+	}
+	return bizday.NewCalendarAsOf(entries, time.Now()).HolidaysBetween(from, to), nil
+}
+
+// insertHolidays は holidays.yaml の形式のデータ data の holidays の一覧に、year 年の祝日の行 items を書き足す
+// year より後の年の祝日があればその前に、なければ一覧の最後に入れる。data が空なら holidays の一覧を作る
+func insertHolidays(data []byte, year int, items string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	appendList := func() []byte {
+		if data = bytes.TrimRight(data, "\n"); len(data) > 0 {
+			data = append(data, '\n')
+		}
+		return append(data, "holidays:\n"+items...)
+	}
+	if len(doc.Content) == 0 {
+		return appendList(), nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("holidays.yaml の形式ではありません")
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	insert := func(at int) []byte {
+		var b strings.Builder
+		for i, l := range lines {
+			if i == at {
+				b.WriteString(items)
+			}
+			b.WriteString(l)
+			if i == len(lines)-1 && !strings.HasSuffix(l, "\n") {
+				b.WriteString("\n")
+			}
+		}
+		if at >= len(lines) {
+			b.WriteString(items)
+		}
+		return []byte(b.String())
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "holidays" {
+			continue
+		}
+		seq := root.Content[i+1]
+		if seq.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("holidays が一覧になっていません")
+		}
+		for _, n := range seq.Content {
+			var h bizday.HolidayYAML
+			if err := n.Decode(&h); err != nil {
+				return nil, err
+			}
+			d, err := bizday.ParseDate(h.Date, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("%d 行目: 祝日のパースに失敗: %w", n.Line, err)
+			}
+			y := d.Year()
+			if y == year {
+				return nil, fmt.Errorf("%d 年の祝日はすでにあります (%d 行目)。書き直すなら overrides を使うか、その年の行を消してください", year, n.Line)
+			}
+			if y > year {
+				return insert(n.Line - 1), nil
+			}
+		}
+		// 一覧の最後: 次のトップレベルの項目 (の前のコメント・空行) の手前に入れる
+		end := len(lines)
+		if i+2 < len(root.Content) {
+			end = root.Content[i+2].Line - 1
+			for end > 0 {
+				l := strings.TrimSpace(lines[end-1])
+				if l != "" && !strings.HasPrefix(l, "#") {
+					break
+				}
+				end--
+			}
+		}
+		if len(seq.Content) == 0 {
+			// "holidays: []" のような空の一覧は書き換える
+			lines[root.Content[i].Line-1] = "holidays:\n"
+		}
+		return insert(end), nil
+	}
+	return appendList(), nil
+}
+
+// yamlFlowScalar は s を {date: ..., name: ...} の中に書ける YAML のスカラーにする
+// 区切りの記号などを含む名前だけ引用符で囲む
+func yamlFlowScalar(s string) string {
+	if s == "" || strings.ContainsAny(s, ",:[]{}#&*!|>'\"%@`") || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"bizday"
+	bizdayv1 "bizday/gen/bizday/v1"
+)
+
+// grpcServer は proto/bizday/v1/bizday.proto の BizdayService の実装
+// 処理は HTTP API と同じ server の isBusinessDay・add・count・monthSummary に任せ、応答を proto のメッセージに詰め替える
+type grpcServer struct {
+	bizdayv1.UnimplementedBizdayServiceServer
+	s *server
+}
+
+// newGRPCServer は BizdayService を登録した gRPC サーバを返す
+// --keys に read のキーがあれば、メタデータ authorization の Bearer のキーを確かめる
+func newGRPCServer(s *server) *grpc.Server {
+	var opts []grpc.ServerOption
+	if need := s.queryRole(); need != "" {
+		opts = append(opts, grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authorizeGRPC(ctx, need); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}))
+	}
+	g := grpc.NewServer(opts...)
+	bizdayv1.RegisterBizdayServiceServer(g, &grpcServer{s: s})
+	return g
+}
+
+// gatewayHandler は HTTP API の /v1/... を grpc-gateway 経由で BizdayService に渡すハンドラを返す (serve --gateway)
+// 同じプロセスの grpcServer を直接呼ぶので、gRPC の待ち受けがなくても使える
+// 項目名は proto の名前 (business_day など) で、省略された値も出すので応答の形は HTTP API とほぼ同じになる
+// ただし class は DAY_CLASS_HOLIDAY のような列挙の名前で、エラーは grpc-gateway の形式 ({"code": 3, "message": ...}) になる
+func gatewayHandler(s *server) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+	}))
+	if err := bizdayv1.RegisterBizdayServiceHandlerServer(context.Background(), mux, &grpcServer{s: s}); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// authorizeGRPC は gRPC の要求のキーが役割 need を持つかを確かめる (なければ Unauthenticated、足りなければ PermissionDenied)
+func (s *server) authorizeGRPC(ctx context.Context, need string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	r := &http.Request{Header: http.Header{}}
+	for _, v := range md.Get("authorization") {
+		r.Header.Add("Authorization", v)
+	}
+	k, ok := authenticate(s.keys, r)
+	if !ok {
+		return status.Error(codes.Unauthenticated, errUnauthorized.Error())
+	}
+	if !authorizes(k.Role, need) {
+		return status.Error(codes.PermissionDenied, errForbidden.Error())
+	}
+	return nil
+}
+
+// invalidArgument は要求の誤り err を gRPC の InvalidArgument にする
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// IsBusinessDay は GET /v1/is-business-day と同じく、日付が営業日かどうかと分類を返す
+func (g *grpcServer) IsBusinessDay(ctx context.Context, req *bizdayv1.IsBusinessDayRequest) (*bizdayv1.IsBusinessDayResponse, error) {
+	out, err := g.s.isBusinessDay(req.GetCalendar(), req.GetDate())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &bizdayv1.IsBusinessDayResponse{
+		SchemaVersion: int32(out.SchemaVersion),
+		Calendar:      out.Calendar,
+		Date:          out.Date,
+		BusinessDay:   out.BusinessDay,
+		Class:         protoDayClass(out.Class),
+		Name:          out.Name,
+	}, nil
+}
+
+// Count は GET /v1/count と同じく、期間 (両端を含む) の営業日数と想定稼働時間を返す
+func (g *grpcServer) Count(ctx context.Context, req *bizdayv1.CountRequest) (*bizdayv1.CountResponse, error) {
+	out, err := g.s.count(req.GetCalendar(), req.GetFrom(), req.GetTo(), req.GetBreakdown())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	res := &bizdayv1.CountResponse{
+		SchemaVersion: int32(out.SchemaVersion),
+		Calendar:      out.Calendar,
+		From:          out.From,
+		To:            out.To,
+		BusinessDays:  int32(out.BusinessDays),
+		Hours:         out.Hours,
+	}
+	if b := out.Breakdown; b != nil {
+		res.Breakdown = &bizdayv1.Breakdown{
+			TotalDays:    int32(b.TotalDays),
+			WeekendDays:  int32(b.WeekendDays),
+			Holidays:     int32(b.Holidays),
+			Closures:     int32(b.Closures),
+			Workdays:     int32(b.Workdays),
+			BusinessDays: int32(b.BusinessDays),
+		}
+	}
+	return res, nil
+}
+
+// AddBusinessDays は GET /v1/add と同じく、日付の n 営業日後 (負なら前) の日付を返す
+func (g *grpcServer) AddBusinessDays(ctx context.Context, req *bizdayv1.AddBusinessDaysRequest) (*bizdayv1.AddBusinessDaysResponse, error) {
+	out, err := g.s.add(req.GetCalendar(), req.GetDate(), int(req.GetN()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &bizdayv1.AddBusinessDaysResponse{
+		SchemaVersion: int32(out.SchemaVersion),
+		Calendar:      out.Calendar,
+		Date:          out.Date,
+		N:             int32(out.N),
+		Result:        out.Result,
+	}, nil
+}
+
+// MonthSummary は GET /v1/month-summary と同じく、月の営業日の経過状況を返す
+func (g *grpcServer) MonthSummary(ctx context.Context, req *bizdayv1.MonthSummaryRequest) (*bizdayv1.MonthSummaryResponse, error) {
+	out, err := g.s.monthSummary(req.GetCalendar(), req.GetMonth())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	res := &bizdayv1.MonthSummaryResponse{
+		SchemaVersion:          int32(out.SchemaVersion),
+		Calendar:               out.Calendar,
+		Date:                   out.Date,
+		Month:                  out.Month,
+		BusinessDayIndex:       int32(out.BusinessDayIndex),
+		BusinessDayIndexLabel:  out.BusinessDayIndexLabel,
+		BusinessDaysTotal:      int32(out.BusinessDaysTotal),
+		BusinessDaysRemaining:  int32(out.BusinessDaysRemaining),
+		PercentElapsed:         out.PercentElapsed,
+		RemainingHours:         out.RemainingHours,
+		WorkedHours:            out.WorkedHours,
+		CalendarDaysTotal:      int32(out.CalendarDaysTotal),
+		CalendarDaysElapsed:    int32(out.CalendarDaysElapsed),
+		CalendarDaysRemaining:  int32(out.CalendarDaysRemaining),
+		CalendarPercentElapsed: out.CalendarPercentElapsed,
+	}
+	for _, h := range out.Holidays {
+		res.Holidays = append(res.Holidays, &bizdayv1.Holiday{Date: h.Date, Name: h.Name})
+	}
+	return res, nil
+}
+
+// protoDayClass は bizday.DayClass を proto の DayClass にする
+func protoDayClass(c bizday.DayClass) bizdayv1.DayClass {
+	switch c {
+	case bizday.ClassBusiness:
+		return bizdayv1.DayClass_DAY_CLASS_BUSINESS
+	case bizday.ClassWeekend:
+		return bizdayv1.DayClass_DAY_CLASS_WEEKEND
+	case bizday.ClassHoliday:
+		return bizdayv1.DayClass_DAY_CLASS_HOLIDAY
+	case bizday.ClassWorkday:
+		return bizdayv1.DayClass_DAY_CLASS_WORKDAY
+	}
+	return bizdayv1.DayClass_DAY_CLASS_UNSPECIFIED
+}
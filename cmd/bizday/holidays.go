@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// holidaySearchYears は --next で今後の祝日を探す範囲 (年数)
+// 祝日のないカレンダーで探し続けないように区切る
+const holidaySearchYears = 5
+
+// runHolidays は今後の祝日 (--year ならその年の祝日) を曜日と名前付きで一覧表示する
+// 定休日に重なる祝日も含めるので、祝日の早見表として使える
+func runHolidays(fs *flag.FlagSet) func() error {
+	next := fs.Int("next", 5, "表示する今後の祝日の件数")
+	year := fs.Int("year", 0, "この年の祝日をすべて表示する (例: 2025)")
+	from := fs.String("from", "", "この日の翌日から数える (省略時は今日)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		var hs []bizday.Holiday
+		if *year != 0 {
+			start, end := time.Date(*year, 1, 1, 0, 0, 0, 0, time.Local), time.Date(*year, 12, 31, 0, 0, 0, 0, time.Local)
+			if err := checkCoverage(cal, start, end); err != nil {
+				return err
+			}
+			hs = cal.HolidaysBetween(start, end)
+		} else {
+			if *next <= 0 {
+				return fmt.Errorf("--next には 1 以上の件数を指定してください")
+			}
+			t := time.Now()
+			if *from != "" {
+				if t, err = parseDateTime(*from); err != nil {
+					return err
+				}
+			}
+			start := bizday.BeginningOfDay(t).AddDate(0, 0, 1)
+			end := start.AddDate(holidaySearchYears, 0, 0)
+			hs = cal.HolidaysBetween(start, end)
+			if len(hs) >= *next {
+				hs = hs[:*next]
+				end = hs[len(hs)-1].Date
+			}
+			// 件数に足りなければ探した範囲全体、足りれば最後の祝日までのデータが要る
+			if err := checkCoverage(cal, start, end); err != nil {
+				return err
+			}
+		}
+
+		if len(hs) == 0 {
+			fmt.Println(tr("該当する祝日はありません"))
+			return nil
+		}
+		for _, h := range hs {
+			name := holidayLabel(h.Name)
+			if name == "" {
+				name = tr("休日")
+			}
+			fmt.Printf("%s %s\n", formatListDate(h.Date), name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"bizday"
+)
+
+// addHoursPerDayFlag は --hours-per-day を fs に登録する
+// 既定値は設定ファイルの hours_per_day (なければ DefaultWorkHours の 8 時間)
+func addHoursPerDayFlag(fs *flag.FlagSet) *float64 {
+	def := bizday.DefaultWorkHours.Duration().Hours()
+	if conf.HoursPerDay > 0 {
+		def = conf.HoursPerDay
+	}
+	h := hoursPerDayFlag(def)
+	fs.Var(&h, "hours-per-day", "1 営業日あたりの想定稼働時間 (0 より大きく 24 以下、例: 7.5)、設定ファイルの hours_per_weekday にある曜日はそちらを使う")
+	return (*float64)(&h)
+}
+
+// hoursPerDayFlag は --hours-per-day の値 (0 より大きく 24 以下の時間数)
+// 上限があるので、1 か月や 1 年の想定稼働時間の合計は time.Duration の範囲に収まる
+type hoursPerDayFlag float64
+
+func (f *hoursPerDayFlag) String() string { return strconv.FormatFloat(float64(*f), 'f', -1, 64) }
+
+func (f *hoursPerDayFlag) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	if v <= 0 || v > 24 {
+		return fmt.Errorf("0 より大きく 24 以下の時間数を指定してください: %s", s)
+	}
+	*f = hoursPerDayFlag(v)
+	return nil
+}
+
+// dayHours は営業日 1 日あたり hoursPerDay 時間 (設定ファイルの hours_per_weekday の曜日はその時間) の DayHours を返す
+func dayHours(hoursPerDay float64) bizday.DayHours {
+	return bizday.DayHours{Default: hoursDuration(hoursPerDay), ByWeekday: conf.byWeekday}
+}
+
+// hoursDuration は時間数 (7.5 など) を time.Duration にする
+func hoursDuration(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
+
+// runHours は今月 (--month・--from/--to があればその期間) の想定稼働時間を表示する
+// 曜日ごとの稼働時間は設定ファイルの hours_per_day・hours_per_weekday で変えられる
+func runHours(fs *flag.FlagSet) func() error {
+	hoursPerDay := addHoursPerDayFlag(fs)
+	fte := fs.Float64("fte", 1, "稼働率 (例: 週 4 日勤務なら 0.8)、時間に掛け合わせる")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-04-15)")
+	monthStr := fs.String("month", "", "対象の月 (例: 2025-07、--year と合わせて 7 とも書ける)")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	fromStr := fs.String("from", "", "期間の開始日 (--to と合わせて指定する)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-09-30)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		if *fte <= 0 {
+			return fmt.Errorf("--fte には正の値を指定してください")
+		}
+		h := dayHours(*hoursPerDay).Scale(*fte)
+
+		if *fromStr != "" || *toStr != "" {
+			if *fromStr == "" || *toStr == "" {
+				return fmt.Errorf("--from と --to は両方指定してください")
+			}
+			from, err := parseDateTime(*fromStr)
+			if err != nil {
+				return err
+			}
+			to, err := parseDateTime(*toStr)
+			if err != nil {
+				return err
+			}
+			from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+			if err := checkCoverage(cal, from, to); err != nil {
+				return err
+			}
+			days, err := cal.CountBusinessDays(from, to)
+			if err != nil {
+				return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+			}
+			hours, err := cal.PlannedHours(h, from, to)
+			if err != nil {
+				return err
+			}
+			fmt.Printf(tr("期間 %s ~ %s の想定稼働時間は %s 時間 です (営業日 %d 日)\n"),
+				formatDate(from), formatDate(to), formatHours(hours.Hours()), days)
+			return nil
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		if *monthStr != "" || *year != 0 {
+			if *date != "" {
+				return fmt.Errorf("--month と --date は同時に指定できません")
+			}
+			month, err := parseMonth(*monthStr, *year)
+			if err != nil {
+				return err
+			}
+			today = monthReference(month, today)
+		}
+		start, end := bizday.BeginningOfMonth(today), bizday.EndOfMonth(today)
+		day := bizday.BeginningOfDay(today)
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		// 1 か月分なので桁あふれしない
+		total, _ := cal.PlannedHours(h, start, end)
+		elapsed, _ := cal.PlannedHours(h, start, day)
+		fmt.Printf(tr("%d年%d月の想定稼働時間は %s 時間 です\n"), start.Year(), start.Month(), formatHours(total.Hours()))
+		fmt.Printf(tr("%s までに %s 時間、残り %s 時間 です\n"),
+			formatDate(today), formatHours(elapsed.Hours()), formatHours((total - elapsed).Hours()))
+		return nil
+	}
+}
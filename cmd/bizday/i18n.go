@@ -0,0 +1,277 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// langEnv は出力の言語を指定する環境変数 (--lang の既定値)
+const langEnv = "BIZDAY_LANG"
+
+// lang は出力の言語 ("ja" または "en")
+var lang = "ja"
+
+// weekdaysEN は曜日の英語の略称
+var weekdaysEN = [...]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// detectLang は $BIZDAY_LANG、設定ファイルの lang、$LC_ALL・$LC_MESSAGES・$LANG の順に出力の言語を決める
+// ロケールが英語など日本語以外なら en、C・POSIX や未設定なら既定の ja にする
+func detectLang() string {
+	for _, v := range []string{os.Getenv(langEnv), conf.Lang} {
+		if v != "" {
+			return v
+		}
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(key)
+		if v == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(v, "ja"), v == "C", v == "POSIX", strings.HasPrefix(v, "C."):
+			return "ja"
+		default:
+			return "en"
+		}
+	}
+	return "ja"
+}
+
+// addLangFlag は --lang を fs に登録する (newFlagSet ですべてのサブコマンドに登録する)
+// 訳すのはサブコマンドの結果の出力と祝日の名前で、エラーメッセージはカタログにあるものだけを訳す
+func addLangFlag(fs *flag.FlagSet) {
+	fs.StringVar(&lang, "lang", lang, "出力の言語 (ja, en)、省略時は $"+langEnv+"・設定ファイルの lang・$LANG から決める (フラグの説明と多くのエラーメッセージは日本語のまま)")
+}
+
+// checkLang は --lang の値が対応しているかを確認する
+func checkLang() error {
+	switch lang {
+	case "ja", "en":
+		return nil
+	}
+	return fmt.Errorf("未対応の言語: %s (ja または en を指定してください)", lang)
+}
+
+// weekdayLabel は曜日 w の lang の言語での略称 (ja なら 月、en なら Mon)
+func weekdayLabel(w time.Weekday) string {
+	if lang == "en" {
+		return weekdaysEN[w]
+	}
+	return weekdaysJA[w]
+}
+
+// tr は日本語の文言 (fmt の書式を含む) を lang の言語にする
+// カタログにない文言や ja のときはそのまま返すので、訳のない出力やエラーは日本語で表示される
+func tr(s string) string {
+	if lang == "en" {
+		if t, ok := catalogEN[s]; ok {
+			return t
+		}
+	}
+	return s
+}
+
+// holidayLabel は祝日の名前 name を lang の言語にする
+// 組み合わせたカレンダーの「元日 / New Year's Day」のような名前は、区切りごとに訳して重複を除く
+// 訳のない名前 (祝日データや設定ファイルで付けた名前) はそのまま返す
+func holidayLabel(name string) string {
+	if lang != "en" || name == "" {
+		return name
+	}
+	parts := strings.Split(name, " / ")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if t, ok := holidayNamesEN[p]; ok {
+			p = t
+		}
+		if !slices.Contains(out, p) {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " / ")
+}
+
+// ordinal は n の序数の表記を lang の言語で返す (ja なら 5、en なら 5th のように英語の接尾辞を付ける)
+func ordinal(n int) string {
+	if lang != "en" {
+		return strconv.Itoa(n)
+	}
+	suffix := "th"
+	switch abs := max(n, -n); {
+	case abs%100 >= 11 && abs%100 <= 13:
+	case abs%10 == 1:
+		suffix = "st"
+	case abs%10 == 2:
+		suffix = "nd"
+	case abs%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// holidayNamesEN は日本の祝日の名前 (埋め込みの祝日データと JapaneseHolidays の名前) の英語名
+var holidayNamesEN = map[string]string{
+	"元日":      "New Year's Day",
+	"年始休み":    "New Year Holiday",
+	"成人の日":    "Coming of Age Day",
+	"建国記念の日":  "National Foundation Day",
+	"天皇誕生日":   "The Emperor's Birthday",
+	"春分の日":    "Vernal Equinox Day",
+	"昭和の日":    "Showa Day",
+	"憲法記念日":   "Constitution Memorial Day",
+	"みどりの日":   "Greenery Day",
+	"こどもの日":   "Children's Day",
+	"海の日":     "Marine Day",
+	"山の日":     "Mountain Day",
+	"敬老の日":    "Respect for the Aged Day",
+	"秋分の日":    "Autumnal Equinox Day",
+	"体育の日":    "Health and Sports Day",
+	"スポーツの日":  "Sports Day",
+	"文化の日":    "Culture Day",
+	"勤労感謝の日":  "Labor Thanksgiving Day",
+	"振替休日":    "Substitute Holiday",
+	"国民の休日":   "Citizens' Holiday",
+	"休日":      "Holiday",
+	"天皇の即位の日": "Enthronement Day",
+	"即位礼正殿の儀": "Enthronement Ceremony",
+	"大喪の礼":    "Funeral of Emperor Showa",
+	"結婚の儀":    "Imperial Wedding",
+}
+
+// catalogEN は英語の文言のカタログ (キーは日本語の文言、書式の引数の順番はそろえる)
+var catalogEN = map[string]string{
+	"エラー: %s\n": "error: %s\n",
+
+	// summary
+	"%s は祝日 (%s) です\n":                          "%s is a holiday (%s)\n",
+	"%d年%d月のサマリです (%s 時点)\n":                    "Summary for %d-%02d (as of %s)\n",
+	"今月の祝日はありません":                               "No holidays this month",
+	"今月の祝日: %s\n":                               "Holidays this month: %s\n",
+	"、":                                         ", ",
+	"今日は今月の %s 営業日目 です\n":                       "Today is the %s business day of this month\n",
+	"今月の残り営業日は %d 日 です\n":                       "%d business days left this month\n",
+	"今月の残り想定稼働時間は %s 時間 です\n":                   "%s planned work hours left this month\n",
+	"今月の経過稼働時間は %.1f 時間 です\n":                   "%.1f work hours elapsed this month\n",
+	"%.1f %% 経過しました\n":                          "%.1f %% elapsed\n",
+	"%s %.1f %% 経過しました\n":                       "%s %.1f %% elapsed\n",
+	"暦日では %d / %d 日目、残り %d 日 (%.1f %% 経過) です\n": "Calendar day %d / %d, %d days left (%.1f %% elapsed)\n",
+	"期間 %s ~ %s の営業日は %d 日 です\n":                "%[3]d business days from %[1]s to %[2]s\n",
+	"期間の想定稼働時間は %s 時間 です\n":                     "%s planned work hours in the range\n",
+	" - 休業日 %d 日":                               " - %d closure days",
+	"内訳: 暦日 %d 日 - 定休日 %d 日 - 祝日 %d 日%s + 振替出勤 %d 日 = 営業日 %d 日\n": "Breakdown: %d calendar days - %d weekend days - %d holidays%s + %d extra workdays = %d business days\n",
+	"注意: 次の営業日より前に祝日があります (%s)\n":                                 "Note: a holiday comes before the next business day (%s)\n",
+	"注意: %d営業日後は祝日です (%s)\n":                                      "Note: holiday in %d business days (%s)\n",
+	"警告: %s 年の祝日データがないため、その年の祝日は平日として数えています\n":                    "Warning: no holiday data for %s; holidays in those years are counted as weekdays\n",
+	"第%s営業日": "%s business day",
+	"会社のカレンダーでは今日は %s 営業日目、残り %d 日 (今月の営業日 %d 日のうち個人の休暇 %d 日) です\n": "On the company calendar today is the %s business day, %d left (%d business days this month, %d of them your vacation)\n",
+	"会社のカレンダーでは営業日 %d 日 (個人の休暇 %d 日を含む) です\n":                       "%d business days on the company calendar (including %d vacation days)\n",
+
+	// is-business-day
+	"%s は営業日です\n":      "%s is a business day\n",
+	"%s は休業日です (%s)\n": "%s is not a business day (%s)\n",
+	"%s は休業日です\n":      "%s is not a business day\n",
+
+	// holidays
+	"該当する祝日はありません": "No matching holidays",
+	"休日":           "Holiday",
+
+	// until
+	"%s までの残り営業日は %d 日 です\n":     "%[2]d business days left until %[1]s\n",
+	"%s までの残り想定稼働時間は %s 時間 です\n": "%[2]s planned work hours left until %[1]s\n",
+	"期限 %s は過ぎています":              "Deadline %s has passed",
+
+	// week
+	"営業日は %d 日、経過 %d 日、残り %d 日 です\n": "%d business days, %d elapsed, %d left\n",
+
+	// fiscal
+	"%d年度 (%s ~ %s)\n": "FY%d (%s ~ %s)\n",
+	"今日は今年度の %s 営業日目 です (全 %d 日、残り %d 日、%.1f %% 経過)\n":             "Today is the %s business day of the fiscal year (%d in total, %d left, %.1f %% elapsed)\n",
+	"%s 第%d四半期 (%d月~%d月): 営業日 %d 日、経過 %d 日、残り %d 日 (%.1f %% 経過)\n": "%s Q%d (months %d-%d): %d business days, %d elapsed, %d left (%.1f %% elapsed)\n",
+
+	// year
+	"%d年度 (%s ~ %s) の営業日\n": "Business days in FY%d (%s ~ %s)\n",
+	"%d年の営業日\n":             "Business days in %d\n",
+	"期間\t営業日\t祝日\t想定稼働時間\t": "Period\tBusiness days\tHolidays\tPlanned hours\t",
+
+	// close
+	"%d年%d月の決算の日程\n": "Closing schedule for %d-%02d\n",
+	"最終営業日":          "Last business day",
+	"翌月第1営業日":        "1st business day of next month",
+	"翌月第2営業日":        "2nd business day of next month",
+	"翌月第3営業日":        "3rd business day of next month",
+	"翌月第4営業日":        "4th business day of next month",
+	"翌月第5営業日":        "5th business day of next month",
+
+	// payday
+	"%d年%d月の給料日は %s です": "Payday for %d-%02d is %s",
+	" (締め日は %s)":        " (cutoff %s)",
+
+	// hours
+	"期間 %s ~ %s の想定稼働時間は %s 時間 です (営業日 %d 日)\n": "%[3]s planned work hours from %[1]s to %[2]s (%[4]d business days)\n",
+	"%d年%d月の想定稼働時間は %s 時間 です\n":                 "%[3]s planned work hours in %[1]d-%02[2]d\n",
+	"%s までに %s 時間、残り %s 時間 です\n":                "%[2]s hours by %[1]s, %[3]s hours left\n",
+
+	// offdays
+	"定休日 (%s)":           "Weekend (%s)",
+	"祝日":                 "Holiday",
+	"休業日":                "Closure",
+	"・":                  " / ",
+	"%d年%d月の休業日は%d日です\n": "%[3]d days off in %[1]d-%02[2]d\n",
+
+	// summary --visual
+	"%d年%d月\n": "%d-%02d\n",
+	"(-: 休業日、x: 経過した営業日、*: 今日)": "(-: day off, x: elapsed business day, *: today)",
+
+	// estimate
+	"期間 %s ~ %s の稼働見積もりです\n":              "Workload estimate from %s to %s\n",
+	"営業日 %d 日の想定稼働時間: %s 時間\n":            "Planned work hours for %d business days: %s\n",
+	"半日営業 %d 日: -%s 時間\n":                 "%d half days: -%s hours\n",
+	"休暇 %s 日: -%s 時間\n":                   "%s days of time off: -%s hours\n",
+	"会議 %d 件: -%s 時間\n":                   "%d meetings: -%s hours\n",
+	"残りの稼働可能時間は %s 時間 です\n":               "%s hours available\n",
+	"営業日計算中にエラー: %w":                      "error while counting business days: %w",
+	"期間 %s ~ %s の営業日 %d 日に %d 件を割り当てます\n": "Assigning %[4]d tasks to %[3]d business days from %[1]s to %[2]s\n",
+	"%s %d 件\n": "%s %d tasks\n",
+
+	// finish, sla, open, cron
+	"完了見込みは %s です\n":           "Expected to finish at %s\n",
+	"SLA の期限は %s です\n":         "SLA due at %s\n",
+	"営業時間外":                    "outside business hours",
+	"営業時間内":                    "within business hours",
+	"締め切り %s 以降":               "after the %s cutoff",
+	"受付 %s は%sのため、%s から数えます\n": "Received at %s (%s); counting from %s\n",
+	"%s は営業時間内です\n":            "%s is within business hours\n",
+	"%s は営業時間外です\n":            "%s is outside business hours\n",
+	"%s 営業日":                   "%s business day",
+	"%s 休業日 → %s に実行":          "%s day off → runs at %s",
+	"%s 休業日":                   "%s day off",
+	"次に営業日に実行されるのは %s です\n":    "Next run on a business day: %s\n",
+
+	// compare-tz, overlap
+	"%s 時点\n": "As of %s\n",
+	"営業日":     "Business day",
+	"%d 日以内に %s と %s の営業時間が重なる日はありません\n": "No day within %d days when business hours of %s and %s overlap\n",
+	"重なる時間帯: %s–%s %s (%s: %s–%s)\n":     "Overlap: %s–%s %s (%s: %s–%s)\n",
+	"次に重なる日: %s\n":                       "Next overlapping day: %s\n",
+
+	// progress, sprint
+	"期間 %s ~ %s の営業日は全 %d 日 です\n":                        "%[3]d business days in total from %[1]s to %[2]s\n",
+	"期間の経過営業日は %d 日 です\n":                                "%d business days elapsed\n",
+	"期間の残り営業日は %d 日 です\n":                                "%d business days left\n",
+	"スプリント %d (%s ~ %s)\n":                               "Sprint %d (%s ~ %s)\n",
+	"今日はスプリントの %s 営業日目 です (全 %d 日、残り %d 日、%.1f %% 経過)\n": "Today is the %s business day of the sprint (%d in total, %d left, %.1f %% elapsed)\n",
+	"次のスプリントは %s に始まります (%s は休業日)\n":                     "Next sprint starts on %s (%s is a day off)\n",
+	"次のスプリントは %s に始まります\n":                               "Next sprint starts on %s\n",
+
+	// update
+	"祝日データは最新です (%s に取得、有効期限 %s) → %s\n":    "Holiday data is up to date (fetched %s, expires %s) → %s\n",
+	"祝日データを更新しました: %d 年~%d 年 (%d 件) → %s\n": "Updated holiday data: %d-%d (%d entries) → %s\n",
+
+	// self-update-data
+	"警告: このビルドには署名の公開鍵が埋め込まれていないため、チェックサムだけを確かめました (配布元での改ざんは見抜けません)": "Warning: this build has no embedded signing key, so only the checksum was verified (tampering at the source cannot be detected)",
+}
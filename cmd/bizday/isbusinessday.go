@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// runIsBusinessDay は指定日 (省略時は今日) が営業日かを表示する (祝日ならその名前も)
+// 日付は --date のほか、bizday is 2025-11-24 のように引数でも指定できる (引数の後のフラグも有効)
+// 営業日でなければ終了コード 1 で終了するので、cron などで `bizday is --quiet && コマンド` のようにガードとして使える
+// (is は is-business-day の別名、日付や祝日データの誤りは 1 と区別できるよう 2〜4 で終了する)
+func runIsBusinessDay(fs *flag.FlagSet) func() error {
+	date := fs.String("date", "", "判定する日付 (例: 2025-05-01)、省略時は今日")
+	quiet := fs.Bool("quiet", false, "結果を表示せず終了コードだけで返す")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if fs.NArg() > 0 {
+			if *date != "" {
+				return fmt.Errorf("日付は --date か引数のどちらかで指定してください: %s", fs.Arg(0))
+			}
+			*date = fs.Arg(0)
+			fs.Parse(fs.Args()[1:])
+			if fs.NArg() > 0 {
+				return fmt.Errorf("日付の引数は 1 つだけ指定してください: %s", strings.Join(fs.Args(), " "))
+			}
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *date != "" {
+			t, err = parseDateTime(*date)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := checkCoverage(cal, t, t); err != nil {
+			return err
+		}
+		if cal.IsBusinessDay(t) {
+			if !*quiet {
+				fmt.Printf(tr("%s は営業日です\n"), formatDate(t))
+			}
+			return nil
+		}
+		if !*quiet {
+			if name, ok := cal.HolidayName(t); ok && name != "" {
+				fmt.Printf(tr("%s は休業日です (%s)\n"), formatDate(t), holidayLabel(name))
+			} else {
+				fmt.Printf(tr("%s は休業日です\n"), formatDate(t))
+			}
+		}
+		return errFalse
+	}
+}
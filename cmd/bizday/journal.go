@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// 実行中の書き換えの記録 (--overrides-journal) の操作
+const (
+	journalSet    = "set"    // override を足す (同じカレンダーと日付のものは置き換え)
+	journalDelete = "delete" // override を取り除く
+)
+
+// journalEntry は --overrides-journal のファイルの 1 行 (admin API の書き換え 1 回)
+type journalEntry struct {
+	Time     string       `json:"time"`
+	Actor    string       `json:"actor,omitempty"`
+	Op       string       `json:"op"`
+	Override overrideJSON `json:"override"`
+}
+
+// overrideJournal は admin API の書き換えを 1 行 1 件の JSON で書き足すファイル
+// 起動時に先頭から読み直して override を組み立てるので、書き換えは再起動しても残る
+type overrideJournal struct {
+	f *os.File
+}
+
+// applyJournalOp は操作 op で o を m に反映する
+func applyJournalOp(m map[overrideKey]overrideJSON, op string, o overrideJSON) {
+	switch op {
+	case journalSet:
+		m[o.key()] = o
+	case journalDelete:
+		delete(m, o.key())
+	}
+}
+
+// openOverrideJournal は path の記録を読み直して override を組み立て、ファイルを今の override だけの内容に書き直してから追記用に開く
+// 記録の override は check で確かめる (カレンダーの名前が設定から消えたときなど、読み直せなければエラー)
+func openOverrideJournal(path string, check func(*overrideJSON) error) (*overrideJournal, map[overrideKey]overrideJSON, error) {
+	overrides := map[overrideKey]overrideJSON{}
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e journalEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if e.Op != journalSet && e.Op != journalDelete {
+			return nil, nil, fmt.Errorf("%s:%d: op には %s か %s を指定してください: %s", path, line, journalSet, journalDelete, e.Op)
+		}
+		if err := check(&e.Override); err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		applyJournalOp(overrides, e.Op, e.Override)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// 取り除いたものや置き換えたものの記録は残さない (経緯は --audit-log に残る)
+	var compact bytes.Buffer
+	now := time.Now().Format(time.RFC3339)
+	for _, o := range sortedOverrides(overrides) {
+		line, err := json.Marshal(journalEntry{Time: now, Op: journalSet, Override: o})
+		if err != nil {
+			return nil, nil, err
+		}
+		compact.Write(append(line, '\n'))
+	}
+	if err := writeFileAtomic(path, compact.Bytes()); err != nil {
+		return nil, nil, fmt.Errorf("%s を書き直せません: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &overrideJournal{f: f}, overrides, nil
+}
+
+// append は e を 1 行の JSON として書き足し、ディスクに書き出すまで待つ (nil の overrideJournal では何もしない)
+func (j *overrideJournal) append(e journalEntry) error {
+	if j == nil {
+		return nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := j.f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("書き換えの記録に書けません: %w", err)
+	}
+	return j.f.Sync()
+}
+
+// Close はファイルを閉じる (nil の overrideJournal では何もしない)
+func (j *overrideJournal) Close() error {
+	if j == nil {
+		return nil
+	}
+	return j.f.Close()
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"bizday"
+)
+
+// summaryJSON は summary --json の出力
+// フィールドの意味は SchemaVersion が同じ間は変えない (追加のみ)
+type summaryJSON struct {
+	SchemaVersion          int            `json:"schema_version"`
+	Calendar               string         `json:"calendar"`
+	Date                   string         `json:"date"`
+	Month                  string         `json:"month"`
+	BusinessDayIndex       int            `json:"business_day_index"`
+	BusinessDayIndexLabel  string         `json:"business_day_index_label"`
+	BusinessDaysTotal      int            `json:"business_days_total"`
+	BusinessDaysRemaining  int            `json:"business_days_remaining"`
+	PercentElapsed         float64        `json:"percent_elapsed"`
+	RemainingHours         float64        `json:"remaining_hours"`
+	WorkedHours            float64        `json:"worked_hours"`
+	CalendarDaysTotal      int            `json:"calendar_days_total"`
+	CalendarDaysElapsed    int            `json:"calendar_days_elapsed"`
+	CalendarDaysRemaining  int            `json:"calendar_days_remaining"`
+	CalendarPercentElapsed float64        `json:"calendar_percent_elapsed"`
+	Holidays               []holidayJSON  `json:"holidays"`
+	UpcomingHolidays       []holidayJSON  `json:"upcoming_holidays,omitempty"`
+	Breakdown              *breakdownJSON `json:"breakdown,omitempty"`
+	// Company は --vacations のとき、個人の休暇を除かない会社のカレンダーでの数
+	Company *companySummaryJSON `json:"company,omitempty"`
+}
+
+// companySummaryJSON は summary --vacations --json で併記する、会社のカレンダーでの今月の数
+type companySummaryJSON struct {
+	BusinessDayIndex      int     `json:"business_day_index"`
+	BusinessDaysTotal     int     `json:"business_days_total"`
+	BusinessDaysRemaining int     `json:"business_days_remaining"`
+	PercentElapsed        float64 `json:"percent_elapsed"`
+}
+
+// rangeJSON は summary --from --to --json の出力
+type rangeJSON struct {
+	SchemaVersion int            `json:"schema_version"`
+	Calendar      string         `json:"calendar"`
+	From          string         `json:"from"`
+	To            string         `json:"to"`
+	BusinessDays  int            `json:"business_days"`
+	Hours         float64        `json:"hours"`
+	Breakdown     *breakdownJSON `json:"breakdown,omitempty"`
+	// Company は --vacations のとき、個人の休暇を除かない会社のカレンダーでの数
+	Company *companyRangeJSON `json:"company,omitempty"`
+}
+
+// companyRangeJSON は summary --from --to --vacations --json で併記する、会社のカレンダーでの期間の数
+type companyRangeJSON struct {
+	BusinessDays int     `json:"business_days"`
+	Hours        float64 `json:"hours"`
+}
+
+// holidayJSON は祝日 1 件
+type holidayJSON struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// breakdownJSON は --breakdown の内訳 (bizday.RangeBreakdown と同じ項目)
+type breakdownJSON struct {
+	TotalDays    int `json:"total_days"`
+	WeekendDays  int `json:"weekend_days"`
+	Holidays     int `json:"holidays"`
+	Closures     int `json:"closures"`
+	Workdays     int `json:"workdays"`
+	BusinessDays int `json:"business_days"`
+}
+
+// newHolidaysJSON は祝日の一覧を JSON 用に変換する (祝日がなければ空の配列)
+func newHolidaysJSON(hs []bizday.Holiday) []holidayJSON {
+	out := make([]holidayJSON, 0, len(hs))
+	for _, h := range hs {
+		out = append(out, holidayJSON{Date: dateString(h.Date), Name: h.Name})
+	}
+	return out
+}
+
+func newBreakdownJSON(b bizday.RangeBreakdown) *breakdownJSON {
+	return &breakdownJSON{
+		TotalDays:    b.TotalDays,
+		WeekendDays:  b.WeekendDays,
+		Holidays:     b.Holidays,
+		Closures:     b.Closures,
+		Workdays:     b.Workdays,
+		BusinessDays: b.BusinessDays,
+	}
+}
+
+// newSummaryJSON は today を含む月の営業日の経過状況を JSON 用にまとめる
+// 残りの想定稼働時間は h (曜日ごとの設定を含む) に稼働率 fte を掛けて数える
+func newSummaryJSON(cal *bizday.Calendar, name string, today time.Time, h bizday.DayHours, fte float64) summaryJSON {
+	sum := cal.Summary(today, bizday.MonthPeriod)
+	remaining, _ := cal.PlannedHours(h.Scale(fte), bizday.BeginningOfDay(today).AddDate(0, 0, 1), sum.End) // 月の残りなので桁あふれしない
+	return summaryJSON{
+		SchemaVersion:          bizday.SchemaVersion,
+		Calendar:               name,
+		Date:                   dateString(today),
+		Month:                  today.Format("2006-01"),
+		BusinessDayIndex:       sum.Elapsed,
+		BusinessDayIndexLabel:  indexLabel(sum.Elapsed),
+		BusinessDaysTotal:      sum.Total,
+		BusinessDaysRemaining:  sum.Remaining,
+		PercentElapsed:         sum.Percent,
+		RemainingHours:         roundHours(remaining.Hours()),
+		WorkedHours:            roundHours(cal.WorkedDuration(sum.Start, today).Hours() * fte),
+		CalendarDaysTotal:      sum.CalendarDays,
+		CalendarDaysElapsed:    sum.CalendarElapsed,
+		CalendarDaysRemaining:  sum.CalendarDays - sum.CalendarElapsed,
+		CalendarPercentElapsed: percent(sum.CalendarElapsed, sum.CalendarDays),
+		Holidays:               newHolidaysJSON(cal.HolidaysBetween(sum.Start, sum.End)),
+	}
+}
+
+// newCompanySummaryJSON は会社のカレンダー company での today を含む月の数をまとめる
+func newCompanySummaryJSON(company *bizday.Calendar, today time.Time) *companySummaryJSON {
+	sum := company.Summary(today, bizday.MonthPeriod)
+	return &companySummaryJSON{
+		BusinessDayIndex:      sum.Elapsed,
+		BusinessDaysTotal:     sum.Total,
+		BusinessDaysRemaining: sum.Remaining,
+		PercentElapsed:        sum.Percent,
+	}
+}
+
+// newRangeJSON は from~to (両端含む) の営業日数と想定稼働時間を JSON 用にまとめる
+func newRangeJSON(cal *bizday.Calendar, name string, from, to time.Time, h bizday.DayHours, breakdown bool) (rangeJSON, error) {
+	days, err := cal.CountBusinessDays(from, to)
+	if err != nil {
+		return rangeJSON{}, fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+	}
+	hours, err := cal.PlannedHours(h, from, to)
+	if err != nil {
+		return rangeJSON{}, err
+	}
+	out := rangeJSON{
+		SchemaVersion: bizday.SchemaVersion,
+		Calendar:      name,
+		From:          dateString(from),
+		To:            dateString(to),
+		BusinessDays:  days,
+		Hours:         roundHours(hours.Hours()),
+	}
+	if breakdown {
+		b, err := cal.Breakdown(from, to)
+		if err != nil {
+			return rangeJSON{}, fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+		}
+		out.Breakdown = newBreakdownJSON(b)
+	}
+	return out, nil
+}
+
+// indexLabel は営業日目の表示用の文字列 ("第5営業日"、--lang en なら "5th business day") を返す
+func indexLabel(n int) string {
+	return fmt.Sprintf(tr("第%s営業日"), ordinal(n))
+}
+
+// roundHours は時間数を小数点以下 2 桁に丸める (formatHours と同じ精度)
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+// dateString は JSON に書く日付の表記 (--date-style によらず ISO 形式)
+func dateString(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// writeJSON は v をインデント付きの JSON で標準出力に書き出す
+func writeJSON(v any) error {
+	return encodeJSON(os.Stdout, v)
+}
+
+// encodeJSON は v をインデント付きの JSON で w に書き出す
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// 1 回の計算で扱う量の上限
+// 営業日は 1 日ずつ判定するので、桁違いの値を受け付けると serve では 1 件の要求がいつまでも終わらない
+const (
+	maxAddBusinessDays = 100 * 262 // add・deadline・/v1/add で進める営業日数の上限 (およそ 100 年分)
+	maxRangeDays       = 100 * 366 // summary --from --to・diff・/v1/count で数える期間の暦日数の上限 (およそ 100 年)
+)
+
+// checkAddBusinessDays は進める営業日数 n が上限の内かを確認する
+func checkAddBusinessDays(n int) error {
+	if n > maxAddBusinessDays || n < -maxAddBusinessDays {
+		return fmt.Errorf("営業日数は ±%d 以内で指定してください: %d", maxAddBusinessDays, n)
+	}
+	return nil
+}
+
+// checkRange は a~b (どちらが先でもよい) の暦日数が上限の内かを確認する
+func checkRange(a, b time.Time) error {
+	if b.Before(a) {
+		a, b = b, a
+	}
+	if a.AddDate(0, 0, maxRangeDays).Before(b) {
+		return fmt.Errorf("期間は %d 日以内で指定してください: %s ~ %s", maxRangeDays, dateString(a), dateString(b))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runList は今後の営業日を日付と曜日付きで一覧表示する
+func runList(fs *flag.FlagSet) func() error {
+	next := newSpanFlag(10, bizday.SpanBusinessDays)
+	fs.Var(next, "next", "表示する期間 (例: 10, 10bd, 2w)、単位を省略すると営業日数")
+	from := fs.String("from", "", "この日の翌日から数える (省略時は今日)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *from != "" {
+			t, err = parseDateTime(*from)
+			if err != nil {
+				return err
+			}
+		}
+
+		n := cal.SpanBusinessDays(t, next.span)
+		if n <= 0 {
+			return fmt.Errorf("--next には 1 営業日以上の期間を指定してください")
+		}
+		days := cal.NextBusinessDays(t, n)
+		if err := checkCoverage(cal, t, days[len(days)-1]); err != nil {
+			return err
+		}
+		for _, d := range days {
+			fmt.Println(formatListDate(d))
+		}
+		return nil
+	}
+}
+
+// formatListDate は一覧用に曜日付きで日付を整形する (ja 形式はもともと曜日を含む)
+func formatListDate(d time.Time) string {
+	if dateStyle == "ja" {
+		return formatDate(d)
+	}
+	return annotate(fmt.Sprintf("%s (%s)", plainDate(d), weekdayLabel(d.Weekday())), d)
+}
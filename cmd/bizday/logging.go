@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"bizday"
+)
+
+// levelTrace は -debug で出す、日ごとの判定の理由を残すログのレベル (slog.LevelDebug より細かい)
+const levelTrace = slog.LevelDebug - 4
+
+// logLevel は標準エラー出力に書くログのレベル
+// 既定は Info (serve・watch の動作のログとエラー)、-v で Debug (読み込んだデータや選んだカレンダー)、
+// -debug で levelTrace (除外した日とその理由) まで出す
+var logLevel = new(slog.LevelVar)
+
+// setupLogging は slog の既定のロガーを標準エラー出力のテキスト形式にする
+// サブコマンドのフラグの解析より前に設定ファイルや祝日データを読み込むので、-v・-debug は args から先に拾っておく
+func setupLogging(args []string) {
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		switch a {
+		case "-v", "--v":
+			lowerLogLevel(slog.LevelDebug)
+		case "-debug", "--debug":
+			lowerLogLevel(levelTrace)
+		}
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel, ReplaceAttr: traceLevelName})))
+}
+
+// traceLevelName は levelTrace のログのレベルを DEBUG-4 ではなく TRACE と表示する
+func traceLevelName(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && a.Value.Any() == levelTrace {
+		a.Value = slog.StringValue("TRACE")
+	}
+	return a
+}
+
+// lowerLogLevel はログのレベルを l まで下げる (すでに l より細かければそのまま)
+func lowerLogLevel(l slog.Level) {
+	if l < logLevel.Level() {
+		logLevel.Set(l)
+	}
+}
+
+// logLevelFlag は -v・-debug のフラグ (真にするとログのレベルを level まで下げる)
+type logLevelFlag slog.Level
+
+func (f logLevelFlag) String() string   { return "false" }
+func (f logLevelFlag) IsBoolFlag() bool { return true }
+
+func (f logLevelFlag) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if v {
+		lowerLogLevel(slog.Level(f))
+	}
+	return nil
+}
+
+// addLogFlags は -v・-debug を fs に登録する (newFlagSet ですべてのサブコマンドに登録する)
+func addLogFlags(fs *flag.FlagSet) {
+	fs.Var(logLevelFlag(slog.LevelDebug), "v", "読み込んだ設定・祝日データや選んだカレンダーを標準エラー出力に書く")
+	fs.Var(logLevelFlag(levelTrace), "debug", "-v に加えて、営業日から除いた日とその理由 (定休日・祝日・会社の休業日) を標準エラー出力に書く")
+}
+
+// traceNonBusinessDays は -debug のとき、start~end で営業日から除いた日とその理由をログに書く
+// 理由は weekend (定休日)、holiday (祝日)、closure (設定ファイルや拠点・--vacations で重ねた会社・個人の休業日)
+func traceNonBusinessDays(cal *bizday.Calendar, start, end time.Time) {
+	if !slog.Default().Enabled(context.Background(), levelTrace) {
+		return
+	}
+	days, err := cal.NonBusinessDays(start, end)
+	if err != nil {
+		return
+	}
+	for _, d := range days {
+		reason := "weekend"
+		switch {
+		case d.Closure:
+			reason = "closure"
+		case d.Holiday && !d.Weekend:
+			reason = "holiday"
+		}
+		slog.Log(context.Background(), levelTrace, "営業日から除きました", "date", dateString(d.Date), "reason", reason, "name", d.Name)
+	}
+}
+
+// errorChain は err が包んでいるエラーの型を外側から順に返す (-v のときの失敗の原因の表示用)
+// メッセージに文脈を足すだけの fmt.Errorf のエラーは除く
+func errorChain(err error) []string {
+	var chain []string
+	for e := errors.Unwrap(err); e != nil; e = errors.Unwrap(e) {
+		if t := fmt.Sprintf("%T", e); t != "*fmt.wrapError" {
+			chain = append(chain, t)
+		}
+	}
+	return chain
+}
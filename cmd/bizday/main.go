@@ -0,0 +1,485 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // タイムゾーンのデータがないホスト (CI のコンテナなど) でも --tz を使えるように
+
+	"bizday"
+	_ "bizday/data/jp"
+)
+
+func main() {
+	setupLogging(os.Args[1:])
+	var err error
+	if conf, err = loadConfig(os.Getenv(configEnv)); err != nil {
+		exit(dataError(fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)))
+	}
+	if conf.Timezone != "" {
+		if err := setTimezone(conf.Timezone); err != nil {
+			exit(dataError(fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)))
+		}
+	}
+	if conf.DateStyle != "" {
+		dateStyle = conf.DateStyle
+	}
+	// --coverage のないサブコマンド (compare-tz など) も設定ファイルの coverage に従う
+	if conf.Coverage != "" {
+		coveragePolicy = bizday.CoveragePolicy(conf.Coverage)
+	}
+	lang = detectLang()
+
+	// サブコマンドの振り分け (指定がなければ今月のサマリを表示)
+	args := os.Args[1:]
+	cmd := "summary"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
+	}
+
+	c, ok := lookupCommand(cmd)
+	if !ok {
+		exit(fmt.Errorf("未知のサブコマンド: %s (一覧は bizday help)", cmd))
+	}
+	err = c.exec(args)
+	if err != nil {
+		exit(err)
+	}
+}
+
+// holidaysLoaded は起動時の祝日データを読み込んだかどうか
+var holidaysLoaded bool
+
+// ensureHolidays は起動時の祝日データをまだ読み込んでいなければ loadStartupHolidays で読み込む
+// カレンダーを組み立てる resolve などから呼ぶので、help・completion・update のように祝日データを使わないサブコマンドは
+// 祝日データのファイルが壊れていても動き、読み込みの手間もかからない
+func ensureHolidays() error {
+	if holidaysLoaded {
+		return nil
+	}
+	if err := loadStartupHolidays(); err != nil {
+		return dataError(err)
+	}
+	return nil
+}
+
+// loadStartupHolidays は祝日一覧を読み込み、holidaySource・holidayCalendars と登録済みの jp カレンダーを差し替える
+// 読み込む順は $BIZDAY_HOLIDAYS、設定ファイルの holidays、設定ファイルの holiday_sources、キャッシュ、埋め込み済みのデータ
+// 読み込みに失敗したときは何も差し替えない (serve の再読み込みでは以前のデータで動き続ける)
+func loadStartupHolidays() error {
+	path := os.Getenv(holidaysEnv)
+	if path == "" {
+		path = conf.Holidays
+	}
+	data, err := loadHolidays(path)
+	if err != nil {
+		return fmt.Errorf("祝日ファイルの読み込みに失敗しました: %w", err)
+	}
+	holidaySource, holidayCalendars = data.source, data.calendars
+	slog.Debug("祝日データを読み込みました", "source", data.source, "entries", len(data.entries), "calendars", len(data.calendars))
+	// データにない年の祝日は規則で算出する
+	entries, _ := data.entriesFor("jp")
+	bizday.Replace("jp", bizday.NewJapanCalendarAsOf(entries, time.Now()))
+	holidaysLoaded = true
+	return nil
+}
+
+// reloadHolidays は祝日データを読み込み直す (serve・watch で再起動せずにデータを更新するときに使う)
+// 返す restore を呼ぶと読み込み直す前のデータに戻せる。読み込みに失敗したときは何も変えない
+func reloadHolidays() (restore func(), err error) {
+	oldSource, oldCalendars := holidaySource, holidayCalendars
+	oldJP, _ := bizday.Lookup("jp")
+	restore = func() {
+		holidaySource, holidayCalendars = oldSource, oldCalendars
+		bizday.Replace("jp", oldJP)
+	}
+	if err := loadStartupHolidays(); err != nil {
+		return nil, err
+	}
+	return restore, nil
+}
+
+// exit はエラーを表示し、エラーの種類に応じた終了コードで終了する
+// 既定では「エラー: ...」の 1 行だけを標準エラー出力に書き、-v・-debug のときは slog の形式で原因のエラーの型も書く
+func exit(err error) {
+	var e *exitError
+	if !errors.As(err, &e) || e.err != nil {
+		if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+			attrs := []any{}
+			if chain := errorChain(err); len(chain) > 0 {
+				attrs = append(attrs, "causes", chain)
+			}
+			slog.Error(err.Error(), attrs...)
+		} else {
+			fmt.Fprintf(os.Stderr, tr("エラー: %s\n"), err)
+		}
+	}
+	os.Exit(exitCode(err))
+}
+
+// runSummary は今月 (--month があればその月) の営業日の経過状況を表示する
+func runSummary(fs *flag.FlagSet) func() error {
+	noHolidays := fs.Bool("no-holidays", false, "祝日データを無視して土日のみを除外して数える")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	fte := fs.Float64("fte", 1, "稼働率 (例: 週 4 日勤務なら 0.8)、時間の出力に掛け合わせる")
+	breakdown := fs.Bool("breakdown", false, "今月の営業日数の内訳 (土日・祝日) を表示する")
+	warnHolidays := newSpanFlag(0, bizday.SpanBusinessDays)
+	fs.Var(warnHolidays, "warn-holidays", "この期間 (例: 5, 5bd, 1w) 以内に祝日があれば警告する (0 なら警告しない)")
+	date := fs.String("date", "", "この日時を今日として、その月のサマリを表示する (例: 2025-04-15)")
+	fromStr := fs.String("from", "", "期間の開始日 (--to と合わせて指定すると、その期間の営業日数を表示する)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-09-30)")
+	monthStr := fs.String("month", "", "サマリを表示する月 (例: 2025-07、--year と合わせて 7 とも書ける)")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	jsonOut := fs.Bool("json", false, "結果を JSON で出力する (jq やダッシュボードでの加工向け)")
+	visual := fs.Bool("visual", false, "今月のカレンダーを表で表示し、経過率を棒グラフでも表示する (端末なら色付き)")
+	addDateStyleFlag(fs)
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		if *fte <= 0 {
+			return fmt.Errorf("--fte には正の値を指定してください")
+		}
+		if *noHolidays {
+			cal = &bizday.Calendar{Hours: cal.Hours, Weekend: cal.Weekend}
+		}
+
+		if *fromStr != "" || *toStr != "" {
+			if *fromStr == "" || *toStr == "" {
+				return fmt.Errorf("--from と --to は両方指定してください")
+			}
+			from, err := parseDateTime(*fromStr)
+			if err != nil {
+				return err
+			}
+			to, err := parseDateTime(*toStr)
+			if err != nil {
+				return err
+			}
+			from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+			if err := checkRange(from, to); err != nil {
+				return err
+			}
+			if err := checkCoverage(cal, from, to); err != nil {
+				return err
+			}
+			if *jsonOut {
+				out, err := newRangeJSON(cal, calFlags.country, from, to, dayHours(*hoursPerDay).Scale(*fte), *breakdown)
+				if err != nil {
+					return err
+				}
+				if c := calFlags.company; c != nil {
+					days, _ := c.CountBusinessDays(from, to)
+					hours, err := c.PlannedHours(dayHours(*hoursPerDay).Scale(*fte), from, to)
+					if err != nil {
+						return err
+					}
+					out.Company = &companyRangeJSON{BusinessDays: days, Hours: roundHours(hours.Hours())}
+				}
+				return writeJSON(out)
+			}
+			if err := printRangeSummary(cal, from, to, dayHours(*hoursPerDay).Scale(*fte), *breakdown); err != nil {
+				return err
+			}
+			if c := calFlags.company; c != nil {
+				days, _ := cal.CountBusinessDays(from, to)
+				company, _ := c.CountBusinessDays(from, to)
+				fmt.Printf(tr("会社のカレンダーでは営業日 %d 日 (個人の休暇 %d 日を含む) です\n"), company, company-days)
+			}
+			return nil
+		}
+
+		// 今日の日付 (--date があればその日時、--month があればその月を数える時点)
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+			if name, ok := cal.HolidayName(today); ok && name != "" && !*jsonOut {
+				fmt.Printf(tr("%s は祝日 (%s) です\n"), formatDate(today), holidayLabel(name))
+			}
+		}
+		if *monthStr != "" || *year != 0 {
+			if *date != "" {
+				return fmt.Errorf("--month と --date は同時に指定できません")
+			}
+			month, err := parseMonth(*monthStr, *year)
+			if err != nil {
+				return err
+			}
+			today = monthReference(month, today)
+			if !*jsonOut {
+				fmt.Printf(tr("%d年%d月のサマリです (%s 時点)\n"), month.Year(), month.Month(), formatDate(today))
+			}
+		}
+
+		if err := checkCoverage(cal, today, today); err != nil {
+			return err
+		}
+		traceNonBusinessDays(cal, bizday.BeginningOfMonth(today), bizday.EndOfMonth(today))
+		if *jsonOut {
+			out := newSummaryJSON(cal, calFlags.country, today, dayHours(*hoursPerDay), *fte)
+			if *breakdown {
+				b, err := cal.Breakdown(bizday.BeginningOfMonth(today), bizday.EndOfMonth(today))
+				if err != nil {
+					return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+				}
+				out.Breakdown = newBreakdownJSON(b)
+			}
+			if n := cal.SpanBusinessDays(today, warnHolidays.span); n > 0 {
+				hs, _ := cal.UpcomingHolidays(today, n)
+				out.UpcomingHolidays = newHolidaysJSON(hs)
+			}
+			if calFlags.company != nil {
+				out.Company = newCompanySummaryJSON(calFlags.company, today)
+			}
+			return writeJSON(out)
+		}
+
+		// 今月の営業日数・今日が何営業日目か・残り営業日数 (Elapsed は「月初~today(含む)」の営業日数)
+		sum := cal.Summary(today, bizday.MonthPeriod)
+		start, end := sum.Start, sum.End
+		// 残り営業日 (今日より後) の想定稼働時間 (曜日ごとの設定があればそれに従う)
+		remaining, err := cal.PlannedHours(dayHours(*hoursPerDay).Scale(*fte), bizday.BeginningOfDay(today).AddDate(0, 0, 1), end)
+		if err != nil {
+			return err
+		}
+		remainingHours := remaining.Hours()
+		// 営業時間帯に沿って、月初から現在時刻までに経過した稼働時間
+		worked := cal.WorkedDuration(start, today)
+
+		if *visual {
+			printMonthGrid(cal, today, useColor())
+		}
+		holidays := cal
+		if calFlags.company != nil {
+			holidays = calFlags.company // 個人の休暇は祝日の一覧に入れず、会社のカレンダーとの比較の行で数える
+		}
+		printMonthHolidays(holidays.HolidaysBetween(start, end))
+		fmt.Printf(tr("今日は今月の %s 営業日目 です\n"), ordinal(sum.Elapsed))
+		fmt.Printf(tr("今月の残り営業日は %d 日 です\n"), sum.Remaining)
+		fmt.Printf(tr("今月の残り想定稼働時間は %s 時間 です\n"), formatHours(remainingHours))
+		fmt.Printf(tr("今月の経過稼働時間は %.1f 時間 です\n"), worked.Hours()**fte)
+		if *visual {
+			fmt.Printf(tr("%s %.1f %% 経過しました\n"), progressBar(sum.Percent, 30), sum.Percent)
+		} else {
+			fmt.Printf(tr("%.1f %% 経過しました\n"), sum.Percent)
+		}
+
+		// --vacations のときは、個人の休暇を除かない会社のカレンダーでの数も並べて表示
+		if c := calFlags.company; c != nil {
+			csum := c.Summary(today, bizday.MonthPeriod)
+			fmt.Printf(tr("会社のカレンダーでは今日は %s 営業日目、残り %d 日 (今月の営業日 %d 日のうち個人の休暇 %d 日) です\n"),
+				ordinal(csum.Elapsed), csum.Remaining, csum.Total, csum.Total-sum.Total)
+		}
+
+		// 暦日ベースの経過状況も並べて表示
+		fmt.Printf(tr("暦日では %d / %d 日目、残り %d 日 (%.1f %% 経過) です\n"),
+			sum.CalendarElapsed, sum.CalendarDays, sum.CalendarDays-sum.CalendarElapsed,
+			percent(sum.CalendarElapsed, sum.CalendarDays))
+
+		if *breakdown {
+			b, err := cal.Breakdown(start, end)
+			if err != nil {
+				return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+			}
+			printBreakdown(b)
+		}
+		if n := cal.SpanBusinessDays(today, warnHolidays.span); n > 0 {
+			printUpcomingHolidays(cal, today, n)
+		}
+		return nil
+	}
+}
+
+// parseMonth は --month の値 ("2025-07"、または year と合わせた "7") をその月の 1 日にする
+// year が 0 なら今年とする
+func parseMonth(s string, year int) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("--year は --month と合わせて指定してください")
+	}
+	if strings.Contains(s, "-") {
+		if year != 0 {
+			return time.Time{}, fmt.Errorf("--month を年付きで指定したときは --year を指定しないでください")
+		}
+		t, err := time.ParseInLocation("2006-01", s, time.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--month は 2025-07 の形式で指定してください: %s", s)
+		}
+		return t, nil
+	}
+	m, err := strconv.Atoi(s)
+	if err != nil || m < 1 || m > 12 {
+		return time.Time{}, fmt.Errorf("--month には 1~12 か 2025-07 の形式を指定してください: %s", s)
+	}
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	return time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.Local), nil
+}
+
+// monthReference は month の月のサマリをどの時点で数えるかを返す
+// 今月なら now、過ぎた月なら月末 (すべて経過)、先の月なら月初
+func monthReference(month, now time.Time) time.Time {
+	switch start := bizday.BeginningOfMonth(month); {
+	case start.Equal(bizday.BeginningOfMonth(now)):
+		return now
+	case start.Before(now):
+		return bizday.EndOfMonth(month)
+	default:
+		return start
+	}
+}
+
+// printRangeSummary は from~to (両端含む) の営業日数と想定稼働時間を表示する
+func printRangeSummary(cal *bizday.Calendar, from, to time.Time, h bizday.DayHours, breakdown bool) error {
+	days, err := cal.CountBusinessDays(from, to)
+	if err != nil {
+		return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+	}
+	fmt.Printf(tr("期間 %s ~ %s の営業日は %d 日 です\n"), formatDate(from), formatDate(to), days)
+	hours, err := cal.PlannedHours(h, from, to)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(tr("期間の想定稼働時間は %s 時間 です\n"), formatHours(hours.Hours()))
+	if breakdown {
+		b, err := cal.Breakdown(from, to)
+		if err != nil {
+			return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+		}
+		printBreakdown(b)
+	}
+	return nil
+}
+
+// printMonthHolidays は月内の祝日を "今月の祝日: 5/3 憲法記念日 5/4 みどりの日 …" の形で表示する
+func printMonthHolidays(hs []bizday.Holiday) {
+	if len(hs) == 0 {
+		fmt.Println(tr("今月の祝日はありません"))
+		return
+	}
+	parts := make([]string, 0, len(hs))
+	for _, h := range hs {
+		s := fmt.Sprintf("%d/%d", h.Date.Month(), h.Date.Day())
+		if h.Name != "" {
+			s += " " + holidayLabel(h.Name)
+		}
+		parts = append(parts, s)
+	}
+	fmt.Printf(tr("今月の祝日: %s\n"), strings.Join(parts, tr("、")))
+}
+
+// formatHours は時間数を小数点以下 2 桁までに丸め、余分な 0 を付けずに整形する
+func formatHours(h float64) string {
+	return strconv.FormatFloat(math.Round(h*100)/100, 'f', -1, 64)
+}
+
+// holidaysEnv は祝日データのファイルを指定する環境変数
+const holidaysEnv = "BIZDAY_HOLIDAYS"
+
+// holidaySource は起動時に読み込んだ祝日データの出どころ (ファイルのパスか「埋め込みデータ」)
+var holidaySource string
+
+// holidayCalendars は起動時に読み込んだ祝日データの calendars (国・地域ごとの祝日)
+var holidayCalendars map[string][]bizday.HolidayEntry
+
+// holidayData は読み込んだ祝日データ
+type holidayData struct {
+	entries   []bizday.HolidayEntry            // 先頭の holidays (日本の祝日)
+	calendars map[string][]bizday.HolidayEntry // calendars に書かれた国・地域ごとの祝日
+	source    string                           // ファイルのパスか「埋め込みデータ」
+}
+
+// entriesFor は name のカレンダーに使う祝日を返す
+// calendars に name があればその内容を、なければ先頭の holidays を使う (calendars を持つデータでは jp だけ)
+func (d holidayData) entriesFor(name string) ([]bizday.HolidayEntry, bool) {
+	if entries, ok := d.calendars[name]; ok {
+		return entries, true
+	}
+	if len(d.calendars) == 0 || name == "jp" {
+		return d.entries, true
+	}
+	return nil, false
+}
+
+// loadHolidays は祝日を読み込み、有効期間付きの HolidayEntry のスライスとデータの出どころを返す
+// path が指定されていればそのファイルを読む (読めなければエラー)
+// 設定ファイルに holiday_sources があれば、その取得元を優先する順に合わせる (mergeHolidaySources)
+// どちらもなければ、キャッシュにある update で取得した内閣府の CSV か Google カレンダーの .ics、self-update-data で取得した YAML、
+// 埋め込み済みの YAML の順に、最初に見つかったものを使う
+func loadHolidays(path string) (holidayData, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return holidayData{}, err
+		}
+		return parseHolidayData(b, path)
+	}
+	if len(conf.HolidaySources) > 0 {
+		m, err := mergeHolidaySources(conf.HolidaySources)
+		if err != nil {
+			return holidayData{}, err
+		}
+		warnSourceConflicts(m.conflicts)
+		return m.data, nil
+	}
+	// update で取得したデータが複数あれば、最後に取得したものを使う
+	if src, path := newestUpdateCache(); path != "" {
+		b, err := os.ReadFile(path)
+		if err == nil {
+			entries, err := src.parse(b)
+			if err != nil {
+				return holidayData{}, fmt.Errorf("%s: %w", path, err)
+			}
+			return holidayData{entries: entries, source: path}, nil
+		}
+		slog.Debug("キャッシュの祝日データを読めないので使いません", "path", path, "err", err)
+	}
+	if path, err := cachePath("holidays.yaml"); err == nil {
+		b, err := os.ReadFile(path)
+		if err == nil {
+			return parseHolidayData(b, path)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Debug("キャッシュの祝日データを読めないので使いません", "path", path, "err", err)
+		}
+	}
+	entries, err := bizday.DefaultHolidays()
+	if err != nil {
+		return holidayData{}, fmt.Errorf("埋め込みデータ: %w", err)
+	}
+	return holidayData{entries: entries, source: "埋め込みデータ"}, nil
+}
+
+// parseHolidayData は holidays.yaml の形式のデータを先頭の holidays と calendars に分けて読む
+func parseHolidayData(b []byte, source string) (holidayData, error) {
+	d := holidayData{source: source}
+	var err error
+	if d.entries, err = bizday.ParseHolidays(b); err != nil {
+		return d, fmt.Errorf("%s: %w", source, err)
+	}
+	if d.calendars, err = bizday.ParseHolidayCalendars(b); err != nil {
+		return d, fmt.Errorf("%s: calendars: %w", source, err)
+	}
+	return d, nil
+}
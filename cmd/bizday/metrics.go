@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// metricGauge は /metrics で公開するゲージ 1 種類
+type metricGauge struct {
+	name, help string
+	value      func(cal *bizday.Calendar, now time.Time, st bizday.MonthStats) float64
+}
+
+// metricGauges は /metrics で公開するゲージ (ラベル calendar ごとに値を出す)
+var metricGauges = []metricGauge{
+	{"bizday_is_business_day", "今日が営業日なら 1、休業日なら 0", func(cal *bizday.Calendar, now time.Time, _ bizday.MonthStats) float64 {
+		if cal.IsBusinessDay(now) {
+			return 1
+		}
+		return 0
+	}},
+	{"bizday_month_business_days_total", "今月の営業日数", func(_ *bizday.Calendar, _ time.Time, st bizday.MonthStats) float64 {
+		return float64(st.BusinessDays)
+	}},
+	{"bizday_month_business_days_elapsed", "今月の月初から今日まで (今日を含む) の営業日数", func(_ *bizday.Calendar, _ time.Time, st bizday.MonthStats) float64 {
+		return float64(st.Index)
+	}},
+	{"bizday_month_business_days_remaining", "今月の今日より後の営業日数", func(_ *bizday.Calendar, _ time.Time, st bizday.MonthStats) float64 {
+		return float64(st.Remaining)
+	}},
+	{"bizday_month_progress_ratio", "今月の営業日の経過率 (0~1)", func(_ *bizday.Calendar, _ time.Time, st bizday.MonthStats) float64 {
+		if st.BusinessDays == 0 {
+			return 0
+		}
+		return float64(st.Index) / float64(st.BusinessDays)
+	}},
+}
+
+// handleMetrics は Prometheus のテキスト形式で営業日のゲージを返す
+// 値は serve の既定のカレンダーと --metrics-calendars、それまでに API で使われたカレンダーごとに出す
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.cals))
+	cals := make(map[string]*bizday.Calendar, len(s.cals))
+	for name, cal := range s.cals {
+		names = append(names, name)
+		cals[name] = cal
+	}
+	s.mu.Unlock()
+	sort.Strings(names)
+
+	now := time.Now()
+	stats := make(map[string]bizday.MonthStats, len(names))
+	for _, name := range names {
+		stats[name] = cals[name].MonthStats(now)
+	}
+
+	var b strings.Builder
+	for _, g := range metricGauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s{calendar=%q} %g\n", g.name, name, g.value(cals[name], now, stats[name]))
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, b.String())
+}
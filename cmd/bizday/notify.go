@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// webhookEnv は通知先の Webhook URL を指定する環境変数 (URL を crontab やプロセス一覧に出さずに済む)
+const webhookEnv = "BIZDAY_WEBHOOK"
+
+// webhookMessage は Slack・Teams の Incoming Webhook に送る本文 (どちらも text を受け付ける)
+type webhookMessage struct {
+	Text string `json:"text"`
+}
+
+// runNotify は今月のサマリ (何営業日目か・残り営業日数・経過率) を Slack や Teams の Incoming Webhook に投稿する
+// cron で毎朝実行する想定で、--business-days-only なら休業日には投稿しない
+func runNotify(fs *flag.FlagSet) func() error {
+	webhook := fs.String("webhook", "", "投稿先の Incoming Webhook の URL ($"+webhookEnv+" でも指定可)")
+	businessOnly := fs.Bool("business-days-only", false, "今日が休業日なら投稿しない")
+	dryRun := fs.Bool("dry-run", false, "投稿せずに送る内容を標準出力に表示する")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-05-14)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *webhook == "" {
+			*webhook = os.Getenv(webhookEnv)
+		}
+		if *webhook == "" && !*dryRun {
+			return fmt.Errorf("--webhook か $%s で投稿先の URL を指定してください", webhookEnv)
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		if err := checkCoverage(cal, bizday.BeginningOfMonth(today), bizday.EndOfMonth(today)); err != nil {
+			return err
+		}
+		if *businessOnly && !cal.IsBusinessDay(today) {
+			return nil
+		}
+
+		body, err := json.Marshal(webhookMessage{Text: notifyText(cal, calFlags.country, today)})
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			fmt.Println(string(body))
+			return nil
+		}
+		return postWebhook(&http.Client{Timeout: 30 * time.Second}, *webhook, body)
+	}
+}
+
+// notifyText は通知の本文を組み立てる
+func notifyText(cal *bizday.Calendar, name string, today time.Time) string {
+	st := cal.MonthStats(today)
+	percent := 0.0
+	if st.BusinessDays > 0 {
+		percent = float64(st.Index) / float64(st.BusinessDays) * 100
+	}
+	lines := []string{fmt.Sprintf("*%s* (%s カレンダー)", formatDate(today), name)}
+	if cal.IsBusinessDay(today) {
+		lines = append(lines, fmt.Sprintf("今月の営業日 %d 日のうち、今日は %s (残り %d 日)", st.BusinessDays, indexLabel(st.Index), st.Remaining))
+	} else {
+		off := "今日は休業日です"
+		if h, ok := cal.HolidayName(today); ok && h != "" {
+			off += " (" + h + ")"
+		}
+		lines = append(lines, off, fmt.Sprintf("今月の営業日 %d 日のうち、経過 %d 日 (残り %d 日)", st.BusinessDays, st.Index, st.Remaining))
+	}
+	lines = append(lines, fmt.Sprintf("`%s` %.1f %% 経過", progressBar(percent, 20), percent))
+	return strings.Join(lines, "\n")
+}
+
+// postWebhook は body を JSON として url に POST する
+func postWebhook(client *http.Client, url string, body []byte) error {
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return networkError(fmt.Errorf("通知の送信に失敗: %w", err))
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return networkError(fmt.Errorf("通知の送信に失敗: %s", resp.Status))
+	}
+	return nil
+}
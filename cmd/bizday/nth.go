@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+// runNth は指定月 (省略時は今月) の第 n 営業日を表示する (-n -1 なら最終営業日)
+// 「第 5 営業日に請求書を支払う」「最終営業日に月次締め」のような日付を求めるのに使う
+func runNth(fs *flag.FlagSet) func() error {
+	month := fs.String("month", "", "対象の月 (例: 2025-06、--year と合わせて 6 とも書ける)、省略時は今月")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	n := fs.Int("n", 1, "何営業日目か (負の値なら月末から数え、-1 が最終営業日)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		m := time.Now()
+		if *month != "" || *year != 0 {
+			if m, err = parseMonth(*month, *year); err != nil {
+				return err
+			}
+		}
+		first := time.Date(m.Year(), m.Month(), 1, 0, 0, 0, 0, time.Local)
+		if err := checkCoverage(cal, first, first.AddDate(0, 1, -1)); err != nil {
+			return err
+		}
+		d, err := cal.NthBusinessDay(m.Year(), m.Month(), *n)
+		if err != nil {
+			return err
+		}
+		fmt.Println(formatDate(d))
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// offdayReason は休業の理由を "定休日 (土)"・"祝日 (憲法記念日)" のような表示用の文字列で返す
+func offdayReason(n bizday.NonBusinessDay) string {
+	weekend := fmt.Sprintf(tr("定休日 (%s)"), weekdayLabel(n.Date.Weekday()))
+	if !n.Holiday {
+		return weekend
+	}
+	reason := tr("祝日")
+	if n.Closure {
+		reason = tr("休業日")
+	}
+	if n.Name != "" {
+		reason += " (" + holidayLabel(n.Name) + ")"
+	}
+	if n.Weekend {
+		reason = weekend + tr("・") + reason
+	}
+	return reason
+}
+
+// runOffdays は指定月の休業日 (定休日・祝日) を理由付きで一覧表示する
+func runOffdays(fs *flag.FlagSet) func() error {
+	month := fs.String("month", "", "対象の月 (例: 2025-05)、省略時は今月")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *month != "" {
+			t, err = time.ParseInLocation("2006-01", *month, time.Local)
+			if err != nil {
+				return fmt.Errorf("--month は YYYY-MM の形式で指定してください: %s", *month)
+			}
+		}
+
+		if err := checkCoverage(cal, bizday.BeginningOfMonth(t), bizday.EndOfMonth(t)); err != nil {
+			return err
+		}
+		days, err := cal.NonBusinessDays(bizday.BeginningOfMonth(t), bizday.EndOfMonth(t))
+		if err != nil {
+			return err
+		}
+		fmt.Printf(tr("%d年%d月の休業日は%d日です\n"), t.Year(), t.Month(), len(days))
+		for _, d := range days {
+			fmt.Printf("%s %s\n", formatListDate(d.Date), offdayReason(d))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// dateTimeLayouts は --at などで受け付ける日時の書式
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+// runOpen は指定日時 (省略時は現在) が営業時間内かを表示する
+// 営業時間外なら終了コード 1 で終了するので、シェルでの実行可否判定に使える
+func runOpen(fs *flag.FlagSet) func() error {
+	at := fs.String("at", "", "判定する日時 (例: 2025-04-01T19:00)、省略時は現在時刻")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *at != "" {
+			t, err = parseDateTime(*at)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := checkCoverage(cal, t, t); err != nil {
+			return err
+		}
+		if cal.IsOpen(t) {
+			fmt.Printf(tr("%s は営業時間内です\n"), formatDateTime(t))
+			return nil
+		}
+		fmt.Printf(tr("%s は営業時間外です\n"), formatDateTime(t))
+		return errFalse
+	}
+}
+
+// parseDateTime は dateTimeLayouts のいずれかの書式の日時、bizday.ParseDate の書式の日付
+// (2006-01-02, 2006/01/02, 20060102)、または和暦 (令和7年4月1日, R7.4.1) をパースする
+// タイムゾーンの指定がない場合はローカルタイムとして扱う
+func parseDateTime(s string) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := bizday.ParseDate(s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, ok, err := bizday.ParseWareki(s, time.Local); ok {
+		return t, err
+	}
+	return time.Time{}, fmt.Errorf("日時の形式が不正です: %q (%s、2006-01-02 15:04、和暦 (令和7年4月1日) のいずれかで指定してください)",
+		s, strings.Join(bizday.DateFormats, ", "))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runOverlap は 2 地域の営業時間が重なる時間帯と、次にそれが発生する日を表示する
+func runOverlap(fs *flag.FlagSet) func() error {
+	specs := fs.String("calendars", "", "カレンダー名:タイムゾーン を 2 つカンマ区切りで指定 (例: jp:Asia/Tokyo,us:America/New_York)")
+	from := fs.String("from", "", "探索を始める日時 (省略時は現在時刻)")
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+
+		zones, err := parseZonedCalendars(*specs)
+		if err != nil {
+			return err
+		}
+		if len(zones) != 2 {
+			return fmt.Errorf("--calendars にはカレンダーを 2 つ指定してください")
+		}
+
+		t := time.Now()
+		if *from != "" {
+			t, err = parseDateTime(*from)
+			if err != nil {
+				return err
+			}
+		}
+
+		a, b := zones[0], zones[1]
+		day, start, end, ok := bizday.NextOverlap(a, b, t.In(a.Location))
+		last := day
+		if !ok {
+			last = t.AddDate(0, 0, bizday.OverlapSearchDays)
+		}
+		for _, z := range zones {
+			if err := checkCoverage(z.Calendar, t.In(z.Location), last.In(z.Location)); err != nil {
+				return err
+			}
+		}
+		if !ok {
+			fmt.Printf(tr("%d 日以内に %s と %s の営業時間が重なる日はありません\n"), bizday.OverlapSearchDays, a.Name, b.Name)
+			return nil
+		}
+
+		fmt.Printf(tr("重なる時間帯: %s–%s %s (%s: %s–%s)\n"),
+			formatClock(start.Sub(day)), formatClock(end.Sub(day)), start.Format("MST"),
+			b.Name, start.In(b.Location).Format("15:04"), end.In(b.Location).Format("15:04 MST"))
+		fmt.Printf(tr("次に重なる日: %s\n"), formatDate(day))
+		return nil
+	}
+}
+
+// formatClock は 0:00 からの経過時間を "26:00" のような 24 時超えも許す表記にする
+func formatClock(d time.Duration) string {
+	m := int(d.Minutes())
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
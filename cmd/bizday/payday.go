@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runPayday は指定月 (省略時は今月) から --next か月分の給料日を、土日祝日なら前の営業日にずらして表示する
+// --cutoff-days を指定すると、給料日の何営業日前が締め日 (勤怠・経費の提出期限など) かも表示する
+func runPayday(fs *flag.FlagSet) func() error {
+	def := 25
+	if conf.Payday != 0 {
+		def = conf.Payday
+	}
+	day := fs.Int("day", def, "給料日 (1~31、月の日数を超えるなら月末)、省略時は設定ファイルの payday か 25 日")
+	month := fs.String("month", "", "最初の月 (例: 2025-06、--year と合わせて 6 とも書ける)、省略時は今月")
+	year := fs.Int("year", 0, "--month を月の数字だけで指定したときの年 (省略時は今年)")
+	next := fs.Int("next", 1, "表示する月数")
+	convStr := fs.String("convention", string(bizday.RollPreceding), "休業日に当たったときのずらし方 (following, preceding, modified-following)")
+	cutoff := fs.Int("cutoff-days", 0, "給料日の何営業日前を締め日として表示するか (0 なら表示しない)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		conv, err := bizday.ParseRollConvention(*convStr)
+		if err != nil {
+			return err
+		}
+		if *next < 1 {
+			return fmt.Errorf("--next には 1 以上を指定してください")
+		}
+		if *cutoff < 0 {
+			return fmt.Errorf("--cutoff-days には 0 以上を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		m := time.Now()
+		if *month != "" || *year != 0 {
+			if m, err = parseMonth(*month, *year); err != nil {
+				return err
+			}
+		}
+		m = bizday.BeginningOfMonth(m)
+		if err := checkCoverage(cal, m, m.AddDate(0, *next, -1)); err != nil {
+			return err
+		}
+		for i := 0; i < *next; i++ {
+			mm := m.AddDate(0, i, 0)
+			d, err := cal.PaydayAdjusted(mm.Year(), mm.Month(), *day, conv)
+			if err != nil {
+				return err
+			}
+			line := fmt.Sprintf(tr("%d年%d月の給料日は %s です"), mm.Year(), mm.Month(), formatDate(d))
+			if *cutoff > 0 {
+				c, err := cal.AddBusinessDays(d, -*cutoff)
+				if err != nil {
+					return err
+				}
+				line += fmt.Sprintf(tr(" (締め日は %s)"), formatDate(c))
+			}
+			fmt.Println(line)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runProgress は任意の期間 (プロジェクトのフェーズや契約期間など) に対する今日時点の進捗を表示する
+func runProgress(fs *flag.FlagSet) func() error {
+	fromStr := fs.String("from", "", "期間の開始日 (例: 2025-04-01)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-06-30)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		if *fromStr == "" || *toStr == "" {
+			return fmt.Errorf("--from と --to を指定してください")
+		}
+		from, err := parseDateTime(*fromStr)
+		if err != nil {
+			return err
+		}
+		to, err := parseDateTime(*toStr)
+		if err != nil {
+			return err
+		}
+		from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+
+		total, err := cal.CountBusinessDays(from, to)
+		if err != nil {
+			return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+		}
+
+		// 期間開始前なら経過 0、終了後なら全営業日が経過済み
+		elapsed := 0
+		today := time.Now()
+		if !today.Before(from) {
+			end := to
+			if today.Before(to) {
+				end = today
+			}
+			elapsed, err = cal.CountBusinessDays(from, end)
+			if err != nil {
+				return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+			}
+		}
+
+		fmt.Printf(tr("期間 %s ~ %s の営業日は全 %d 日 です\n"), formatDate(from), formatDate(to), total)
+		fmt.Printf(tr("期間の経過営業日は %d 日 です\n"), elapsed)
+		fmt.Printf(tr("期間の残り営業日は %d 日 です\n"), total-elapsed)
+		if total > 0 {
+			fmt.Printf(tr("%.1f %% 経過しました\n"), float64(elapsed)/float64(total)*100)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// refreshJitter は serve --refresh の間隔に足すゆらぎの上限 (間隔に対する割合)
+// 同じ間隔で動かした複数のサーバが同時に取得元へ問い合わせないようにする
+const refreshJitter = 0.1
+
+// parseRefreshSources は serve --refresh-source の値 (cao,google など) を update の取得元の名前の一覧にする
+func parseRefreshSources(v string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := updateSources[name]; !ok {
+			return nil, fmt.Errorf("--refresh-source には cao か google を指定してください: %s", name)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--refresh-source に取得元を指定してください")
+	}
+	return names, nil
+}
+
+// refreshInterval は interval に 0~refreshJitter の割合のゆらぎを足した待ち時間
+func refreshInterval(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Float64()*refreshJitter*float64(interval))
+}
+
+// refreshPeriodically は interval (とゆらぎ) ごとに sources の祝日データを取得し直してキャッシュを更新し、
+// 1 つでも更新できたら reload でカレンダーを差し替える (serve --refresh)
+// 取得・読み込みに失敗したときはキャッシュもカレンダーも変えず、次の回にまた取得する
+func (s *server) refreshPeriodically(ctx context.Context, interval time.Duration, sources []string) {
+	t := time.NewTimer(refreshInterval(interval))
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if refreshSources(sources) {
+			if out, err := s.reload(auditRefresh, ""); err != nil {
+				slog.Error("再読み込みに失敗しました", "err", err)
+			} else {
+				slog.Info("祝日データを読み込み直しました", "source", out.Source)
+			}
+		}
+		t.Reset(refreshInterval(interval))
+	}
+}
+
+// refreshSources は sources の祝日データを既定の URL から取得してキャッシュを更新し、1 つでも更新できたかを返す
+func refreshSources(sources []string) bool {
+	updated := false
+	for _, name := range sources {
+		src := updateSources[name]
+		path, err := cachePath(src.file)
+		if err != nil {
+			slog.Error("祝日データを取得し直せません", "source", name, "err", fmt.Errorf("キャッシュの場所を決められません: %w", err))
+			continue
+		}
+		first, last, n, err := updateCache(src, src.url, path)
+		if err != nil {
+			slog.Error("祝日データを取得し直せません", "source", name, "err", err)
+			continue
+		}
+		slog.Info("祝日データを取得し直しました", "source", name, "first", first, "last", last, "holidays", n, "path", path)
+		updated = true
+	}
+	return updated
+}
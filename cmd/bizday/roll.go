@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runRoll は指定日 (省略時は今日) が休業日なら、規約に従って営業日にずらした日付を表示する
+// 金融の受渡日・支払日の調整 (following / preceding / modified-following) に使う
+func runRoll(fs *flag.FlagSet) func() error {
+	date := fs.String("date", "", "ずらす日付 (例: 2025-05-31)、省略時は今日")
+	conv := fs.String("convention", string(bizday.RollFollowing), "ずらし方 (following, preceding, modified-following)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		c, err := bizday.ParseRollConvention(*conv)
+		if err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *date != "" {
+			if t, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		d, err := cal.Roll(t, c)
+		if err != nil {
+			return err
+		}
+		if err := checkCoverage(cal, t, d); err != nil {
+			return err
+		}
+		fmt.Println(formatDate(d))
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"bizday"
+)
+
+// scheduleJSON は schedule --format json の出力
+type scheduleJSON struct {
+	SchemaVersion int               `json:"schema_version"`
+	Calendar      string            `json:"calendar"`
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Tasks         int               `json:"tasks"`
+	Days          []scheduleDayJSON `json:"days"`
+}
+
+// scheduleDayJSON は 1 営業日分の割り当て
+type scheduleDayJSON struct {
+	Date  string `json:"date"`
+	Tasks int    `json:"tasks"`
+}
+
+// runSchedule は -tasks 件の作業を期間の営業日に均等に割り当てた計画を表示する
+// 割り切れない分は期間全体に散らして、1 日あたりの件数の差が 1 件以内になるようにする
+func runSchedule(fs *flag.FlagSet) func() error {
+	tasks := fs.Int("tasks", 0, "割り当てる作業の件数")
+	fromStr := fs.String("from", "", "期間の開始日 (例: 2025-05-01)")
+	toStr := fs.String("to", "", "期間の終了日 (例: 2025-05-31)")
+	format := fs.String("format", "text", "出力形式 (text, json, csv)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		switch *format {
+		case "text", "json", "csv":
+		default:
+			return fmt.Errorf("--format には text, json, csv のいずれかを指定してください: %s", *format)
+		}
+		if *tasks <= 0 {
+			return fmt.Errorf("--tasks には 1 以上の件数を指定してください")
+		}
+		if *fromStr == "" || *toStr == "" {
+			return fmt.Errorf("--from と --to を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+		from, err := parseDateTime(*fromStr)
+		if err != nil {
+			return err
+		}
+		to, err := parseDateTime(*toStr)
+		if err != nil {
+			return err
+		}
+		from, to = bizday.BeginningOfDay(from), bizday.BeginningOfDay(to)
+		if to.Before(from) {
+			return fmt.Errorf("--to には --from 以降の日付を指定してください")
+		}
+		if err := checkCoverage(cal, from, to); err != nil {
+			return err
+		}
+
+		out := scheduleJSON{SchemaVersion: bizday.SchemaVersion, Calendar: calFlags.country,
+			From: dateString(from), To: dateString(to), Tasks: *tasks}
+		var days []scheduleDayJSON
+		var labels []string
+		for d := range cal.BusinessDays(from, to) {
+			days = append(days, scheduleDayJSON{Date: dateString(d)})
+			labels = append(labels, formatListDate(d))
+		}
+		if len(days) == 0 {
+			return fmt.Errorf("期間 %s ~ %s に営業日がありません", formatDate(from), formatDate(to))
+		}
+		for i, n := range distribute(*tasks, len(days)) {
+			days[i].Tasks = n
+		}
+		out.Days = days
+
+		switch *format {
+		case "json":
+			return encodeJSON(os.Stdout, out)
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"date", "tasks"})
+			for _, d := range days {
+				w.Write([]string{d.Date, strconv.Itoa(d.Tasks)})
+			}
+			w.Flush()
+			return w.Error()
+		}
+		fmt.Printf(tr("期間 %s ~ %s の営業日 %d 日に %d 件を割り当てます\n"), formatDate(from), formatDate(to), len(days), *tasks)
+		for i, d := range days {
+			fmt.Printf(tr("%s %d 件\n"), labels[i], d.Tasks)
+		}
+		return nil
+	}
+}
+
+// distribute は n 件を days 日に割り当てた日ごとの件数を返す
+// 端数は i 日目までの累計が n*i/days に近くなるように散らす
+func distribute(n, days int) []int {
+	counts := make([]int, days)
+	for i := range counts {
+		counts[i] = n*(i+1)/days - n*i/days
+	}
+	return counts
+}
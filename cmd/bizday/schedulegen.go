@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// scheduleGuards は --on に指定できる実行条件と、それを判定するガードのサブコマンド
+var scheduleGuards = map[string]string{
+	"business-days":  "is-business-day",
+	"business-hours": "open",
+}
+
+// runScheduleGen は営業日だけコマンドを実行する systemd timer / launchd plist を生成する
+func runScheduleGen(fs *flag.FlagSet) func() error {
+	cmd := fs.String("cmd", "", "実行するコマンド (sh -c で実行される)")
+	on := fs.String("on", "business-days", "実行条件 (business-days, business-hours)")
+	at := fs.String("at", "09:00", "実行時刻 (HH:MM)")
+	format := fs.String("format", "systemd", "出力形式 (systemd, launchd)")
+	name := fs.String("name", "bizday-job", "ユニット名 / launchd のラベル")
+	bin := fs.String("bizday", "", "ガードに使う bizday のパス (省略時は実行中のバイナリ)")
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+
+		if *cmd == "" {
+			return fmt.Errorf("--cmd を指定してください")
+		}
+		guard, ok := scheduleGuards[*on]
+		if !ok {
+			return fmt.Errorf("--on には business-days か business-hours を指定してください: %s", *on)
+		}
+		clock, err := time.Parse("15:04", *at)
+		if err != nil {
+			return fmt.Errorf("--at は HH:MM の形式で指定してください: %s", *at)
+		}
+		if _, err := calFlags.resolve(); err != nil {
+			return err
+		}
+		if *bin == "" {
+			if *bin, err = os.Executable(); err != nil {
+				return fmt.Errorf("bizday のパスを取得できません (--bizday で指定してください): %w", err)
+			}
+		}
+
+		// ガードが偽なら正常終了して、休業日の実行がジョブの失敗として扱われないようにする
+		guardArgs := []string{shellQuote(*bin), guard}
+		if guard == "is-business-day" {
+			guardArgs = append(guardArgs, "--quiet")
+		}
+		guardArgs = append(guardArgs, "--calendar", shellQuote(calFlags.country))
+		if calFlags.weekend != "" {
+			guardArgs = append(guardArgs, "--weekend", shellQuote(calFlags.weekend))
+		}
+		// 祝日データのない年の扱いもジョブの実行時の判定に引き継ぐ
+		guardArgs = append(guardArgs, "--coverage", shellQuote(calFlags.coverage))
+		script := strings.Join(guardArgs, " ") + " >/dev/null || exit 0; " + *cmd
+
+		switch *format {
+		case "systemd":
+			fmt.Print(systemdUnits(*name, script, clock))
+		case "launchd":
+			fmt.Print(launchdPlist(*name, script, clock))
+		default:
+			return fmt.Errorf("--format には systemd か launchd を指定してください: %s", *format)
+		}
+		return nil
+	}
+}
+
+// systemdUnits は .service と .timer の 2 つのユニットを続けて返す
+// タイマーは毎日起動し、営業日かどうかはガードで判定する (定休日がカレンダーごとに異なるため)
+func systemdUnits(name, script string, clock time.Time) string {
+	exec := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$").Replace(script)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s.service\n", name)
+	fmt.Fprintf(&b, "[Unit]\nDescription=%s (bizday: 営業日のみ実行)\n\n", name)
+	fmt.Fprintf(&b, "[Service]\nType=oneshot\nExecStart=/bin/sh -c \"%s\"\n\n", exec)
+	fmt.Fprintf(&b, "# %s.timer\n", name)
+	fmt.Fprintf(&b, "[Unit]\nDescription=%s の実行タイマー\n\n", name)
+	fmt.Fprintf(&b, "[Timer]\nOnCalendar=*-*-* %s:00\nPersistent=false\n\n", clock.Format("15:04"))
+	fmt.Fprintf(&b, "[Install]\nWantedBy=timers.target\n")
+	return b.String()
+}
+
+// launchdPlist は毎日 clock に起動する launchd のジョブ定義を返す
+func launchdPlist(name, script string, clock time.Time) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", xmlEscape(name))
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range []string{"/bin/sh", "-c", script} {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>StartCalendarInterval</key>\n\t<dict>\n")
+	fmt.Fprintf(&b, "\t\t<key>Hour</key>\n\t\t<integer>%d</integer>\n", clock.Hour())
+	fmt.Fprintf(&b, "\t\t<key>Minute</key>\n\t\t<integer>%d</integer>\n", clock.Minute())
+	b.WriteString("\t</dict>\n</dict>\n</plist>\n")
+	return b.String()
+}
+
+// shellQuote は s を sh の単一引用符で囲む
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
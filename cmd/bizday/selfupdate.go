@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// defaultDataURL は公開されている最新の祝日データ (チェックサムは末尾に .sha256、署名は .sig を付けた URL)
+const defaultDataURL = "https://github.com/sho130/bizday/releases/latest/download/holidays.yaml"
+
+// dataPublicKey は祝日データの署名を確かめる ed25519 の公開鍵 (base64)
+// リリースのビルドで -ldflags "-X main.dataPublicKey=..." で埋め込む。空のビルドでは署名を確かめられず、
+// チェックサムはデータと同じ配布元から取得するので、配布元が改ざんされると見抜けない
+var dataPublicKey string
+
+// maxDataSize は取得する祝日データの上限サイズ
+const maxDataSize = 10 << 20
+
+// cachePath は取得したデータを保存するキャッシュ内のファイル name のパスを返す
+func cachePath(name string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bizday", name), nil
+}
+
+// runSelfUpdateData は最新の祝日データを取得し、チェックサムと署名を確かめてキャッシュに保存する
+// バイナリは更新せず、次回の起動から埋め込みデータの代わりに使われる
+func runSelfUpdateData(fs *flag.FlagSet) func() error {
+	url := fs.String("url", defaultDataURL, "祝日データの URL")
+	sumURL := fs.String("sha256-url", "", "チェックサムの URL (省略時は --url に .sha256 を付けたもの)")
+	sigURL := fs.String("sig-url", "", "署名の URL (省略時は --url に .sig を付けたもの)")
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if *sumURL == "" {
+			*sumURL = *url + ".sha256"
+		}
+		if *sigURL == "" {
+			*sigURL = *url + ".sig"
+		}
+		key, err := parsePublicKey(dataPublicKey)
+		if err != nil {
+			return err
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		data, err := fetch(client, *url)
+		if err != nil {
+			return err
+		}
+		sumText, err := fetch(client, *sumURL)
+		if err != nil {
+			return err
+		}
+		if err := verifyChecksum(data, sumText); err != nil {
+			return err
+		}
+		if key == nil {
+			fmt.Fprintln(os.Stderr, tr("警告: このビルドには署名の公開鍵が埋め込まれていないため、チェックサムだけを確かめました (配布元での改ざんは見抜けません)"))
+		} else {
+			sig, err := fetch(client, *sigURL)
+			if err != nil {
+				return err
+			}
+			if err := verifySignature(key, data, sig); err != nil {
+				return err
+			}
+		}
+
+		entries, err := bizday.ParseHolidays(data)
+		if err != nil {
+			return dataError(fmt.Errorf("取得した祝日データを読み込めません: %w", err))
+		}
+		first, last, n := coverage(entries)
+		if n == 0 {
+			return dataError(fmt.Errorf("取得した祝日データに祝日がありません"))
+		}
+
+		path, err := cachePath("holidays.yaml")
+		if err != nil {
+			return fmt.Errorf("キャッシュの場所を決められません: %w", err)
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			return fmt.Errorf("祝日データの保存に失敗: %w", err)
+		}
+		fmt.Printf(tr("祝日データを更新しました: %d 年~%d 年 (%d 件) → %s\n"), first, last, n, path)
+		return nil
+	}
+}
+
+// parsePublicKey は base64 の ed25519 の公開鍵を読む (空なら nil)
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("埋め込まれた署名の公開鍵が ed25519 の公開鍵ではありません: %q", s)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// verifyChecksum は data の SHA-256 が sumText と一致するかを確かめる
+// sumText は sha256sum の出力形式 ("<16 進数>  <ファイル名>") で、先頭だけを使う
+func verifyChecksum(data, sumText []byte) error {
+	fields := strings.Fields(string(sumText))
+	if len(fields) == 0 {
+		return dataError(fmt.Errorf("チェックサムが空です"))
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+		return dataError(fmt.Errorf("チェックサムが一致しません (期待値 %s, 実際 %s)", fields[0], got))
+	}
+	return nil
+}
+
+// verifySignature は sig (data への ed25519 の署名を base64 にしたもの) が key で確かめられるかを調べる
+func verifySignature(key ed25519.PublicKey, data, sig []byte) error {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil || len(b) != ed25519.SignatureSize {
+		return dataError(fmt.Errorf("署名を読めません"))
+	}
+	if !ed25519.Verify(key, data, b) {
+		return dataError(fmt.Errorf("署名が一致しません (配布元のデータが改ざんされたおそれがあります)"))
+	}
+	return nil
+}
+
+// fetch は url の内容を取得する
+func fetch(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, networkError(fmt.Errorf("取得に失敗: %w", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, networkError(fmt.Errorf("取得に失敗: %s (%s)", url, resp.Status))
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxDataSize))
+	if err != nil {
+		return nil, networkError(fmt.Errorf("取得に失敗: %w", err))
+	}
+	return b, nil
+}
+
+// coverage は祝日 (振替出勤日を除く) が含まれる最初と最後の年と件数を返す
+func coverage(entries []bizday.HolidayEntry) (first, last, n int) {
+	for _, e := range entries {
+		if e.Workday {
+			continue
+		}
+		y := e.Date.Year()
+		if n == 0 || y < first {
+			first = y
+		}
+		if n == 0 || y > last {
+			last = y
+		}
+		n++
+	}
+	return first, last, n
+}
+
+// writeFileAtomic は一時ファイルに書いてから置き換え、途中で失敗しても古いデータを壊さない
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
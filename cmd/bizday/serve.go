@@ -0,0 +1,769 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"bizday"
+)
+
+// server は serve の JSON API
+// カレンダーはクエリの calendar ごとに初回だけ組み立て、以降は共有する (Calendar は読み取り専用なので並行に使える)
+// 同じカレンダーに繰り返し問い合わせるので、営業日は年ごとのビットマップにして覚えておく
+type server struct {
+	flags        *calendarFlags // 既定のカレンダーと --holidays・--as-of などの指定
+	hoursPerDay  float64        // 営業日 1 日あたりの想定稼働時間
+	batchWorkers int            // POST /v1/batch で並行に答える goroutine の数 (0 以下なら GOMAXPROCS)
+
+	mu    sync.Mutex
+	cals  map[string]*bizday.Calendar // calendarKey で正規化した名前ごとの組み立て済みのカレンダー (最大 maxServeCalendars 件)
+	known map[string]bool             // calendar に指定できる名前 (起動時と再読み込みのたびに作り直す)
+	audit *auditLog                   // --audit-log の監査ログ (指定がなければ nil)
+	keys  []apiKeyYAML                // --keys の API のキー (なければ admin API は使えない)
+
+	overrides map[overrideKey]overrideJSON // admin API で足した休業日・営業日
+	journal   *overrideJournal             // --overrides-journal の記録 (指定がなければ nil で、再起動すると override は消える)
+}
+
+// maxServeCalendars は serve が組み立てて覚えておくカレンダーの数の上限
+// 組み合わせ (jp,us など) はいくらでも作れるので、上限を超えた分は覚えずに要求のたびに組み立てる
+const maxServeCalendars = 64
+
+// isBusinessDayJSON は /v1/is-business-day の応答
+type isBusinessDayJSON struct {
+	SchemaVersion int             `json:"schema_version"`
+	Calendar      string          `json:"calendar"`
+	Date          string          `json:"date"`
+	BusinessDay   bool            `json:"business_day"`
+	Class         bizday.DayClass `json:"class"`
+	Name          string          `json:"name,omitempty"`
+}
+
+// addJSON は /v1/add の応答
+type addJSON struct {
+	SchemaVersion int    `json:"schema_version"`
+	Calendar      string `json:"calendar"`
+	Date          string `json:"date"`
+	N             int    `json:"n"`
+	Result        string `json:"result"`
+}
+
+// reloadJSON は /reload の応答
+type reloadJSON struct {
+	SchemaVersion int      `json:"schema_version"`
+	Source        string   `json:"source"`
+	Calendars     []string `json:"calendars"`
+}
+
+// openAPISpec は serve の API の OpenAPI 3 の定義 (GET /openapi.json で返す)
+// エンドポイントや応答のフィールドを変えたら合わせて更新する
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// errorJSON はエラー時の応答
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+// runServe は営業日の判定・集計を JSON で返す HTTP サーバを起動する
+// /metrics では Prometheus 向けに営業日のゲージを、/openapi.json では API の OpenAPI 3 の定義を公開する (Go からは bizday/client で呼べる)
+// SIGINT/SIGTERM で受け付け中のリクエストを待ってから終了し、SIGUSR1 で今日のサマリと祝日データの状態をログに書く
+// SIGHUP か POST /reload で、再起動せずに祝日データと設定済みのカレンダーを読み込み直す
+// --keys に read のキーがあれば問い合わせに read か admin の、キーがあれば再読み込みに admin のキーが要る
+// --keys に admin のキーがあれば、/admin/v1/overrides で実行中に休業日・営業日を足したり取り除いたりできる (すぐにすべての問い合わせに効く)
+// --refresh を指定すると、その間隔で update の取得元から祝日データを取得し直し、更新できたらカレンダーを差し替える
+// POST /v1/batch で /v1/... の問い合わせを最大 maxBatchRequests 件まとめて送ると、--batch-workers 個の goroutine で並行に答える
+// GET /v1/calendar はカレンダーそのものを Calendar.MarshalJSON の形式で返す (別のサービスでそのまま使ったり覚えておいたりできる)
+// --grpc-addr を指定すると、同じ問い合わせを gRPC (proto/bizday/v1/bizday.proto の BizdayService) でも受け付ける
+func runServe(fs *flag.FlagSet) func() error {
+	addr := fs.String("addr", ":8080", "待ち受けるアドレス")
+	grpcAddr := fs.String("grpc-addr", "", "gRPC で待ち受けるアドレス (例: :9090)、省略時は gRPC を受け付けない")
+	gateway := fs.Bool("gateway", false, "HTTP API の /v1/... を grpc-gateway 経由で gRPC の実装に渡す (応答は proto の JSON の形式)")
+	metricsCalendars := fs.String("metrics-calendars", "", "/metrics に既定のカレンダーと合わせて出すカレンダー (例: us,uk)")
+	refresh := fs.Duration("refresh", 0, "この間隔 (に最大 1 割のゆらぎを足した時間) ごとに祝日データを取得し直す (例: 24h、0 なら取得し直さない)")
+	refreshSource := fs.String("refresh-source", "cao", "--refresh で取得し直す update の取得元 (cao, google、カンマ区切りで複数)")
+	keysPath := fs.String("keys", "", "API のキーのファイル (name・key・role の YAML のリスト)、read のキーがあれば問い合わせにもキーが要る")
+	journalPath := fs.String("overrides-journal", "", "admin API の書き換えを記録するファイル、起動時に読み直して祝日データの上に重ねる (省略時は再起動で消える)")
+	auditPath := fs.String("audit-log", "", "祝日データの再読み込みのたびに、前後のデータのハッシュを 1 行の JSON で書き足すファイル")
+	batchWorkers := fs.Int("batch-workers", runtime.GOMAXPROCS(0), "POST /v1/batch の問い合わせを並行に答える goroutine の数")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if *batchWorkers < 1 {
+			return fmt.Errorf("--batch-workers には 1 以上を指定してください: %d", *batchWorkers)
+		}
+		if *refresh < 0 {
+			return fmt.Errorf("--refresh には 0 以上の間隔を指定してください: %s", *refresh)
+		}
+		refreshFrom, err := parseRefreshSources(*refreshSource)
+		if err != nil {
+			return err
+		}
+		var keys []apiKeyYAML
+		if *keysPath != "" {
+			if keys, err = loadAPIKeys(*keysPath); err != nil {
+				return dataError(fmt.Errorf("API のキーのファイルの読み込みに失敗しました: %w", err))
+			}
+		}
+		var audit *auditLog
+		if *auditPath != "" {
+			if audit, err = openAuditLog(*auditPath); err != nil {
+				return err
+			}
+			defer audit.Close()
+		}
+
+		if err := ensureHolidays(); err != nil {
+			return err
+		}
+		// --region は既定のカレンダーにだけ効かせる (要求の calendar は uk-sct のように地域まで指定する)
+		if err := calFlags.applyRegion(); err != nil {
+			return err
+		}
+		s := &server{flags: calFlags, hoursPerDay: *hoursPerDay, batchWorkers: *batchWorkers, cals: map[string]*bizday.Calendar{}, audit: audit,
+			keys: keys, overrides: map[overrideKey]overrideJSON{}}
+		if err := s.loadKnown(); err != nil {
+			return err
+		}
+		if *journalPath != "" {
+			if s.journal, s.overrides, err = openOverrideJournal(*journalPath, s.checkOverride); err != nil {
+				return dataError(fmt.Errorf("書き換えの記録の読み込みに失敗しました: %w", err))
+			}
+			defer s.journal.Close()
+			slog.Debug("書き換えの記録を読み込みました", "path", *journalPath, "overrides", len(s.overrides))
+		}
+		// 起動時に既定のカレンダーを組み立てて、指定の誤りをすぐに知らせる
+		if _, _, err := s.namedCalendar(calFlags.country); err != nil {
+			return err
+		}
+		if *metricsCalendars != "" {
+			for _, name := range strings.Split(*metricsCalendars, ",") {
+				if _, _, err := s.namedCalendar(name); err != nil {
+					return err
+				}
+			}
+		}
+
+		handler, err := s.routes(*gateway)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{
+			Addr:              *addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go s.dumpStatusOnSignal(ctx)
+		go s.reloadOnSignal(ctx)
+		if *refresh > 0 {
+			go s.refreshPeriodically(ctx, *refresh, refreshFrom)
+		}
+
+		errc := make(chan error, 2)
+		go func() {
+			slog.Info("待ち受けています", "addr", *addr)
+			errc <- srv.ListenAndServe()
+		}()
+		var gsrv *grpc.Server
+		if *grpcAddr != "" {
+			lis, err := net.Listen("tcp", *grpcAddr)
+			if err != nil {
+				return networkError(fmt.Errorf("gRPC サーバを起動できません: %w", err))
+			}
+			gsrv = newGRPCServer(s)
+			go func() {
+				slog.Info("gRPC で待ち受けています", "addr", *grpcAddr)
+				errc <- gsrv.Serve(lis)
+			}()
+		}
+		select {
+		case err := <-errc:
+			return networkError(fmt.Errorf("サーバを起動できません: %w", err))
+		case <-ctx.Done():
+		}
+
+		slog.Info("終了しています")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if gsrv != nil {
+			gsrv.GracefulStop()
+		}
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// routes は API のエンドポイントを登録したハンドラを返す
+// gateway なら /v1/... は grpc-gateway (gatewayHandler) で受ける
+// --keys があれば、エンドポイントごとに要る役割のキーを確かめる (/openapi.json はキーがなくても読める)
+func (s *server) routes(gateway bool) (http.Handler, error) {
+	mux := http.NewServeMux()
+	query := s.queryRole()
+	if gateway {
+		gw, err := gatewayHandler(s)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle("/v1/", s.endpoint(query, gw.ServeHTTP))
+	} else {
+		mux.HandleFunc("GET /v1/is-business-day", s.endpoint(query, s.handleIsBusinessDay))
+		mux.HandleFunc("GET /v1/count", s.endpoint(query, s.handleCount))
+		mux.HandleFunc("GET /v1/add", s.endpoint(query, s.handleAdd))
+		mux.HandleFunc("GET /v1/month-summary", s.endpoint(query, s.handleMonthSummary))
+	}
+	// 一括の問い合わせとカレンダーの書き出しは gRPC にないので、--gateway のときも HTTP のハンドラで受ける
+	mux.HandleFunc("POST /v1/batch", s.endpoint(query, s.handleBatch))
+	mux.HandleFunc("GET /v1/calendar", s.endpoint(query, s.handleCalendar))
+	if hasRole(s.keys, roleAdmin) {
+		mux.HandleFunc("GET /admin/v1/overrides", s.requireRole(roleAdmin, s.handleListOverrides))
+		mux.HandleFunc("POST /admin/v1/overrides", s.requireRole(roleAdmin, s.handleSetOverride))
+		mux.HandleFunc("DELETE /admin/v1/overrides/{date}", s.requireRole(roleAdmin, s.handleDeleteOverride))
+	}
+	mux.HandleFunc("GET /metrics", s.endpoint(query, s.handleMetrics))
+	mux.HandleFunc("POST /reload", s.endpoint(s.reloadRole(), s.handleReload))
+	mux.HandleFunc("GET /openapi.json", handleOpenAPI)
+	return mux, nil
+}
+
+// calendar は名前 name (calendarKey で正規化したもの) のカレンダーを、serve に指定されたフラグ (--holidays・--as-of など) を適用して返す
+func (s *server) calendar(name string) (*bizday.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cal, ok := s.cals[name]; ok {
+		return cal, nil
+	}
+	cal, err := s.build(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.cals) < maxServeCalendars {
+		s.cals[name] = cal
+	}
+	return cal, nil
+}
+
+// calendarKey はクエリの calendar の値を、カレンダーを覚えておくときの名前に正規化する
+// カンマで区切った名前から空白・空の名前・重複を除き、先頭以外を昇順に並べる (jp,us,uk と jp, uk ,us,jp は同じ名前になる)
+// 組み合わせたカレンダーの営業時間は先頭のカレンダーのものなので、先頭はそのまま残す
+// 指定できない名前を含むならエラーを返す (組み立ても覚えもしない)
+func (s *server) calendarKey(raw string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !s.known[name] {
+			return "", fmt.Errorf("未知のカレンダー: %s", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("calendar にカレンダーの名前を指定してください: %q", raw)
+	}
+	sort.Strings(names[1:])
+	return strings.Join(names, ","), nil
+}
+
+// loadKnown は calendar に指定できる名前を読み込み直す
+func (s *server) loadKnown() error {
+	known, err := s.knownNames()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.known = known
+	s.mu.Unlock()
+	return nil
+}
+
+// knownNames は calendar に指定できる名前 (calendarNames と --holidays のファイルの calendars) の集合を返す
+func (s *server) knownNames() (map[string]bool, error) {
+	known := map[string]bool{}
+	for _, name := range calendarNames() {
+		known[name] = true
+	}
+	if s.flags.holidays != "" {
+		d, err := loadHolidays(s.flags.holidays)
+		if err != nil {
+			return nil, dataError(fmt.Errorf("祝日ファイルの読み込みに失敗しました: %w", err))
+		}
+		for name := range d.calendars {
+			known[name] = true
+		}
+	}
+	return known, nil
+}
+
+// build は名前 name のカレンダーを serve のフラグで組み立てる (s.mu を持った状態で呼ぶ)
+func (s *server) build(name string) (*bizday.Calendar, error) {
+	f := *s.flags
+	f.country = name
+	cal, err := f.resolve()
+	if err != nil {
+		return nil, err
+	}
+	if entries := s.overridesFor(name); len(entries) > 0 {
+		cal = cal.WithExtra(entries)
+	}
+	return cal.WithBitmapCache(), nil
+}
+
+// rebuild はそれまでに組み立てたカレンダーをすべて今のデータと override で作り直して返す (s.mu を持った状態で呼ぶ)
+func (s *server) rebuild() (map[string]*bizday.Calendar, error) {
+	cals := make(map[string]*bizday.Calendar, len(s.cals))
+	for name := range s.cals {
+		cal, err := s.build(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		cals[name] = cal
+	}
+	return cals, nil
+}
+
+// reload は祝日データを読み込み直し、それまでに組み立てたカレンダーをすべて作り直して差し替える
+// 読み込みや組み立てに失敗したときは何も差し替えず、以前のデータで動き続ける
+// 処理中のリクエストは差し替え前のカレンダーを使い終えるまで使う (Calendar は差し替えるだけで書き換えない)
+// --audit-log があれば、きっかけ event と POST /reload を呼んだキーの name (actor)、差し替えの前後のデータの出どころ・カレンダーごとのハッシュを (失敗したときも) 書き足す
+func (s *server) reload(event, actor string) (reloadJSON, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out reloadJSON
+	err := s.audited(event, actor, "", func() error {
+		var err error
+		out, err = s.swap()
+		return err
+	})
+	return out, err
+}
+
+// audited は change でカレンダーを差し替え、--audit-log があればその前後を監査ログに書き足す (s.mu を持った状態で呼ぶ)
+// 書くのはきっかけ event、操作した人 actor と内容 detail (admin API のとき)、前後のデータの出どころとカレンダーごとのハッシュで、
+// change が失敗したときもそのエラーを書く
+func (s *server) audited(event, actor, detail string, change func() error) error {
+	if s.audit == nil {
+		return change()
+	}
+	now := time.Now()
+	entry := auditEntry{Time: now.Format(time.RFC3339), Event: event, Actor: actor, Detail: detail,
+		SourceBefore: s.source(), Calendars: calendarHashes(s.cals, now)}
+	err := change()
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.SourceAfter = s.source()
+		for i := range entry.Calendars {
+			c := &entry.Calendars[i]
+			c.After = calendarHash(s.cals[c.Name], now)
+			entry.Changed = entry.Changed || c.After != c.Before
+		}
+	}
+	if werr := s.audit.write(entry); werr != nil {
+		slog.Error("監査ログに書けません", "err", werr)
+	}
+	return err
+}
+
+// swap は reload の本体 (s.mu を持った状態で呼ぶ)
+func (s *server) swap() (reloadJSON, error) {
+	restore, err := reloadHolidays()
+	if err != nil {
+		return reloadJSON{}, err
+	}
+	cals, err := s.rebuild()
+	if err != nil {
+		restore()
+		return reloadJSON{}, err
+	}
+	known, err := s.knownNames()
+	if err != nil {
+		restore()
+		return reloadJSON{}, err
+	}
+	s.cals, s.known = cals, known
+
+	out := reloadJSON{SchemaVersion: bizday.SchemaVersion, Source: s.source(), Calendars: []string{}}
+	for name := range cals {
+		out.Calendars = append(out.Calendars, name)
+	}
+	sort.Strings(out.Calendars)
+	return out, nil
+}
+
+// handleReload は祝日データを読み込み直し、読み込んだデータの出どころと作り直したカレンダーを返す
+func (s *server) handleReload(w http.ResponseWriter, r *http.Request) {
+	var actor string
+	if k, ok := authenticate(s.keys, r); ok {
+		actor = k.Name
+	}
+	out, err := s.reload(auditHTTP, actor)
+	if err != nil {
+		slog.Error("再読み込みに失敗しました", "err", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	slog.Info("祝日データを読み込み直しました", "source", out.Source)
+	writeResponse(w, http.StatusOK, out)
+}
+
+// reloadOnSignal は reloadSignals を受け取るたびに祝日データを読み込み直す
+func (s *server) reloadOnSignal(ctx context.Context) {
+	sigs := reloadSignals()
+	if len(sigs) == 0 {
+		return
+	}
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+	defer signal.Stop(c)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c:
+			if out, err := s.reload(auditSignal, ""); err != nil {
+				slog.Error("再読み込みに失敗しました", "err", err)
+			} else {
+				slog.Info("祝日データを読み込み直しました", "source", out.Source)
+			}
+		}
+	}
+}
+
+// namedCalendar は calendar の値 raw (省略時は serve の --calendar) を正規化し、そのカレンダーと名前を返す
+func (s *server) namedCalendar(raw string) (*bizday.Calendar, string, error) {
+	if raw == "" {
+		raw = s.flags.country
+	}
+	name, err := s.calendarKey(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	cal, err := s.calendar(name)
+	return cal, name, err
+}
+
+// paramDate は要求の項目 key の値 v を日付として読む (空なら def)
+func paramDate(key, v string, def time.Time) (time.Time, error) {
+	if v == "" {
+		return def, nil
+	}
+	t, err := parseDateTime(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return t, nil
+}
+
+// paramMonth は要求の month の値 v (例: 2025-05) の月の、集計の基準にする日を返す (空なら now)
+func paramMonth(v string, now time.Time) (time.Time, error) {
+	if v == "" {
+		return now, nil
+	}
+	month, err := time.ParseInLocation("2006-01", v, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("month は 2025-05 の形式で指定してください: %s", v)
+	}
+	return monthReference(month, now), nil
+}
+
+// checkCoverage は start~end に祝日データのない年があるとき、serve の --coverage が error ならエラーを返し、それ以外なら警告をログに書く
+// CLI の checkCoverage と違って標準エラー出力には書かない
+func (s *server) checkCoverage(cal *bizday.Calendar, start, end time.Time) error {
+	err := cal.CheckCoverage(start, end)
+	var uncovered *bizday.UncoveredError
+	if !errors.As(err, &uncovered) {
+		return err
+	}
+	if bizday.CoveragePolicy(s.flags.coverage) == bizday.CoverageError {
+		return err
+	}
+	slog.Warn("祝日データがない年の祝日は平日として数えています", "years", uncovered.Years)
+	return nil
+}
+
+// 以下の isBusinessDay・add・count・monthSummary は HTTP API と gRPC (grpc.go) で共通の処理
+// どれも --coverage の扱いに従って、祝日データのない年を黙って数えないようにする
+// 引数は要求の項目の値そのもので、エラーはどれも要求の誤り (HTTP は 400、gRPC は InvalidArgument)
+
+// isBusinessDay は date (省略時は今日) が営業日かを返す
+func (s *server) isBusinessDay(calendar, date string) (isBusinessDayJSON, error) {
+	cal, name, err := s.namedCalendar(calendar)
+	if err != nil {
+		return isBusinessDayJSON{}, err
+	}
+	t, err := paramDate("date", date, time.Now())
+	if err != nil {
+		return isBusinessDayJSON{}, err
+	}
+	if err := s.checkCoverage(cal, t, t); err != nil {
+		return isBusinessDayJSON{}, err
+	}
+	class, holiday := cal.Classify(t)
+	return isBusinessDayJSON{
+		SchemaVersion: bizday.SchemaVersion,
+		Calendar:      name,
+		Date:          dateString(t),
+		BusinessDay:   cal.IsBusinessDay(t),
+		Class:         class,
+		Name:          holiday,
+	}, nil
+}
+
+// add は date (省略時は今日) の n 営業日後 (負なら前) の日付を返す
+func (s *server) add(calendar, date string, n int) (addJSON, error) {
+	cal, name, err := s.namedCalendar(calendar)
+	if err != nil {
+		return addJSON{}, err
+	}
+	t, err := paramDate("date", date, time.Now())
+	if err != nil {
+		return addJSON{}, err
+	}
+	if err := checkAddBusinessDays(n); err != nil {
+		return addJSON{}, err
+	}
+	d, err := cal.AddBusinessDays(t, n)
+	if err != nil {
+		return addJSON{}, err
+	}
+	if err := s.checkCoverage(cal, t, d); err != nil {
+		return addJSON{}, err
+	}
+	return addJSON{
+		SchemaVersion: bizday.SchemaVersion,
+		Calendar:      name,
+		Date:          dateString(t),
+		N:             n,
+		Result:        dateString(d),
+	}, nil
+}
+
+// count は from~to (両端含む) の営業日数を返す (breakdown なら内訳も)
+func (s *server) count(calendar, from, to string, breakdown bool) (rangeJSON, error) {
+	cal, name, err := s.namedCalendar(calendar)
+	if err != nil {
+		return rangeJSON{}, err
+	}
+	if from == "" || to == "" {
+		return rangeJSON{}, errors.New("from と to は両方指定してください")
+	}
+	a, err := paramDate("from", from, time.Time{})
+	if err != nil {
+		return rangeJSON{}, err
+	}
+	b, err := paramDate("to", to, time.Time{})
+	if err != nil {
+		return rangeJSON{}, err
+	}
+	if err := checkRange(a, b); err != nil {
+		return rangeJSON{}, err
+	}
+	if err := s.checkCoverage(cal, a, b); err != nil {
+		return rangeJSON{}, err
+	}
+	return newRangeJSON(cal, name, bizday.BeginningOfDay(a), bizday.BeginningOfDay(b), s.dayHours(), breakdown)
+}
+
+// monthSummary は month (例: 2025-05、省略時は今月) の営業日の経過状況を返す
+func (s *server) monthSummary(calendar, month string) (summaryJSON, error) {
+	cal, name, err := s.namedCalendar(calendar)
+	if err != nil {
+		return summaryJSON{}, err
+	}
+	ref, err := paramMonth(month, time.Now())
+	if err != nil {
+		return summaryJSON{}, err
+	}
+	if err := s.checkCoverage(cal, bizday.BeginningOfMonth(ref), bizday.EndOfMonth(ref)); err != nil {
+		return summaryJSON{}, err
+	}
+	return newSummaryJSON(cal, name, ref, s.dayHours(), 1), nil
+}
+
+// handleIsBusinessDay は date (省略時は今日) が営業日かを返す
+func (s *server) handleIsBusinessDay(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	out, err := s.isBusinessDay(q.Get("calendar"), q.Get("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
+
+// handleAdd は date (省略時は今日) の n 営業日後 (負なら前) の日付を返す
+func (s *server) handleAdd(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	n, err := strconv.Atoi(q.Get("n"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("n には営業日数を整数で指定してください"))
+		return
+	}
+	out, err := s.add(q.Get("calendar"), q.Get("date"), n)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
+
+// handleCount は from~to (両端含む) の営業日数を返す (breakdown=true なら内訳も)
+func (s *server) handleCount(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	out, err := s.count(q.Get("calendar"), q.Get("from"), q.Get("to"), q.Get("breakdown") == "true")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
+
+// handleMonthSummary は month (例: 2025-05、省略時は今月) の営業日の経過状況を返す
+func (s *server) handleMonthSummary(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	out, err := s.monthSummary(q.Get("calendar"), q.Get("month"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, out)
+}
+
+// maxCalendarYears は GET /v1/calendar で展開する年数の上限
+const maxCalendarYears = 100
+
+// handleCalendar は calendar の実際に使うカレンダー (admin API で足した休業日・営業日を含む) を Calendar.MarshalJSON の形式で返す
+// 祝日の一覧を持つカレンダー (jp など) は一覧の祝日をすべて返し、規則で算出する祝日は from~to 年 (省略時は今年) の分を展開する
+// client は bizday.Calendar に読み込んでそのまま使える
+func (s *server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	cal, _, err := s.namedCalendar(q.Get("calendar"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	from := time.Now().Year()
+	if v := q.Get("from"); v != "" {
+		if from, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("from には年を整数で指定してください: %s", v))
+			return
+		}
+	}
+	to := from
+	if v := q.Get("to"); v != "" {
+		if to, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("to には年を整数で指定してください: %s", v))
+			return
+		}
+	}
+	if to < from || to-from >= maxCalendarYears {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("from~to には %d 年以内の年の範囲を指定してください: %d~%d", maxCalendarYears, from, to))
+		return
+	}
+	if err := s.checkCoverage(cal, time.Date(from, time.January, 1, 0, 0, 0, 0, time.Local), time.Date(to, time.December, 31, 0, 0, 0, 0, time.Local)); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, cal.Expand(from, to))
+}
+
+// handleOpenAPI は API の OpenAPI 3 の定義を返す
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(openAPISpec)
+}
+
+// writeResponse は v を JSON の応答として書き出す
+func writeResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := encodeJSON(w, v); err != nil {
+		slog.Error("応答の書き出しに失敗しました", "err", err)
+	}
+}
+
+// writeError はエラーを JSON の応答として書き出す
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeResponse(w, status, errorJSON{Error: err.Error()})
+}
+
+// dumpStatusOnSignal は statusSignals を受け取るたびに、既定のカレンダーでの今日のサマリと祝日データの状態をログに書く
+func (s *server) dumpStatusOnSignal(ctx context.Context) {
+	sigs := statusSignals()
+	if len(sigs) == 0 {
+		return
+	}
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+	defer signal.Stop(c)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c:
+			cal, _, err := s.namedCalendar(s.flags.country)
+			if err != nil {
+				slog.Error("状態", "err", err)
+				continue
+			}
+			logStatus(cal, s.flags.country, time.Now(), s.dataSource())
+		}
+	}
+}
+
+// logStatus は serve と watch が statusSignals を受け取ったときに書く、now の日のサマリと祝日データの出どころのログ
+func logStatus(cal *bizday.Calendar, name string, now time.Time, source string) {
+	st := cal.MonthStats(now)
+	slog.Info("状態", "date", dateString(now), "calendar", name,
+		"business_day_index", st.Index, "business_days_total", st.BusinessDays, "business_days_remaining", st.Remaining,
+		"business_day", cal.IsBusinessDay(now), "source", source)
+}
+
+// dayHours は API で使う 1 日あたりの想定稼働時間 (serve の --hours-per-day と設定ファイルの曜日ごとの設定)
+func (s *server) dayHours() bizday.DayHours {
+	return dayHours(s.hoursPerDay)
+}
+
+// dataSource は serve が使っている祝日データの出どころを返す
+func (s *server) dataSource() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source()
+}
+
+// source は dataSource の本体 (s.mu を持った状態で呼ぶ)
+func (s *server) source() string {
+	return s.flags.source()
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"bizday"
+)
+
+// テストではホームの設定ファイル・キャッシュと $BIZDAY_HOLIDAYS を読まず、埋め込みの祝日データだけを使う
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "bizday-test")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", dir)
+	os.Setenv("XDG_CACHE_HOME", dir)
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	os.Unsetenv(holidaysEnv)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// testKeys は RBAC のテストに使う API のキー
+var testKeys = []apiKeyYAML{
+	{Name: "reader", Key: "read-key-0123456789", Role: roleRead},
+	{Name: "operator", Key: "admin-key-0123456789", Role: roleAdmin},
+}
+
+// newTestServer は既定のカレンダーが jp の serve のハンドラを返す
+func newTestServer(t *testing.T, keys []apiKeyYAML) http.Handler {
+	t.Helper()
+	if err := ensureHolidays(); err != nil {
+		t.Fatalf("ensureHolidays: %v", err)
+	}
+	s := &server{flags: &calendarFlags{country: "jp", coverage: string(bizday.CoverageWarn)}, hoursPerDay: 8,
+		cals: map[string]*bizday.Calendar{}, keys: keys, overrides: map[overrideKey]overrideJSON{}}
+	if err := s.loadKnown(); err != nil {
+		t.Fatalf("loadKnown: %v", err)
+	}
+	h, err := s.routes(false)
+	if err != nil {
+		t.Fatalf("routes: %v", err)
+	}
+	return h
+}
+
+// serveJSON は h に method path の要求を送り、状態コードと JSON の応答を返す (key があれば Bearer で送る)
+func serveJSON(t *testing.T, h http.Handler, method, path, key, body string) (int, map[string]any) {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		r.Header.Set("Authorization", "Bearer "+key)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var v map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("%s %s: 応答が JSON ではありません: %v: %s", method, path, err, w.Body)
+	}
+	return w.Code, v
+}
+
+func TestServeQueries(t *testing.T) {
+	h := newTestServer(t, nil)
+	tests := []struct {
+		path   string
+		status int
+		field  string
+		want   any
+	}{
+		{"/v1/is-business-day?date=2025-05-05", http.StatusOK, "business_day", false},
+		{"/v1/is-business-day?date=2025-05-05", http.StatusOK, "name", "こどもの日"},
+		{"/v1/is-business-day?date=2025-05-07", http.StatusOK, "class", "business"},
+		{"/v1/is-business-day?date=2025-07-04&calendar=us", http.StatusOK, "business_day", false},
+		{"/v1/is-business-day?date=2025-07-04&calendar=jp,%20us", http.StatusOK, "calendar", "jp,us"},
+		{"/v1/count?from=2025-05-01&to=2025-05-31", http.StatusOK, "business_days", float64(20)},
+		{"/v1/add?date=2025-05-02&n=1", http.StatusOK, "result", "2025-05-07"},
+		{"/v1/add?date=2025-05-07&n=-1", http.StatusOK, "result", "2025-05-02"},
+		{"/v1/month-summary?month=2025-05", http.StatusOK, "business_days_total", float64(20)},
+		{"/v1/month-summary?month=2025-05", http.StatusOK, "calendar_days_remaining", float64(0)},
+		{"/v1/month-summary?month=2025-05", http.StatusOK, "calendar_percent_elapsed", float64(100)},
+		{"/v1/is-business-day?date=2025-13-01", http.StatusBadRequest, "", nil},
+		{"/v1/is-business-day?date=2025-05-05&calendar=xx", http.StatusBadRequest, "", nil},
+		{"/v1/count?from=2025-05-31&to=2025-05-01", http.StatusBadRequest, "", nil},
+		{"/v1/add?date=2025-05-02&n=x", http.StatusBadRequest, "", nil},
+	}
+	for _, tt := range tests {
+		status, v := serveJSON(t, h, http.MethodGet, tt.path, "", "")
+		if status != tt.status {
+			t.Errorf("GET %s = %d, want %d: %v", tt.path, status, tt.status, v)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			if _, ok := v["error"]; !ok {
+				t.Errorf("GET %s の応答に error がありません: %v", tt.path, v)
+			}
+			continue
+		}
+		if got := v[tt.field]; got != tt.want {
+			t.Errorf("GET %s: %s = %v, want %v", tt.path, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestServeCoverage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	yaml := "calendars:\n  acme:\n    holidays:\n      - date: 2025-01-01\n        name: 元日\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		coverage bizday.CoveragePolicy
+		path     string
+		want     int
+	}{
+		{bizday.CoverageError, "/v1/is-business-day?date=2025-05-07", http.StatusOK},
+		{bizday.CoverageError, "/v1/is-business-day?date=2024-05-07", http.StatusBadRequest},
+		{bizday.CoverageError, "/v1/count?from=2024-12-01&to=2025-01-31", http.StatusBadRequest},
+		{bizday.CoverageError, "/v1/add?date=2025-01-06&n=-5", http.StatusBadRequest},
+		{bizday.CoverageError, "/v1/month-summary?month=2024-12", http.StatusBadRequest},
+		{bizday.CoverageWarn, "/v1/is-business-day?date=2024-05-07", http.StatusOK},
+	}
+	for _, tt := range tests {
+		s := &server{flags: &calendarFlags{country: "acme", holidays: path, coverage: string(tt.coverage)}, hoursPerDay: 8,
+			cals: map[string]*bizday.Calendar{}, overrides: map[overrideKey]overrideJSON{}}
+		if err := s.loadKnown(); err != nil {
+			t.Fatalf("loadKnown: %v", err)
+		}
+		h, err := s.routes(false)
+		if err != nil {
+			t.Fatalf("routes: %v", err)
+		}
+		if got, v := serveJSON(t, h, http.MethodGet, tt.path, "", ""); got != tt.want {
+			t.Errorf("GET %s (--coverage %s) = %d, want %d: %v", tt.path, tt.coverage, got, tt.want, v)
+		}
+	}
+}
+
+func TestServeBatch(t *testing.T) {
+	h := newTestServer(t, nil)
+	body := `{"requests": [
+		{"op": "is-business-day", "date": "2025-05-05"},
+		{"op": "add", "date": "2025-05-02", "n": 1},
+		{"op": "count", "calendar": "us", "from": "2025-07-01", "to": "2025-07-31"},
+		{"op": "month-summary", "month": "2025-05"},
+		{"op": "add", "date": "2025-13-01", "n": 1},
+		{"op": "roll"}
+	]}`
+	status, v := serveJSON(t, h, http.MethodPost, "/v1/batch", "", body)
+	if status != http.StatusOK {
+		t.Fatalf("POST /v1/batch = %d: %v", status, v)
+	}
+	results, _ := v["results"].([]any)
+	if len(results) != 6 {
+		t.Fatalf("results = %v, want 6 件", v["results"])
+	}
+	field := func(i int, key, name string) any {
+		r, _ := results[i].(map[string]any)
+		out, _ := r[key].(map[string]any)
+		return out[name]
+	}
+	if got := field(0, "is_business_day", "business_day"); got != false {
+		t.Errorf("results[0].is_business_day.business_day = %v, want false", got)
+	}
+	if got := field(1, "add", "result"); got != "2025-05-07" {
+		t.Errorf("results[1].add.result = %v, want 2025-05-07", got)
+	}
+	if got := field(2, "count", "business_days"); got != float64(22) {
+		t.Errorf("results[2].count.business_days = %v, want 22", got)
+	}
+	if got := field(3, "month_summary", "business_days_total"); got != float64(20) {
+		t.Errorf("results[3].month_summary.business_days_total = %v, want 20", got)
+	}
+	for _, i := range []int{4, 5} {
+		if r, _ := results[i].(map[string]any); r["error"] == nil || len(r) != 1 {
+			t.Errorf("results[%d] = %v, want error だけ", i, r)
+		}
+	}
+
+	for _, body := range []string{`{"requests": []}`, `{"requests": [{"op": "add", "days": 1}]}`, `[`} {
+		if status, v := serveJSON(t, h, http.MethodPost, "/v1/batch", "", body); status != http.StatusBadRequest {
+			t.Errorf("POST /v1/batch %s = %d, want %d: %v", body, status, http.StatusBadRequest, v)
+		}
+	}
+}
+
+func TestServeCalendar(t *testing.T) {
+	h := newTestServer(t, testKeys)
+	admin := testKeys[1].Key
+	if status, v := serveJSON(t, h, http.MethodPost, "/admin/v1/overrides", admin, `{"date": "2025-05-07", "kind": "holiday", "name": "臨時休業"}`); status != http.StatusOK {
+		t.Fatalf("POST /admin/v1/overrides = %d: %v", status, v)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/v1/calendar?calendar=jp,us&from=2025&to=2025", nil)
+	r.Header.Set("Authorization", "Bearer "+admin)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /v1/calendar = %d: %s", w.Code, w.Body)
+	}
+	var cal bizday.Calendar
+	if err := json.Unmarshal(w.Body.Bytes(), &cal); err != nil {
+		t.Fatalf("GET /v1/calendar の応答を Calendar に読めません: %v", err)
+	}
+	// 日本の祝日・米国の祝日・admin API で足した休業日がどれも入っている
+	for _, d := range []time.Time{time.Date(2025, 5, 5, 0, 0, 0, 0, time.Local), time.Date(2025, 7, 4, 0, 0, 0, 0, time.Local), time.Date(2025, 5, 7, 0, 0, 0, 0, time.Local)} {
+		if cal.IsBusinessDay(d) {
+			t.Errorf("GET /v1/calendar のカレンダーで %s が営業日になる", d.Format(time.DateOnly))
+		}
+	}
+	if !cal.IsBusinessDay(time.Date(2025, 5, 8, 0, 0, 0, 0, time.Local)) {
+		t.Error("GET /v1/calendar のカレンダーで 2025-05-08 が休業日になる")
+	}
+
+	for _, path := range []string{"/v1/calendar?from=x", "/v1/calendar?from=2025&to=2024", "/v1/calendar?from=2000&to=2100", "/v1/calendar?calendar=xx"} {
+		if status, v := serveJSON(t, h, http.MethodGet, path, admin, ""); status != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want %d: %v", path, status, http.StatusBadRequest, v)
+		}
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	for _, workers := range []int{0, 1, 7, 5000} {
+		got := parallelMap(items, workers, func(n int) int { return n * 2 })
+		for i, n := range got {
+			if n != i*2 {
+				t.Fatalf("parallelMap (workers %d)[%d] = %d, want %d", workers, i, n, i*2)
+			}
+		}
+	}
+	if got := parallelMap([]int{}, 4, func(n int) int { return n }); len(got) != 0 {
+		t.Errorf("parallelMap(空) = %v", got)
+	}
+}
+
+func TestServeRoles(t *testing.T) {
+	h := newTestServer(t, testKeys)
+	reader, admin := testKeys[0].Key, testKeys[1].Key
+	tests := []struct {
+		method, path, key string
+		want              int
+	}{
+		{http.MethodGet, "/v1/is-business-day?date=2025-05-07", "", http.StatusUnauthorized},
+		{http.MethodGet, "/v1/is-business-day?date=2025-05-07", "wrong-key-0123456789", http.StatusUnauthorized},
+		{http.MethodGet, "/v1/is-business-day?date=2025-05-07", reader, http.StatusOK},
+		{http.MethodGet, "/v1/is-business-day?date=2025-05-07", admin, http.StatusOK},
+		{http.MethodPost, "/reload", reader, http.StatusForbidden},
+		{http.MethodPost, "/reload", admin, http.StatusOK},
+		{http.MethodGet, "/admin/v1/overrides", "", http.StatusUnauthorized},
+		{http.MethodGet, "/admin/v1/overrides", reader, http.StatusForbidden},
+		{http.MethodGet, "/admin/v1/overrides", admin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got, v := serveJSON(t, h, tt.method, tt.path, tt.key, ""); got != tt.want {
+			t.Errorf("%s %s (key %q) = %d, want %d: %v", tt.method, tt.path, tt.key, got, tt.want, v)
+		}
+	}
+
+	// /openapi.json はキーがなくても読める
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /openapi.json = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestServeOverrides(t *testing.T) {
+	h := newTestServer(t, testKeys)
+	admin := testKeys[1].Key
+	const day = "/v1/is-business-day?date=2025-05-07"
+	businessDay := func() any {
+		t.Helper()
+		_, v := serveJSON(t, h, http.MethodGet, day, admin, "")
+		return v["business_day"]
+	}
+
+	if status, v := serveJSON(t, h, http.MethodPost, "/admin/v1/overrides", admin, `{"date": "2025-05-07", "kind": "holiday", "name": "臨時休業"}`); status != http.StatusOK {
+		t.Fatalf("POST /admin/v1/overrides = %d: %v", status, v)
+	}
+	if got := businessDay(); got != false {
+		t.Errorf("臨時休業を足した後の business_day = %v, want false", got)
+	}
+	if status, v := serveJSON(t, h, http.MethodPost, "/admin/v1/overrides", admin, `{"date": "2025-05-07", "kind": "closed"}`); status != http.StatusBadRequest {
+		t.Errorf("kind の誤った POST /admin/v1/overrides = %d, want %d: %v", status, http.StatusBadRequest, v)
+	}
+	if status, v := serveJSON(t, h, http.MethodDelete, "/admin/v1/overrides/2025-05-07", admin, ""); status != http.StatusOK {
+		t.Fatalf("DELETE /admin/v1/overrides = %d: %v", status, v)
+	}
+	if got := businessDay(); got != true {
+		t.Errorf("臨時休業を取り除いた後の business_day = %v, want true", got)
+	}
+	if status, _ := serveJSON(t, h, http.MethodDelete, "/admin/v1/overrides/2025-05-07", admin, ""); status != http.StatusNotFound {
+		t.Errorf("足していない日の DELETE = %d, want %d", status, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// statusSignals は長時間動くモードで状態をログに書くためのシグナル (SIGUSR1 のない OS ではなし)
+func statusSignals() []os.Signal {
+	return nil
+}
+
+// reloadSignals は serve・watch で祝日データを読み込み直すシグナル (SIGHUP のない OS ではなし、POST /reload を使う)
+func reloadSignals() []os.Signal {
+	return nil
+}
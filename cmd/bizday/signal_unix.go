@@ -0,0 +1,18 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// statusSignals は長時間動くモードで状態をログに書くためのシグナル
+func statusSignals() []os.Signal {
+	return []os.Signal{syscall.SIGUSR1}
+}
+
+// reloadSignals は serve・watch で祝日データを読み込み直すシグナル
+func reloadSignals() []os.Signal {
+	return []os.Signal{syscall.SIGHUP}
+}
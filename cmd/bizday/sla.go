@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runSLA は受付日時と SLA (対応期限までの時間) から、営業時間に沿った SLA の期限日時を表示する
+// 営業時間外に受け付けたチケットは次の営業時間の始まりから数える (--policy 24x7 なら受付時刻から暦の時間で数える)
+func runSLA(fs *flag.FlagSet) func() error {
+	received := fs.String("received", "", "チケットの受付日時 (例: \"2025-04-30 16:00\")、省略時は現在時刻")
+	slaStr := fs.String("sla", "", "SLA の時間 (例: 8h, 1h30m, 2bd)、bd は営業日、単位が h・m なら営業時間で数える")
+	policy := fs.String("policy", "business", "時間の数え方 (business: 営業時間だけ数える, 24x7: 暦の時間で数える)")
+	cutoff := fs.String("cutoff", "", "この時刻 (例: 17:00) 以降の受付は翌営業日の始業から数える")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *slaStr == "" {
+			return fmt.Errorf("--sla を指定してください (例: --sla 8h)")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		t := time.Now()
+		if *received != "" {
+			if t, err = parseDateTime(*received); err != nil {
+				return err
+			}
+		}
+		// 8h・90m のような Go の時間の書式は営業時間、それ以外は bd・bh などの単位付きの期間
+		sla, err := time.ParseDuration(*slaStr)
+		if err != nil {
+			span, err := bizday.ParseSpan(*slaStr, bizday.SpanBusinessHours)
+			if err != nil {
+				return err
+			}
+			sla = cal.SpanDuration(t, span)
+		}
+		if sla <= 0 {
+			return fmt.Errorf("--sla には正の時間を指定してください")
+		}
+
+		switch *policy {
+		case "24x7":
+			fmt.Printf(tr("SLA の期限は %s です\n"), formatDateTime(t.Add(sla)))
+			return nil
+		case "business":
+		default:
+			return fmt.Errorf("--policy には business か 24x7 を指定してください: %s", *policy)
+		}
+
+		start, reason := t, tr("営業時間外")
+		if *cutoff != "" {
+			c, err := time.Parse("15:04", *cutoff)
+			if err != nil {
+				return fmt.Errorf("--cutoff は 17:00 の形式で指定してください: %s", *cutoff)
+			}
+			if cal.IsBusinessDay(t) && t.Hour()*60+t.Minute() >= c.Hour()*60+c.Minute() {
+				next, err := cal.NextBusinessDay(t)
+				if err != nil {
+					return err
+				}
+				start, reason = bizday.BeginningOfDay(next), fmt.Sprintf(tr("締め切り %s 以降"), *cutoff)
+			}
+		}
+		if start, err = cal.NextOpen(start); err != nil {
+			return err
+		}
+		due, err := cal.AddBusinessHours(start, sla)
+		if err != nil {
+			return err
+		}
+		if err := checkCoverage(cal, t, due); err != nil {
+			return err
+		}
+		if !start.Equal(t) {
+			fmt.Printf(tr("受付 %s は%sのため、%s から数えます\n"), formatDateTime(t), reason, formatDateTime(start))
+		}
+		fmt.Printf(tr("SLA の期限は %s です\n"), formatDateTime(due))
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// Snapshot はある期間の全日の分類を、計算に使った設定とともに固定したもの
+// SHA256 は SHA256 を空にした Snapshot を json.Marshal した結果のハッシュで、
+// 同じ設定・同じ祝日データなら常に同じ値になる
+type Snapshot struct {
+	SchemaVersion int           `json:"schema_version"`
+	Calendar      string        `json:"calendar"`
+	Weekend       string        `json:"weekend,omitempty"`
+	AsOf          string        `json:"as_of,omitempty"`
+	From          string        `json:"from"`
+	To            string        `json:"to"`
+	Days          []SnapshotDay `json:"days"`
+	SHA256        string        `json:"sha256,omitempty"`
+}
+
+// SnapshotDay は 1 日分の分類
+type SnapshotDay struct {
+	Date        string          `json:"date"`
+	Weekday     string          `json:"weekday"`
+	Class       bizday.DayClass `json:"class"`
+	BusinessDay bool            `json:"business_day"`
+	Name        string          `json:"name,omitempty"`
+}
+
+// hash はスナップショットの内容 (SHA256 を除く) のハッシュを 16 進数で返す
+func (s Snapshot) hash() (string, error) {
+	s.SHA256 = ""
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// runSnapshot は 1 年分の全日の分類をハッシュ付きの JSON で書き出す
+func runSnapshot(fs *flag.FlagSet) func() error {
+	year := fs.Int("year", time.Now().Year(), "対象の年")
+	out := fs.String("o", "", "書き出すファイル (省略時は標準出力)")
+	calFlags := addCalendarFlags(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		start := time.Date(*year, 1, 1, 0, 0, 0, 0, time.Local)
+		end := time.Date(*year, 12, 31, 0, 0, 0, 0, time.Local)
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		s := Snapshot{
+			SchemaVersion: bizday.SchemaVersion,
+			Calendar:      calFlags.country,
+			Weekend:       calFlags.weekend,
+			AsOf:          calFlags.asOf,
+			From:          start.Format("2006-01-02"),
+			To:            end.Format("2006-01-02"),
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			class, name := cal.Classify(d)
+			s.Days = append(s.Days, SnapshotDay{
+				Date:        d.Format("2006-01-02"),
+				Weekday:     strings.ToLower(d.Weekday().String()[:3]),
+				Class:       class,
+				BusinessDay: cal.IsBusinessDay(d),
+				Name:        name,
+			})
+		}
+		if s.SHA256, err = s.hash(); err != nil {
+			return err
+		}
+
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+		if *out == "" {
+			_, err = os.Stdout.Write(b)
+			return err
+		}
+		if err := os.WriteFile(*out, b, 0o644); err != nil {
+			return fmt.Errorf("スナップショットの書き出しに失敗: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "%s に書き出しました (sha256: %s)\n", *out, s.SHA256)
+		return nil
+	}
+}
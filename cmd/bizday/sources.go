@@ -0,0 +1,329 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bizday"
+)
+
+// 設定ファイルの holiday_sources に name で指定できる組み込みの取得元
+const (
+	sourceUpdate   = "update"   // update で取得した内閣府の CSV か Google カレンダーの .ics (新しい方)
+	sourceData     = "data"     // self-update-data で取得した YAML
+	sourceEmbedded = "embedded" // 埋め込み済みの YAML
+)
+
+// holidaySourceYAML は設定ファイルの holiday_sources の要素 (祝日データの取得元 1 件)
+// 組み込みの取得元なら name だけを、ファイルなら path (と表示用の name) を書く
+type holidaySourceYAML struct {
+	Name string `yaml:"name"`
+	// Path は祝日データのファイル (.csv は内閣府の CSV、.ics は iCalendar、それ以外は holidays.yaml の形式)
+	Path string `yaml:"path"`
+	// Enabled が false の取得元は読み込まない (省略時は読み込む)
+	Enabled *bool `yaml:"enabled"`
+}
+
+// label は取得元の表示名 (name、なければ path)
+func (s holidaySourceYAML) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Path
+}
+
+// enabled は取得元を読み込むかどうかを返す
+func (s holidaySourceYAML) enabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// checkHolidaySources は holiday_sources の各要素が組み込みの取得元かファイルを指しているかを確認する
+func checkHolidaySources(srcs []holidaySourceYAML) error {
+	for i, s := range srcs {
+		if s.Path != "" {
+			continue
+		}
+		switch s.Name {
+		case sourceUpdate, sourceData, sourceEmbedded:
+		case "":
+			return fmt.Errorf("%d 件目に name か path を指定してください", i+1)
+		default:
+			return fmt.Errorf("%d 件目: 組み込みの取得元は %s・%s・%s のどれかです (ファイルなら path を指定してください): %s",
+				i+1, sourceUpdate, sourceData, sourceEmbedded, s.Name)
+		}
+	}
+	return nil
+}
+
+// sourceResult は取得元 1 件を読み込んで合わせた結果
+type sourceResult struct {
+	source   holidaySourceYAML
+	data     holidayData
+	found    bool // 読み込めるデータがあったか (無効にした取得元とキャッシュのない組み込みの取得元は false)
+	used     int  // 合わせた結果に残った定義の数
+	replaced int  // 先の取得元に同じ日の定義があったので使わなかった定義の数
+}
+
+// readHolidaySource は取得元 s のデータを読み込む (キャッシュのない組み込みの取得元は found が false)
+func readHolidaySource(s holidaySourceYAML) (d holidayData, found bool, err error) {
+	switch {
+	case s.Path != "":
+		b, err := os.ReadFile(s.Path)
+		if err != nil {
+			return holidayData{}, false, err
+		}
+		switch strings.ToLower(filepath.Ext(s.Path)) {
+		case ".csv":
+			entries, err := bizday.ParseSyukujitsuCSV(b)
+			if err != nil {
+				return holidayData{}, false, fmt.Errorf("%s: %w", s.Path, err)
+			}
+			return holidayData{entries: entries, source: s.Path}, true, nil
+		case ".ics":
+			entries, err := bizday.ParseICSHolidays(b)
+			if err != nil {
+				return holidayData{}, false, fmt.Errorf("%s: %w", s.Path, err)
+			}
+			return holidayData{entries: entries, source: s.Path}, true, nil
+		}
+		d, err := parseHolidayData(b, s.Path)
+		return d, err == nil, err
+	case s.Name == sourceUpdate:
+		src, path := newestUpdateCache()
+		if path == "" {
+			return holidayData{}, false, nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return holidayData{}, false, err
+		}
+		entries, err := src.parse(b)
+		if err != nil {
+			return holidayData{}, false, fmt.Errorf("%s: %w", path, err)
+		}
+		return holidayData{entries: entries, source: path}, true, nil
+	case s.Name == sourceData:
+		path, err := cachePath("holidays.yaml")
+		if err != nil {
+			return holidayData{}, false, nil
+		}
+		b, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return holidayData{}, false, nil
+		}
+		if err != nil {
+			return holidayData{}, false, err
+		}
+		d, err := parseHolidayData(b, path)
+		return d, err == nil, err
+	default:
+		entries, err := bizday.DefaultHolidays()
+		if err != nil {
+			return holidayData{}, false, fmt.Errorf("埋め込みデータ: %w", err)
+		}
+		return holidayData{entries: entries, source: "埋め込みデータ"}, true, nil
+	}
+}
+
+// sourceConflict は 2 つの取得元の同じ日の定義の食い違い (後の取得元の定義は使わない)
+type sourceConflict struct {
+	calendar string              // calendars の名前 (先頭の holidays なら空)
+	kept     bizday.HolidayEntry // 使った定義
+	dropped  bizday.HolidayEntry // 使わなかった定義
+	keptBy   string              // 使った定義の取得元
+	dropBy   string              // 使わなかった定義の取得元
+}
+
+// workday は祝日と振替出勤日の食い違いかどうか (名前だけの違いなら false)
+func (c sourceConflict) workday() bool {
+	return c.kept.Workday != c.dropped.Workday
+}
+
+// String は食い違いの説明
+func (c sourceConflict) String() string {
+	day := c.kept.Date.Format(time.DateOnly)
+	if c.calendar != "" {
+		day = c.calendar + " の " + day
+	}
+	return fmt.Sprintf("%s は %s で%s、%s で%s (%s を使います)",
+		day, c.keptBy, entryDescription(c.kept), c.dropBy, entryDescription(c.dropped), c.keptBy)
+}
+
+// entryDescription は定義の種類と名前 (「祝日「元日」」「振替出勤日」など)
+func entryDescription(e bizday.HolidayEntry) string {
+	kind := "祝日"
+	if e.Workday {
+		kind = "振替出勤日"
+	}
+	if e.Name == "" {
+		return kind
+	}
+	return kind + "「" + e.Name + "」"
+}
+
+// sourceMerge は mergeHolidaySources の結果
+type sourceMerge struct {
+	data      holidayData      // 合わせた祝日データ
+	results   []sourceResult   // holiday_sources の順の、取得元ごとの結果
+	conflicts []sourceConflict // 取得元の間の食い違い
+}
+
+// takenEntry は先の取得元が定義した日の、最初の定義とその取得元
+type takenEntry struct {
+	entry  bizday.HolidayEntry
+	source string
+}
+
+// mergeHolidaySources は holiday_sources の取得元を先に書いたものほど優先して 1 つの祝日データにする
+// 日付ごとに、その日の定義を持つ最初の取得元の定義だけを使う (後の取得元にある同じ日の祝日・振替出勤日は使わない)
+// 使わなかった定義が祝日と振替出勤日で食い違うか名前が違えば、その食い違いを conflicts に残す
+// ある取得元にだけある日はそのまま残るので、国の祝日に会社の .ics の休業日を足したり、ファイルで一部の日を書き換えたりできる
+// calendars (国・地域ごとの祝日) も名前ごとに同じ規則で合わせる。読み込めるデータが 1 つもなければエラー
+func mergeHolidaySources(srcs []holidaySourceYAML) (sourceMerge, error) {
+	m := sourceMerge{results: make([]sourceResult, len(srcs))}
+	var labels []string
+	taken := map[string]map[string]takenEntry{} // カレンダーの名前 ("" は先頭の holidays) ごとの、先の取得元が定義した日
+	for i, s := range srcs {
+		r := &m.results[i]
+		r.source = s
+		if !s.enabled() {
+			continue
+		}
+		d, found, err := readHolidaySource(s)
+		if err != nil {
+			return sourceMerge{}, fmt.Errorf("%s: %w", s.label(), err)
+		}
+		if !found {
+			continue
+		}
+		r.data, r.found = d, true
+		labels = append(labels, d.source)
+		m.data.entries = m.mergeEntries(r, m.data.entries, d.entries, taken, "")
+		for name, entries := range d.calendars {
+			if m.data.calendars == nil {
+				m.data.calendars = map[string][]bizday.HolidayEntry{}
+			}
+			m.data.calendars[name] = m.mergeEntries(r, m.data.calendars[name], entries, taken, name)
+		}
+	}
+	if len(labels) == 0 {
+		return sourceMerge{}, errors.New("holiday_sources に読み込める祝日データがありません")
+	}
+	m.data.source = strings.Join(labels, " + ")
+	sort.SliceStable(m.conflicts, func(i, j int) bool {
+		a, b := m.conflicts[i], m.conflicts[j]
+		if a.calendar != b.calendar {
+			return a.calendar < b.calendar
+		}
+		return a.kept.Date.Before(b.kept.Date)
+	})
+	return m, nil
+}
+
+// mergeEntries は dst に、taken[name] にまだない日の entries の定義を足し、r の used・replaced を数える
+// 1 つの取得元の中の同じ日の定義 (有効期間の違うものなど) はすべて足す
+func (m *sourceMerge) mergeEntries(r *sourceResult, dst, entries []bizday.HolidayEntry, taken map[string]map[string]takenEntry, name string) []bizday.HolidayEntry {
+	if taken[name] == nil {
+		taken[name] = map[string]takenEntry{}
+	}
+	label := r.source.label()
+	added := map[string]bizday.HolidayEntry{}
+	for _, e := range entries {
+		day := e.Date.Format(time.DateOnly)
+		if t, ok := taken[name][day]; ok {
+			r.replaced++
+			if t.entry.Workday != e.Workday || t.entry.Name != e.Name {
+				m.conflicts = append(m.conflicts, sourceConflict{calendar: name, kept: t.entry, dropped: e, keptBy: t.source, dropBy: label})
+			}
+			continue
+		}
+		dst = append(dst, e)
+		if _, ok := added[day]; !ok {
+			added[day] = e
+		}
+		r.used++
+	}
+	for day, e := range added {
+		taken[name][day] = takenEntry{entry: e, source: label}
+	}
+	return dst
+}
+
+// warnSourceConflicts は取得元の間の食い違いを警告としてログに書く (起動時に読み込むたびに呼ぶ)
+func warnSourceConflicts(conflicts []sourceConflict) {
+	for _, c := range conflicts {
+		slog.Warn("祝日データの取得元が食い違っています", "conflict", c.String())
+	}
+}
+
+// overrideConflicts は設定ファイルの extra_holidays と workdays_override の両方にある日の一覧
+// 同じ日を休業日と営業日の両方に指定しても、どちらになるかは重ねる順で決まるだけなので誤りとして扱う
+func overrideConflicts(c config) []string {
+	holidays := map[string]bool{}
+	for _, e := range c.extra {
+		if !e.Workday {
+			holidays[e.Date.Format(time.DateOnly)] = true
+		}
+	}
+	var days []string
+	for _, e := range c.extra {
+		if day := e.Date.Format(time.DateOnly); e.Workday && holidays[day] {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// runSources は設定ファイルの holiday_sources の取得元ごとの読み込み結果と、合わせた祝日データの件数を表示する
+func runSources(fs *flag.FlagSet) func() error {
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if len(conf.HolidaySources) == 0 {
+			if err := ensureHolidays(); err != nil {
+				return err
+			}
+			fmt.Printf("holiday_sources の指定はありません (使用中の祝日データ: %s)\n", holidaySource)
+			return nil
+		}
+		m, err := mergeHolidaySources(conf.HolidaySources)
+		if err != nil {
+			return dataError(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "優先\t取得元\t状態\t期間\t使用\t上書きされた定義")
+		for i, r := range m.results {
+			state, years := "使用", "-"
+			switch {
+			case !r.source.enabled():
+				state = "無効"
+			case !r.found:
+				state = "データなし"
+			default:
+				if first, last, n := coverage(r.data.entries); n > 0 {
+					years = fmt.Sprintf("%d~%d 年", first, last)
+				}
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%d\n", i+1, r.source.label(), state, years, r.used, r.replaced)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		first, last, n := coverage(m.data.entries)
+		fmt.Printf("合わせた祝日データ: %d~%d 年の祝日 %d 件 (定義 %d 件、calendars %d 件)\n", first, last, n, len(m.data.entries), len(m.data.calendars))
+		if len(m.conflicts) > 0 {
+			fmt.Printf("取得元の食い違い %d 件 (bizday validate で一覧できます)\n", len(m.conflicts))
+		}
+		return nil
+	}
+}
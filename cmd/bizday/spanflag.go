@@ -0,0 +1,27 @@
+package main
+
+import "bizday"
+
+// spanFlag は Span を受け取る flag.Value
+type spanFlag struct {
+	span        bizday.Span
+	defaultUnit string
+}
+
+// newSpanFlag は value の数量と defaultUnit の単位を既定値とする spanFlag を返す
+func newSpanFlag(value float64, defaultUnit string) *spanFlag {
+	return &spanFlag{span: bizday.Span{Value: value, Unit: defaultUnit}, defaultUnit: defaultUnit}
+}
+
+func (f *spanFlag) String() string {
+	return f.span.String()
+}
+
+func (f *spanFlag) Set(s string) error {
+	span, err := bizday.ParseSpan(s, f.defaultUnit)
+	if err != nil {
+		return err
+	}
+	f.span = span
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runSprint は今日を含むスプリントの営業日の経過状況と、次のスプリントの開始日を表示する
+// スプリントの定義は --start・--length か設定ファイルの sprint (start と length)
+func runSprint(fs *flag.FlagSet) func() error {
+	startStr := fs.String("start", conf.Sprint.Start, "最初のスプリントの初日 (例: 2025-04-07)、省略時は設定ファイルの sprint.start")
+	lengthDef := conf.Sprint.Length
+	if lengthDef == "" {
+		lengthDef = "2w"
+	}
+	lengthStr := fs.String("length", lengthDef, "1 スプリントの長さ (例: 14d, 2w)、単位を省略すると日数")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-06-04)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *startStr == "" {
+			return fmt.Errorf("--start か設定ファイルの sprint.start でスプリントの初日を指定してください")
+		}
+		length, err := parseSprintLength(*lengthStr)
+		if err != nil {
+			return err
+		}
+		first, err := parseDateTime(*startStr)
+		if err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+
+		n, start, end := bizday.Sprint(today, first, length)
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		st := cal.PeriodStats(start, end, today)
+		fmt.Printf(tr("スプリント %d (%s ~ %s)\n"), n, formatDate(start), formatDate(end))
+		fmt.Printf(tr("今日はスプリントの %s 営業日目 です (全 %d 日、残り %d 日、%.1f %% 経過)\n"),
+			ordinal(st.Elapsed), st.BusinessDays, st.Remaining, percent(st.Elapsed, st.BusinessDays))
+		next := end.AddDate(0, 0, 1)
+		rolled, err := cal.Roll(next, bizday.RollFollowing)
+		if err != nil {
+			return err
+		}
+		if !rolled.Equal(next) {
+			fmt.Printf(tr("次のスプリントは %s に始まります (%s は休業日)\n"), formatDate(rolled), formatDate(next))
+		} else {
+			fmt.Printf(tr("次のスプリントは %s に始まります\n"), formatDate(next))
+		}
+		return nil
+	}
+}
+
+// parseSprintLength はスプリントの長さ (14d, 2w など、単位を省略すると日数) を日数にする
+func parseSprintLength(s string) (int, error) {
+	span, err := bizday.ParseSpan(s, bizday.SpanDays)
+	if err != nil {
+		return 0, err
+	}
+	days := span.Value
+	switch span.Unit {
+	case bizday.SpanDays:
+	case bizday.SpanWeeks:
+		days *= 7
+	default:
+		return 0, fmt.Errorf("スプリントの長さは日数 (d) か週数 (w) で指定してください: %s", s)
+	}
+	if days < 1 || days != float64(int(days)) {
+		return 0, fmt.Errorf("スプリントの長さには 1 日以上の整数の日数を指定してください: %s", s)
+	}
+	return int(days), nil
+}
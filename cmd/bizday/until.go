@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runUntil は期限の日付 (その日を含む) までの残り営業日数と想定稼働時間を表示する
+// 今日は --include-today を指定したときだけ数える (今月の残り営業日と同じく、既定では今日を除く)
+func runUntil(fs *flag.FlagSet) func() error {
+	includeToday := fs.Bool("include-today", false, "今日も残り営業日に含める")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-12-01)")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	fte := fs.Float64("fte", 1, "稼働率 (例: 週 4 日勤務なら 0.8)、時間に掛け合わせる")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if fs.NArg() != 1 {
+			return fmt.Errorf("期限の日付を 1 つ指定してください (例: bizday until 2025-12-19)")
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *fte <= 0 {
+			return fmt.Errorf("--fte には正の値を指定してください")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		deadline, err := parseDateTime(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		deadline, today = bizday.BeginningOfDay(deadline), bizday.BeginningOfDay(today)
+		if deadline.Before(today) {
+			return fmt.Errorf(tr("期限 %s は過ぎています"), formatDate(deadline))
+		}
+
+		from := today.AddDate(0, 0, 1)
+		if *includeToday {
+			from = today
+		}
+		if err := checkCoverage(cal, today, deadline); err != nil {
+			return err
+		}
+		days := 0
+		if !from.After(deadline) {
+			if days, err = cal.CountBusinessDays(from, deadline); err != nil {
+				return fmt.Errorf(tr("営業日計算中にエラー: %w"), err)
+			}
+		}
+		planned, err := cal.PlannedHours(dayHours(*hoursPerDay).Scale(*fte), from, deadline)
+		if err != nil {
+			return err
+		}
+		hours := planned.Hours()
+
+		fmt.Printf(tr("%s までの残り営業日は %d 日 です\n"), formatDate(deadline), days)
+		fmt.Printf(tr("%s までの残り想定稼働時間は %s 時間 です\n"), formatDate(deadline), formatHours(hours))
+		return nil
+	}
+}
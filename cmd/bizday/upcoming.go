@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// printUpcomingHolidays は n 営業日以内に祝日があれば警告を表示する
+func printUpcomingHolidays(cal *bizday.Calendar, today time.Time, n int) {
+	hs, before := cal.UpcomingHolidays(today, n)
+	for i, h := range hs {
+		label := formatDate(h.Date)
+		if h.Name != "" {
+			label += " " + holidayLabel(h.Name)
+		}
+		if before[i] == 0 {
+			fmt.Printf(tr("注意: 次の営業日より前に祝日があります (%s)\n"), label)
+			continue
+		}
+		fmt.Printf(tr("注意: %d営業日後は祝日です (%s)\n"), before[i], label)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"bizday"
+)
+
+// syukujitsuFile は update で取得した内閣府の祝日 CSV のキャッシュ内のファイル名
+const syukujitsuFile = "syukujitsu.csv"
+
+// googleICSFile は update --source google で取得した Google カレンダーの祝日のキャッシュ内のファイル名
+const googleICSFile = "google-holidays.ics"
+
+// updateSource は update の取得元
+type updateSource struct {
+	url   string                                           // 既定の URL
+	file  string                                           // キャッシュ内のファイル名
+	parse func(data []byte) ([]bizday.HolidayEntry, error) // 取得したデータの読み込み
+}
+
+// updateSources は --source に指定できる取得元
+var updateSources = map[string]updateSource{
+	"cao":    {bizday.SyukujitsuURL, syukujitsuFile, bizday.ParseSyukujitsuCSV},
+	"google": {bizday.GoogleJapanHolidaysURL, googleICSFile, bizday.ParseICSHolidays},
+}
+
+// runUpdate は内閣府の祝日 CSV (syukujitsu.csv)、または Google カレンダーの日本の祝日 (.ics) を取得してキャッシュに保存する
+// キャッシュが --max-age より新しければ取得しない。保存したデータは次回の起動から埋め込みデータの代わりに使われる
+// 取得できなかった場合は、それまでのキャッシュ (なければ埋め込みデータ) がそのまま使われる
+func runUpdate(fs *flag.FlagSet) func() error {
+	source := fs.String("source", "cao", "取得元 (cao: 内閣府の CSV, google: Google カレンダーの日本の祝日)")
+	url := fs.String("url", "", "取得する URL (省略時は --source の既定の URL)")
+	maxAge := fs.Duration("max-age", 30*24*time.Hour, "キャッシュの有効期間、これより新しければ取得しない (例: 168h)")
+	force := fs.Bool("force", false, "キャッシュの有効期間内でも取得し直す")
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		src, ok := updateSources[*source]
+		if !ok {
+			return fmt.Errorf("--source には cao か google を指定してください: %s", *source)
+		}
+		if *url == "" {
+			*url = src.url
+		}
+
+		path, err := cachePath(src.file)
+		if err != nil {
+			return fmt.Errorf("キャッシュの場所を決められません: %w", err)
+		}
+		if fi, err := os.Stat(path); err == nil && !*force {
+			if age := time.Since(fi.ModTime()); age < *maxAge {
+				fmt.Printf(tr("祝日データは最新です (%s に取得、有効期限 %s) → %s\n"),
+					formatDateTime(fi.ModTime()), formatDateTime(fi.ModTime().Add(*maxAge)), path)
+				return nil
+			}
+		}
+
+		first, last, n, err := updateCache(src, *url, path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(tr("祝日データを更新しました: %d 年~%d 年 (%d 件) → %s\n"), first, last, n, path)
+		return nil
+	}
+}
+
+// updateCache は取得元 src のデータを url から取得し、読み込めることを確かめてからキャッシュの path に保存する
+// 返すのは取得したデータの祝日の最初と最後の年と件数。取得・読み込みに失敗したときはキャッシュを変えない
+func updateCache(src updateSource, url, path string) (first, last, n int, err error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	data, err := fetch(client, url)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	entries, err := src.parse(data)
+	if err != nil {
+		return 0, 0, 0, dataError(fmt.Errorf("取得した祝日データを読み込めません: %w", err))
+	}
+	first, last, n = coverage(entries)
+
+	// 取得したままの形式 (CSV は Shift_JIS のまま) で保存し、読み込むときに変換する
+	if err := writeFileAtomic(path, data); err != nil {
+		return 0, 0, 0, fmt.Errorf("祝日データの保存に失敗: %w", err)
+	}
+	return first, last, n, nil
+}
+
+// newestUpdateCache は update で取得したキャッシュのうち最も新しいものの取得元とパスを返す (なければ空のパス)
+func newestUpdateCache() (updateSource, string) {
+	var newest updateSource
+	var newestPath string
+	var newestTime time.Time
+	for _, src := range updateSources {
+		path, err := cachePath(src.file)
+		if err != nil {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil || !fi.ModTime().After(newestTime) {
+			continue
+		}
+		newest, newestPath, newestTime = src, path, fi.ModTime()
+	}
+	return newest, newestPath
+}
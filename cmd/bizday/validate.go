@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"bizday"
+)
+
+// runValidate は祝日データのファイルを検証し、見つかった問題を一覧にする
+// --holidays を省略すると、設定ファイルの holiday_sources の取得元の間と、extra_holidays・workdays_override の食い違いを検証する
+// 誤りがあれば (--strict なら警告だけでも) 終了コード 3 で終了するので、データを更新したときの確認に使える
+func runValidate(fs *flag.FlagSet) func() error {
+	path := fs.String("holidays", "", "検証する祝日データの YAML ファイル (holidays.yaml と同じ形式)、省略時は設定ファイルの祝日データの取得元")
+	strict := fs.Bool("strict", false, "警告 (土日の祝日、データのない年、取得元の間の名前の違い) も誤りとして扱う")
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if *path == "" {
+			return validateSources(*strict)
+		}
+		b, err := os.ReadFile(*path)
+		if err != nil {
+			return dataError(err)
+		}
+		problems, err := bizday.ValidateHolidays(b)
+		if err != nil {
+			return dataError(fmt.Errorf("%s を YAML として読み込めません: %w", *path, err))
+		}
+
+		errs, warnings := 0, 0
+		for _, p := range problems {
+			kind := "エラー"
+			if p.Warning {
+				kind = "警告"
+				warnings++
+			} else {
+				errs++
+			}
+			if p.Line == 0 {
+				fmt.Printf("%s: %s: %s: %s\n", *path, kind, p.Section, p.Message)
+			} else {
+				fmt.Printf("%s:%d: %s: %s: %s\n", *path, p.Line, kind, p.Section, p.Message)
+			}
+		}
+		fmt.Printf("%s: エラー %d 件、警告 %d 件\n", *path, errs, warnings)
+		if errs > 0 || (*strict && warnings > 0) {
+			return dataError(nil)
+		}
+		return nil
+	}
+}
+
+// validateSources は設定ファイルの祝日データの食い違いを一覧にする
+// 取得元の間で祝日と振替出勤日が食い違う日と、extra_holidays・workdays_override の両方にある日はエラー、
+// 取得元の間で名前だけが違う日は警告
+func validateSources(strict bool) error {
+	var conflicts []sourceConflict
+	if len(conf.HolidaySources) > 0 {
+		m, err := mergeHolidaySources(conf.HolidaySources)
+		if err != nil {
+			return dataError(err)
+		}
+		conflicts = m.conflicts
+	}
+	errs, warnings := 0, 0
+	for _, c := range conflicts {
+		kind := "警告"
+		if c.workday() {
+			kind = "エラー"
+			errs++
+		} else {
+			warnings++
+		}
+		fmt.Printf("holiday_sources: %s: %s\n", kind, c)
+	}
+	for _, day := range overrideConflicts(conf) {
+		errs++
+		fmt.Printf("extra_holidays: エラー: %s は workdays_override にもあります\n", day)
+	}
+	fmt.Printf("設定ファイルの祝日データ: エラー %d 件、警告 %d 件\n", errs, warnings)
+	if errs > 0 || (strict && warnings > 0) {
+		return dataError(nil)
+	}
+	return nil
+}
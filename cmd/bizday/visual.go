@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"bizday"
+)
+
+// ANSI のエスケープシーケンス (色に対応した端末でだけ使う)
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+)
+
+// useColor は標準出力が端末で、NO_COLOR が設定されておらず TERM が dumb でなければ true を返す
+func useColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// printMonthGrid は today を含む月のカレンダーを日曜始まりの表で表示する
+// 日付の後ろの印は - が休業日 (定休日・祝日)、x が経過した営業日、* が今日で、印のない日が残りの営業日
+func printMonthGrid(cal *bizday.Calendar, today time.Time, color bool) {
+	start, end := bizday.BeginningOfMonth(today), bizday.EndOfMonth(today)
+	fmt.Printf(tr("%d年%d月\n"), start.Year(), start.Month())
+	for w := time.Sunday; w <= time.Saturday; w++ {
+		label := weekdayLabel(w) // 曜日の漢字は 2 桁分の幅
+		if lang == "en" {
+			label = label[:2] // Su, Mo のように 2 文字にして幅をそろえる
+		}
+		fmt.Printf(" %s ", label)
+	}
+	fmt.Println()
+
+	fmt.Print(strings.Repeat("    ", int(start.Weekday())))
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		mark, style := " ", ""
+		switch {
+		case isSameDate(d, today):
+			mark, style = "*", ansiReverse
+		case !cal.IsBusinessDay(d):
+			mark, style = "-", ansiRed
+		case d.Before(today):
+			mark, style = "x", ansiDim
+		}
+		cell := fmt.Sprintf("%2d%s", d.Day(), mark)
+		if color && style != "" {
+			cell = style + cell + ansiReset
+		}
+		fmt.Print(cell + " ")
+		if d.Weekday() == time.Saturday || d.Equal(bizday.BeginningOfDay(end)) {
+			fmt.Println()
+		}
+	}
+	fmt.Println(tr("(-: 休業日、x: 経過した営業日、*: 今日)"))
+}
+
+// progressBar は percent (0~100) を幅 width の棒グラフにする
+func progressBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	filled = max(0, min(width, filled))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+// isSameDate は a と b が同じ日付かどうかを判定
+func isSameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
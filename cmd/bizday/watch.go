@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"bizday"
+)
+
+// watchPollInterval は watch が日付の変わり目を確かめる最大の間隔
+// スリープからの復帰や時計の変更で次の 0:00 のタイマーがずれても、この間隔のうちに追いつく
+const watchPollInterval = time.Minute
+
+// runWatch は常駐して、日付が変わるたび (--tz か設定ファイルの timezone の 0:00) と祝日データを読み込み直すたびに
+// 今月のサマリを出力する。出力先は標準出力、--status-file のファイル (毎回書き換える)、--webhook の Incoming Webhook のいずれか
+// ステータスバーやキオスク端末のダッシュボード向けで、SIGHUP で祝日データを読み込み直し、SIGINT/SIGTERM で終了する
+// SIGUSR1 を受け取ると、serve と同じく今日のサマリと祝日データの出どころをログに書く
+func runWatch(fs *flag.FlagSet) func() error {
+	format := fs.String("format", "line", "出力形式 (line: 1 行の要約, text: 通知と同じ本文, json: summary --json と同じ)")
+	statusFile := fs.String("status-file", "", "標準出力の代わりに、このファイルを毎回書き換える")
+	webhook := fs.String("webhook", "", "標準出力の代わりに、この Incoming Webhook に通知と同じ本文を投稿する ($"+webhookEnv+" でも指定可)")
+	businessOnly := fs.Bool("business-days-only", false, "--webhook のとき、休業日には投稿しない")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		if *format != "line" && *format != "text" && *format != "json" {
+			return fmt.Errorf("--format には line, text, json のいずれかを指定してください: %s", *format)
+		}
+		if *webhook == "" {
+			*webhook = os.Getenv(webhookEnv)
+		}
+		if *statusFile != "" && *webhook != "" {
+			return fmt.Errorf("--status-file と --webhook は同時に指定できません")
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		emit := func(now time.Time) error {
+			// --coverage error なら、祝日データのない月に入ったところで止める
+			if err := checkCoverage(cal, bizday.BeginningOfMonth(now), bizday.EndOfMonth(now)); err != nil {
+				return err
+			}
+			if *webhook != "" {
+				if *businessOnly && !cal.IsBusinessDay(now) {
+					return nil
+				}
+				body, err := json.Marshal(webhookMessage{Text: notifyText(cal, calFlags.country, now)})
+				if err != nil {
+					return err
+				}
+				// 一時的な送信の失敗では止まらず、次の日付の変わり目に再び投稿する
+				if err := postWebhook(client, *webhook, body); err != nil {
+					slog.Error("通知の送信に失敗しました", "err", err)
+				}
+				return nil
+			}
+			out, err := watchOutput(cal, calFlags.country, now, dayHours(*hoursPerDay), *format)
+			if err != nil {
+				return err
+			}
+			if *statusFile != "" {
+				return writeFileAtomic(*statusFile, out)
+			}
+			_, err = os.Stdout.Write(out)
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		reload := make(chan os.Signal, 1)
+		if sigs := reloadSignals(); len(sigs) > 0 {
+			signal.Notify(reload, sigs...)
+			defer signal.Stop(reload)
+		}
+		status := make(chan os.Signal, 1)
+		if sigs := statusSignals(); len(sigs) > 0 {
+			signal.Notify(status, sigs...)
+			defer signal.Stop(status)
+		}
+
+		now := time.Now()
+		if err := emit(now); err != nil {
+			return err
+		}
+		day := bizday.BeginningOfDay(now)
+		for {
+			wait := min(time.Until(day.AddDate(0, 0, 1)), watchPollInterval)
+			timer := time.NewTimer(max(wait, 0))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-status:
+				timer.Stop()
+				logStatus(cal, calFlags.country, time.Now(), calFlags.source())
+				continue
+			case <-reload:
+				timer.Stop()
+				restore, err := reloadHolidays()
+				if err == nil {
+					var n *bizday.Calendar
+					if n, err = calFlags.resolve(); err != nil {
+						restore()
+					} else {
+						cal = n
+					}
+				}
+				if err != nil {
+					slog.Error("再読み込みに失敗しました", "err", err)
+					continue
+				}
+				slog.Info("祝日データを読み込み直しました", "source", holidaySource)
+			case <-timer.C:
+				if bizday.BeginningOfDay(time.Now()).Equal(day) {
+					continue
+				}
+			}
+			now = time.Now()
+			day = bizday.BeginningOfDay(now)
+			if err := emit(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchOutput は watch が標準出力や --status-file に書く内容を format の形式で組み立てる
+func watchOutput(cal *bizday.Calendar, name string, now time.Time, h bizday.DayHours, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		var b bytes.Buffer
+		if err := encodeJSON(&b, newSummaryJSON(cal, name, now, h, 1)); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case "text":
+		return []byte(notifyText(cal, name, now) + "\n"), nil
+	}
+	st := cal.MonthStats(now)
+	line := fmt.Sprintf("%s %s/%d (残り %d 日)", formatDate(now), indexLabel(st.Index), st.BusinessDays, st.Remaining)
+	if !cal.IsBusinessDay(now) {
+		line = fmt.Sprintf("%s 休業日 %d/%d (残り %d 日)", formatDate(now), st.Index, st.BusinessDays, st.Remaining)
+	}
+	return []byte(line + "\n"), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"bizday"
+)
+
+// runWeek は今週 (--week があればその ISO 週) の営業日数と、経過・残りの営業日数を表示する
+func runWeek(fs *flag.FlagSet) func() error {
+	week := fs.String("week", "", "対象の ISO 週 (例: 2025-W23)、省略時は今週")
+	date := fs.String("date", "", "この日を今日として数える (例: 2025-06-04)")
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		today := time.Now()
+		if *date != "" {
+			if today, err = parseDateTime(*date); err != nil {
+				return err
+			}
+		}
+		start, end := bizday.ISOWeek(today)
+		if *week != "" {
+			if start, err = bizday.ParseISOWeek(*week, time.Local); err != nil {
+				return err
+			}
+			end = start.AddDate(0, 0, 6)
+		}
+
+		if err := checkCoverage(cal, start, end); err != nil {
+			return err
+		}
+		// 過ぎた週はすべて経過、先の週はすべて残りとして数える
+		st := cal.PeriodStats(start, end, today)
+		y, w := start.ISOWeek()
+		fmt.Printf("%d-W%02d (%s ~ %s)\n", y, w, formatDate(start), formatDate(end))
+		fmt.Printf(tr("営業日は %d 日、経過 %d 日、残り %d 日 です\n"), st.BusinessDays, st.Elapsed, st.Remaining)
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bizday"
+)
+
+// yearJSON は year --format json の出力
+type yearJSON struct {
+	SchemaVersion int              `json:"schema_version"`
+	Calendar      string           `json:"calendar"`
+	Year          int              `json:"year"`
+	Fiscal        bool             `json:"fiscal"`
+	Months        []yearPeriodJSON `json:"months"`
+	Quarters      []yearPeriodJSON `json:"quarters"`
+	Halves        []yearPeriodJSON `json:"halves"`
+	Total         yearPeriodJSON   `json:"total"`
+}
+
+// yearPeriodJSON は月・四半期・半期・年の 1 行
+type yearPeriodJSON struct {
+	Label        string  `json:"label"`
+	From         string  `json:"from"`
+	To           string  `json:"to"`
+	BusinessDays int     `json:"business_days"`
+	Holidays     int     `json:"holidays"`
+	Hours        float64 `json:"hours"`
+}
+
+// runYear は 1 年分の月ごとの営業日数の表と、四半期・半期・年の合計を表示する
+// --fiscal を指定すると、会計年度 (--start-month か設定ファイルの fiscal_year_start の月から 12 か月) で数える
+func runYear(fs *flag.FlagSet) func() error {
+	def := 4
+	if conf.FiscalYearStart != 0 {
+		def = conf.FiscalYearStart
+	}
+	fiscal := fs.Bool("fiscal", false, "暦年ではなく会計年度で数える (2025 なら 2025 年度)")
+	startMonth := fs.Int("start-month", def, "--fiscal のときの会計年度の始まりの月 (1~12)")
+	format := fs.String("format", "text", "出力形式 (text, json, csv)")
+	hoursPerDay := addHoursPerDayFlag(fs)
+	calFlags := addCalendarFlags(fs)
+	addDateStyleFlag(fs)
+	return func() error {
+		var yearArg string
+		if fs.NArg() > 0 {
+			// 年の後ろのフラグ (bizday year 2025 --fiscal) も読む
+			yearArg = fs.Arg(0)
+			fs.Parse(fs.Args()[1:])
+			if fs.NArg() > 0 {
+				return fmt.Errorf("年は 1 つだけ指定してください (例: bizday year --fiscal 2025): %s", strings.Join(fs.Args(), " "))
+			}
+		}
+		if err := checkDateStyle(); err != nil {
+			return err
+		}
+		switch *format {
+		case "text", "json", "csv":
+		default:
+			return fmt.Errorf("--format には text, json, csv のいずれかを指定してください: %s", *format)
+		}
+		if *startMonth < 1 || *startMonth > 12 {
+			return fmt.Errorf("--start-month には 1~12 を指定してください")
+		}
+		year := time.Now().Year()
+		if yearArg != "" {
+			y, err := strconv.Atoi(yearArg)
+			if err != nil {
+				return fmt.Errorf("年は 2025 のように数字で指定してください: %s", yearArg)
+			}
+			year = y
+		}
+		cal, err := calFlags.resolve()
+		if err != nil {
+			return err
+		}
+
+		first := time.January
+		if *fiscal {
+			first = time.Month(*startMonth)
+		}
+		start := time.Date(year, first, 1, 0, 0, 0, 0, time.Local)
+		if err := checkCoverage(cal, start, start.AddDate(1, 0, -1)); err != nil {
+			return err
+		}
+		h := dayHours(*hoursPerDay)
+		period := func(label string, from time.Time, months int) yearPeriodJSON {
+			to := from.AddDate(0, months, -1)
+			p := yearPeriodJSON{Label: label, From: dateString(from), To: dateString(to)}
+			p.BusinessDays, _ = cal.CountBusinessDays(from, to)
+			p.Holidays = len(cal.HolidaysBetween(from, to))
+			hours, _ := cal.PlannedHours(h, from, to) // 1 年以内の期間なので桁あふれしない
+			p.Hours = roundHours(hours.Hours())
+			return p
+		}
+
+		out := yearJSON{SchemaVersion: bizday.SchemaVersion, Calendar: calFlags.country, Year: year, Fiscal: *fiscal}
+		for i := 0; i < 12; i++ {
+			m := start.AddDate(0, i, 0)
+			out.Months = append(out.Months, period(m.Format("2006-01"), m, 1))
+		}
+		for q := 0; q < 4; q++ {
+			out.Quarters = append(out.Quarters, period(fmt.Sprintf("Q%d", q+1), start.AddDate(0, 3*q, 0), 3))
+		}
+		for half := 0; half < 2; half++ {
+			out.Halves = append(out.Halves, period(fmt.Sprintf("H%d", half+1), start.AddDate(0, 6*half, 0), 6))
+		}
+		out.Total = period(strconv.Itoa(year), start, 12)
+
+		rows := append(append(append(append([]yearPeriodJSON{}, out.Months...), out.Quarters...), out.Halves...), out.Total)
+		switch *format {
+		case "json":
+			return encodeJSON(os.Stdout, out)
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"period", "from", "to", "business_days", "holidays", "hours"})
+			for _, r := range rows {
+				w.Write([]string{r.Label, r.From, r.To, strconv.Itoa(r.BusinessDays), strconv.Itoa(r.Holidays), formatHours(r.Hours)})
+			}
+			w.Flush()
+			return w.Error()
+		}
+
+		if *fiscal {
+			fmt.Printf(tr("%d年度 (%s ~ %s) の営業日\n"), year, formatDate(start), formatDate(start.AddDate(1, 0, -1)))
+		} else {
+			fmt.Printf(tr("%d年の営業日\n"), year)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, tr("期間\t営業日\t祝日\t想定稼働時間\t"))
+		for i, r := range rows {
+			if i == len(out.Months) || i == len(out.Months)+len(out.Quarters) || i == len(rows)-1 {
+				fmt.Fprintln(w, "\t\t\t\t")
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t\n", r.Label, r.BusinessDays, r.Holidays, formatHours(r.Hours))
+		}
+		return w.Flush()
+	}
+}
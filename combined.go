@@ -0,0 +1,116 @@
+package bizday
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// NewCombinedCalendar は cals のすべてで営業日の日だけを営業日とする Calendar (CombinedCalendar) を作る
+// 国をまたぐ決済の受渡日の計算などに使う
+// 定休日は各カレンダーの定休日を合わせたもの、祝日は各カレンダーの祝日を合わせたもの (同じ日は名前を " / " でつなぐ) になる
+// 振替出勤日は、いずれかのカレンダーの振替出勤日のうち、すべてのカレンダーで営業日の日
+// 営業日かどうかは各カレンダーの IsBusinessDay で判定するので、WithCustomRule で上書きした分類もそのまま効く
+// 営業時間は先頭のカレンダーのものを使う
+func NewCombinedCalendar(cals ...*Calendar) *Calendar {
+	if len(cals) == 1 {
+		return cals[0]
+	}
+	members := append([]*Calendar{}, cals...)
+	c := NewRuleCalendar(func(year int) []Holiday {
+		var hs []Holiday
+		names := map[int32][]string{}
+		for _, m := range members {
+			for _, h := range m.HolidaysBetween(date(year, time.January, 1), date(year, time.December, 31)) {
+				day := epochDay(h.Date)
+				if _, ok := names[day]; !ok {
+					hs = append(hs, h)
+				}
+				if h.Name != "" && !slices.Contains(names[day], h.Name) {
+					names[day] = append(names[day], h.Name)
+				}
+			}
+		}
+		for i, h := range hs {
+			hs[i].Name = strings.Join(names[epochDay(h.Date)], " / ")
+		}
+		return hs
+	})
+
+	weekend := []time.Weekday{}
+	seen := map[time.Weekday]bool{}
+	for _, m := range members {
+		for w := time.Sunday; w <= time.Saturday; w++ {
+			if !seen[w] && m.isWeekendDay(w) {
+				seen[w] = true
+				weekend = append(weekend, w)
+			}
+		}
+	}
+	c.Weekend = weekend
+
+	c.workdays = daySet{}
+	for _, m := range members {
+		for day := range m.workdays {
+			y, mo, d := dayFromEpoch(day)
+			if allBusinessDays(members, time.Date(y, mo, d, 0, 0, 0, 0, time.Local)) {
+				c.workdays[day] = struct{}{}
+			}
+		}
+	}
+	c.Hours = members[0].Hours
+	c.members = members
+	return c
+}
+
+// isWeekendDay は曜日 w が c の定休日かどうかを判定
+func (c *Calendar) isWeekendDay(w time.Weekday) bool {
+	if c.Weekend == nil {
+		return w == time.Saturday || w == time.Sunday
+	}
+	return slices.Contains(c.Weekend, w)
+}
+
+// combinedBusinessDay は組み合わせたカレンダー c で t (epochDay は day) が営業日かどうかを判定する
+// c 自身に重ねた振替出勤日・休業日 (WithExtra・WithClosureRules) を先に見て、残りは元のカレンダーの IsBusinessDay に任せる
+func (c *Calendar) combinedBusinessDay(day int32, t time.Time) bool {
+	if c.workdays.contains(day) {
+		return true
+	}
+	if c.holidays.contains(day) {
+		return false
+	}
+	if _, ok := c.closureRuleOn(t); ok {
+		return false
+	}
+	return allBusinessDays(c.members, t)
+}
+
+// memberClass は組み合わせたカレンダー c で、定休日・祝日・振替出勤日から決まる分類と営業日かどうかが食い違う日の分類を返す
+// 元のカレンダーの WithCustomRule で分類を上書きした日がこれに当たり、休業日にしたカレンダーがあればその分類、なければ ClassWorkday になる
+// 組み合わせたカレンダーでないか、食い違わなければ false を返す
+func (c *Calendar) memberClass(t time.Time) (DayClass, bool) {
+	if c.members == nil {
+		return "", false
+	}
+	business := c.IsWorkday(t) || (!c.IsWeekend(t) && !c.IsHoliday(t))
+	if business == c.IsBusinessDay(t) {
+		return "", false
+	}
+	for _, m := range c.members {
+		if class, _ := m.Classify(t); class == ClassWeekend || class == ClassHoliday {
+			return class, true
+		}
+	}
+	return ClassWorkday, true
+}
+
+// allBusinessDays は d が cals のすべてで営業日かどうかを判定
+func allBusinessDays(cals []*Calendar, d time.Time) bool {
+	for _, c := range cals {
+		if !c.IsBusinessDay(d) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package bizday
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UncoveredYears は start~end の年のうち、祝日の分からない年を昇順で返す
+// 祝日一覧だけで数えるカレンダーは、一覧に祝日が 1 件もない年の祝日を数えられない (その年の祝日は平日として扱われる)
+// 生成規則 (Generate) を持つカレンダーと、祝日を 1 件も持たない定休日だけのカレンダーはどの年も分かっているものとする
+// WithExtra で重ねた会社独自の休業日だけがある年は分からない年に含める
+func (c *Calendar) UncoveredYears(start, end time.Time) []int {
+	if c.members != nil {
+		var years []int
+		for _, m := range c.members {
+			for _, y := range m.UncoveredYears(start, end) {
+				if !slices.Contains(years, y) {
+					years = append(years, y)
+				}
+			}
+		}
+		slices.Sort(years)
+		return years
+	}
+	if c.Generate != nil {
+		return nil
+	}
+	covered := c.coveredYears()
+	if len(covered) == 0 {
+		return nil
+	}
+	var years []int
+	for y := start.Year(); y <= end.Year(); y++ {
+		if !covered[y] {
+			years = append(years, y)
+		}
+	}
+	return years
+}
+
+// coveredYears は祝日一覧に祝日 (WithExtra で重ねた会社独自の休業日を除く) が 1 件以上ある年を返す
+func (c *Calendar) coveredYears() map[int]bool {
+	covered := map[int]bool{}
+	for day := range c.holidays {
+		if _, closure := c.closures[day]; !closure {
+			y, _, _ := dayFromEpoch(day)
+			covered[y] = true
+		}
+	}
+	return covered
+}
+
+// CoveragePolicy は祝日データのない年 (UncoveredYears) を数えるときの扱い
+// 扱いを選ぶのは呼び出し側で、CheckCoverage と WithJapaneseFallback を組み合わせて使う
+type CoveragePolicy string
+
+const (
+	CoverageWarn     CoveragePolicy = "warn"     // その年の祝日を平日として数え、警告だけする
+	CoverageError    CoveragePolicy = "error"    // CheckCoverage のエラーで止める
+	CoverageGenerate CoveragePolicy = "generate" // WithJapaneseFallback で日本の祝日の規則で算出する
+)
+
+// UncoveredError は CheckCoverage が返す、祝日の分からない年があることを表すエラー
+type UncoveredError struct {
+	Years []int
+}
+
+func (e *UncoveredError) Error() string {
+	s := make([]string, len(e.Years))
+	for i, y := range e.Years {
+		s[i] = strconv.Itoa(y)
+	}
+	return fmt.Sprintf("%s 年の祝日データがありません", strings.Join(s, ", "))
+}
+
+// CheckCoverage は start~end に祝日の分からない年 (UncoveredYears) があれば *UncoveredError を返す
+// そのまま数えると、その年の祝日は平日として扱われる。黙って数えさせたくない場合に、数える前に呼ぶ
+func (c *Calendar) CheckCoverage(start, end time.Time) error {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	if years := c.UncoveredYears(start, end); len(years) > 0 {
+		return &UncoveredError{Years: years}
+	}
+	return nil
+}
+
+// WithJapaneseFallback は祝日一覧に祝日が 1 件もない年の祝日を、日本の祝日の規則 (JapaneseHolidays と同じ) で算出する Calendar を返す
+// 一覧に祝日がある年は一覧をそのまま使う (NewJapanCalendarAsOf と同じ扱い)。生成規則を持つカレンダーと、祝日を 1 件も持たないカレンダーはそのまま返す
+// NewCombinedCalendar で組み合わせたカレンダーに使うと、組み合わせた元のカレンダーそれぞれに使って組み合わせ直す
+// (組み合わせた後に重ねた休業日などは引き継がないので、組み合わせる前に使う)
+func (c *Calendar) WithJapaneseFallback() *Calendar {
+	if c.members != nil {
+		members := make([]*Calendar, len(c.members))
+		for i, m := range c.members {
+			members[i] = m.WithJapaneseFallback()
+		}
+		return NewCombinedCalendar(members...)
+	}
+	covered := c.coveredYears()
+	if c.Generate != nil || len(covered) == 0 {
+		return c
+	}
+	n := *c
+	n.bitmaps = nil
+	n.Generate = func(year int) []Holiday {
+		if covered[year] {
+			return nil
+		}
+		return japaneseHolidays(year)
+	}
+	n.genCache = &yearCache{}
+	return &n
+}
@@ -0,0 +1,261 @@
+package bizday
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule は 5 フィールド (分 時 日 月 曜日) の cron 式
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar/dowStar は日・曜日が "*" かどうか (両方指定されていればどちらかに一致すれば実行)
+	domStar, dowStar bool
+	// business は日のフィールドに営業日の修飾子 (B, B1, B-1 など) が書かれていれば真
+	// businessAny は B (すべての営業日)、businessNth は月の第 n 営業日 (負なら末尾から数える) の一覧
+	business    bool
+	businessAny bool
+	businessNth []int
+}
+
+// CronSearchDays は次回実行を探す最大日数
+const CronSearchDays = 366 * 5
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron は "30 9 * * 1-5" のような 5 フィールドの cron 式をパースする
+// 日のフィールドには営業日の修飾子も書ける
+//   - B: 営業日のみ ("0 9 B * *" は毎営業日の 9:00)
+//   - Bn: 月の第 n 営業日 ("0 9 B1 * *" は毎月最初の営業日の 9:00)
+//   - B-n: 月末から数えて n 番目の営業日 ("0 18 B-1 * *" は毎月最終営業日の 18:00)
+//
+// "B1,B-1" のように並べられる。修飾子を使うときは曜日のフィールドは「かつ」の条件になる ("0 9 B * mon" は営業日の月曜)
+// 営業日は Calendar の NextCron・CronTimes に渡したカレンダーで判定する (Next では土日を除いた平日)
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron 式は 5 フィールド (分 時 日 月 曜日) で指定してください: %s", expr)
+	}
+
+	s := &CronSchedule{domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(strings.ToUpper(fields[2]), "B") {
+		if err := s.parseBusinessField(fields[2]); err != nil {
+			return nil, err
+		}
+	} else if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, err
+	}
+	// 曜日の 7 は日曜 (0) と同じ
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseCronField は "1-5"・"*/15"・"mon,wed" などの 1 フィールドをビット集合にする
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if r, s, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("cron 式の間隔が不正です: %s", part)
+			}
+			rng, step = r, n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			l, h, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = cronValue(l, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = cronValue(h, names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("cron 式の値が範囲外です: %s (%d-%d)", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseBusinessField は日のフィールドの営業日の修飾子 ("B", "B1", "B-1,B-2" など) を読む
+func (s *CronSchedule) parseBusinessField(field string) error {
+	s.business = true
+	for _, part := range strings.Split(field, ",") {
+		rest, ok := strings.CutPrefix(strings.ToUpper(part), "B")
+		if !ok {
+			return fmt.Errorf("cron 式の日に営業日の修飾子とほかの値は混ぜられません: %s", field)
+		}
+		if rest == "" {
+			s.businessAny = true
+			continue
+		}
+		n, err := strconv.Atoi(rest)
+		if err != nil || n == 0 || n < -23 || n > 23 {
+			return fmt.Errorf("cron 式の営業日の指定が不正です: %s (B、B1~B23、B-1~B-23)", part)
+		}
+		s.businessNth = append(s.businessNth, n)
+	}
+	return nil
+}
+
+func cronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("cron 式の値が不正です: %s", s)
+	}
+	return v, nil
+}
+
+// matchesDay は d の日付が日・月・曜日の条件に一致するかを判定 (営業日の修飾子は cal で判定する)
+func (s *CronSchedule) matchesDay(d time.Time, cal *Calendar) bool {
+	if s.month&(1<<uint(d.Month())) == 0 {
+		return false
+	}
+	if s.business {
+		return s.dow&(1<<uint(d.Weekday())) != 0 && s.matchesBusinessDay(d, cal)
+	}
+	domOK := s.dom&(1<<uint(d.Day())) != 0
+	dowOK := s.dow&(1<<uint(d.Weekday())) != 0
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowOK
+	case s.dowStar:
+		return domOK
+	}
+	return domOK || dowOK
+}
+
+// matchesBusinessDay は d が営業日の修飾子 (B, Bn, B-n) のいずれかに一致するかを判定
+func (s *CronSchedule) matchesBusinessDay(d time.Time, cal *Calendar) bool {
+	if !cal.IsBusinessDay(d) {
+		return false
+	}
+	if s.businessAny {
+		return true
+	}
+	first := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+	last := first.AddDate(0, 1, -1)
+	for _, n := range s.businessNth {
+		var k int
+		if n > 0 {
+			k, _ = cal.CountBusinessDays(first, d)
+		} else {
+			k, _ = cal.CountBusinessDays(d, last)
+			k = -k
+		}
+		if k == n {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayCalendar は Next で営業日の修飾子を判定するための、土日だけを休みにするカレンダー
+var weekdayCalendar = &Calendar{Hours: DefaultWorkHours}
+
+// Next は t より後で最初に実行される日時を返す (見つからなければ ok は false)
+// 営業日の修飾子は土日を除いた平日で判定する。祝日も除くには Calendar の NextCron を使う
+func (s *CronSchedule) Next(t time.Time) (time.Time, bool) {
+	return s.next(t, weekdayCalendar, nil)
+}
+
+// next は t より後の実行日時のうち、accept が nil でなければそれを満たす日の最初のものを返す
+func (s *CronSchedule) next(t time.Time, cal *Calendar, accept func(time.Time) bool) (time.Time, bool) {
+	day := BeginningOfDay(t)
+	for i := 0; i < CronSearchDays; i, day = i+1, day.AddDate(0, 0, 1) {
+		if !s.matchesDay(day, cal) || (accept != nil && !accept(day)) {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if s.hour&(1<<h) == 0 {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if s.minute&(1<<m) == 0 {
+					continue
+				}
+				c := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+				if c.After(t) {
+					return c, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextBusinessFiring は t より後で、営業日に当たる最初の実行日時を返す
+func (c *Calendar) NextBusinessFiring(s *CronSchedule, t time.Time) (time.Time, bool) {
+	return s.next(t, c, c.IsBusinessDay)
+}
+
+// NextCron は t より後で最初に実行される日時を、営業日の修飾子 (B, B1, B-1 など) を c の営業日で判定して返す
+// 修飾子のない cron 式では Next と同じ
+func (c *Calendar) NextCron(s *CronSchedule, t time.Time) (time.Time, bool) {
+	return s.next(t, c, nil)
+}
+
+// CronTimes は t より後の実行日時を最大 n 件、NextCron と同じく c の営業日で判定して返す
+func (c *Calendar) CronTimes(s *CronSchedule, t time.Time, n int) []time.Time {
+	var ts []time.Time
+	for len(ts) < n {
+		next, ok := c.NextCron(s, t)
+		if !ok {
+			break
+		}
+		ts = append(ts, next)
+		t = next
+	}
+	return ts
+}
+
+// ShiftToBusinessDay は営業日でない日の実行を、同じ時刻のまま次の営業日へずらす
+// CronSearchDays 日以内に営業日がなければ ok は false
+func (c *Calendar) ShiftToBusinessDay(t time.Time) (time.Time, bool) {
+	for i := 0; i < CronSearchDays; i, t = i+1, t.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,132 @@
+package bizday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"* * * foo *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) がエラーにならない", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"30 9 * * 1-5", time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC), time.Date(2025, 5, 5, 9, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 5, 2, 10, 1, 0, 0, time.UTC), time.Date(2025, 5, 2, 10, 15, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// 日と曜日の両方を指定するとどちらかに一致すれば実行する
+		{"0 12 15 * sun", time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 11, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 11, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		got, ok := s.Next(tt.from)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("%q: Next(%s) = %s, want %s", tt.expr, tt.from.Format(time.RFC3339), got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestNextBusinessFiring(t *testing.T) {
+	cal := mustJapan(t)
+	s, err := ParseCron("30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 5/3~5/6 は連休なので次の営業日の実行は 5/7
+	got, ok := cal.NextBusinessFiring(s, time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 5, 7, 9, 30, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("NextBusinessFiring = %s, want %s", got, want)
+	}
+	if got, ok := cal.ShiftToBusinessDay(time.Date(2025, 5, 3, 9, 30, 0, 0, time.UTC)); !ok || !got.Equal(time.Date(2025, 5, 7, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("ShiftToBusinessDay = %s, %v", got, ok)
+	}
+	closed := cal.WithWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	if got, ok := closed.ShiftToBusinessDay(time.Date(2025, 5, 3, 9, 30, 0, 0, time.UTC)); ok {
+		t.Errorf("営業日のないカレンダーの ShiftToBusinessDay = %s, want ok = false", got)
+	}
+}
+
+func TestCronBusinessModifiers(t *testing.T) {
+	cal := mustJapan(t)
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want []time.Time
+	}{
+		// 5/1 は木曜で営業日、6/1 は日曜なので 6/2
+		{"0 9 B1 * *", []time.Time{
+			time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+		}},
+		// 5/31 は土曜なので最終営業日は 5/30
+		{"0 18 B-1 * *", []time.Time{
+			time.Date(2025, 4, 30, 18, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 30, 18, 0, 0, 0, time.UTC),
+			time.Date(2025, 6, 30, 18, 0, 0, 0, time.UTC),
+		}},
+		// 5/2 の次の営業日は連休明けの 5/7
+		{"30 9 B 5 *", []time.Time{
+			time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC),
+			time.Date(2025, 5, 2, 9, 30, 0, 0, time.UTC),
+			time.Date(2025, 5, 7, 9, 30, 0, 0, time.UTC),
+		}},
+		// 曜日は「かつ」の条件: 5 月の営業日の月曜 (5/5 はこどもの日)
+		{"0 10 B 5 mon", []time.Time{
+			time.Date(2025, 5, 12, 10, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 19, 10, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 26, 10, 0, 0, 0, time.UTC),
+		}},
+		{"0 9 B2,b-2 5 *", []time.Time{
+			time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, 5, 7, 9, 0, 0, 0, time.UTC),
+		}},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		got := cal.CronTimes(s, from, len(tt.want))
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: CronTimes = %v, want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%q: [%d] = %s, want %s", tt.expr, i, got[i].Format(time.RFC3339), tt.want[i].Format(time.RFC3339))
+			}
+		}
+	}
+
+	// Next では祝日を数えず、平日だけで判定する
+	s, _ := ParseCron("30 9 B * *")
+	if got, _ := s.Next(time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2025, 5, 5, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("Next = %s, want 2025-05-05 09:30", got.Format(time.RFC3339))
+	}
+	for _, expr := range []string{"0 9 B0 * *", "0 9 B1,15 * *", "0 9 Bx * *", "0 9 B24 * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) がエラーにならない", expr)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package bizday
+
+import "time"
+
+// WithCustomRule は日の分類を呼び出し側の関数 rule で上書きする Calendar を返す
+// 「今朝決まった台風による臨時休業」のように、データファイルを書き換えずにプログラムから特定の日の扱いを変えるのに使う
+// rule は定休日・祝日・振替出勤日の判定より先に評価し、ClassBusiness・ClassWorkday を返した日は営業日、
+// ClassWeekend・ClassHoliday を返した日は休業日になる。空文字列 (それ以外の値も) を返した日は通常どおり判定する
+// 複数重ねたときは重ねた順に評価し、最初に分類を返したものを使う
+// WithBitmapCache のカレンダーは結果を年ごとに覚えるので、rule は同じ日に同じ分類を返すこと
+func (c *Calendar) WithCustomRule(rule func(t time.Time) DayClass) *Calendar {
+	n := *c
+	n.bitmaps = nil
+	n.customRules = append(append([]func(time.Time) DayClass{}, c.customRules...), rule)
+	return &n
+}
+
+// customClass は WithCustomRule の関数が t の日付に返した分類を返す (どれも分類を返さなければ false)
+func (c *Calendar) customClass(t time.Time) (DayClass, bool) {
+	for _, rule := range c.customRules {
+		switch class := rule(t); class {
+		case ClassBusiness, ClassWorkday, ClassWeekend, ClassHoliday:
+			return class, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,21 @@
+// Package jp は日本の祝日データ (holidays.yaml) を埋め込み、bizday に "jp" として登録する
+//
+//	import _ "bizday/data/jp"
+//
+//	cal, err := bizday.Load("jp")
+package jp
+
+import (
+	_ "embed"
+
+	"bizday"
+)
+
+// YAML は埋め込みの祝日データ (holidays.yaml の形式)
+//
+//go:embed holidays.yaml
+var YAML []byte
+
+func init() {
+	bizday.RegisterDataset("jp", YAML)
+}
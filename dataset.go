@@ -0,0 +1,54 @@
+package bizday
+
+import (
+	"fmt"
+	"time"
+)
+
+// 国ごとの埋め込みの祝日データの登録簿
+// データは bizday/data/jp のようなサブパッケージが init で登録するので、import したパッケージの分だけがバイナリに入る
+
+var datasets = map[string][]byte{}
+
+// RegisterDataset は name のカレンダーの祝日データ (holidays.yaml の形式) を登録する
+// 同じ名前が登録済みの場合は panic する
+func RegisterDataset(name string, data []byte) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := datasets[name]; dup {
+		panic("bizday: RegisterDataset called twice for " + name)
+	}
+	datasets[name] = data
+}
+
+// Dataset は name で登録された祝日データを返す
+func Dataset(name string) ([]byte, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	data, ok := datasets[name]
+	return data, ok
+}
+
+// Load は name のカレンダーを、登録された祝日データの現時点の内容で作る
+// "jp" は一覧にない年を規則で算出する。祝日データが登録されていなければ、Lookup で登録されたカレンダーを返し、
+// それもなければ "jp" は規則だけで算出するカレンダーにする
+func Load(name string) (*Calendar, error) {
+	data, ok := Dataset(name)
+	if !ok {
+		if cal, ok := Lookup(name); ok {
+			return cal, nil
+		}
+		if name == "jp" {
+			return NewJapanCalendarAsOf(nil, time.Now()), nil
+		}
+		return nil, fmt.Errorf("未知のカレンダー: %s (祝日データなら bizday/data/%s を import してください)", name, name)
+	}
+	entries, err := ParseHolidays(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s の祝日データの読み込みに失敗: %w", name, err)
+	}
+	if name == "jp" {
+		return NewJapanCalendarAsOf(entries, time.Now()), nil
+	}
+	return NewCalendarAsOf(entries, time.Now()), nil
+}
@@ -0,0 +1,57 @@
+package bizday
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DayHours は営業日 1 日あたりの想定稼働時間
+// 曜日ごとに変えられる (例: 金曜だけ 6 時間)。ByWeekday にない曜日は Default を使う
+type DayHours struct {
+	Default   time.Duration
+	ByWeekday map[time.Weekday]time.Duration
+}
+
+// UniformDayHours は曜日によらず d 時間の DayHours を返す
+func UniformDayHours(d time.Duration) DayHours {
+	return DayHours{Default: d}
+}
+
+// On は t の曜日の想定稼働時間を返す
+func (h DayHours) On(t time.Time) time.Duration {
+	if d, ok := h.ByWeekday[t.Weekday()]; ok {
+		return d
+	}
+	return h.Default
+}
+
+// Scale は想定稼働時間を f 倍した DayHours を返す (稼働率を掛けるのに使う)
+func (h DayHours) Scale(f float64) DayHours {
+	n := DayHours{Default: time.Duration(float64(h.Default) * f)}
+	if h.ByWeekday != nil {
+		n.ByWeekday = make(map[time.Weekday]time.Duration, len(h.ByWeekday))
+		for w, d := range h.ByWeekday {
+			n.ByWeekday[w] = time.Duration(float64(d) * f)
+		}
+	}
+	return n
+}
+
+// PlannedHours は start~end (両端含む) の営業日の想定稼働時間を h に従って合計する
+// end が start より前なら 0 を返す
+// 合計が time.Duration で表せる範囲 (約 292 年) を超える期間はエラーにする (桁あふれした値は返さない)
+func (c *Calendar) PlannedHours(h DayHours, start, end time.Time) (time.Duration, error) {
+	var total time.Duration
+	for d := BeginningOfDay(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		on := h.On(d)
+		if (on > 0 && total > math.MaxInt64-on) || (on < 0 && total < math.MinInt64-on) {
+			return 0, fmt.Errorf("%s ~ %s の想定稼働時間は長すぎて表せません", start.Format("2006-01-02"), end.Format("2006-01-02"))
+		}
+		total += on
+	}
+	return total, nil
+}
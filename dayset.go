@@ -0,0 +1,183 @@
+package bizday
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// epochDay は t の年月日 (t のロケーションでの日付) を 1970-01-01 からの日数で表す
+// time.Time より小さく、比較も整数 1 回で済むので、祝日の索引のキーに使う
+func epochDay(t time.Time) int32 {
+	y, m, d := t.Date()
+	return civilDay(y, m, d)
+}
+
+// civilDay は y 年 m 月 d 日 (正しい日付であること) を 1970-01-01 からの日数にする
+// 毎日の判定で呼ぶので、time.Date を経由せずグレゴリオ暦の日数を直接計算する (H. Hinnant の days_from_civil)
+func civilDay(y int, m time.Month, d int) int32 {
+	if m <= time.February {
+		y--
+	}
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+	yoe := y - era*400                       // 400 年周期の中の年 (0~399)
+	doy := (153*((int(m)+9)%12)+2)/5 + d - 1 // 3 月 1 日からの日数
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return int32(era*146097 + doe - 719468)
+}
+
+// weekdayOf は epochDay の day の曜日を返す (1970-01-01 は木曜)
+func weekdayOf(day int32) time.Weekday {
+	w := (int(day) + int(time.Thursday)) % 7
+	if w < 0 {
+		w += 7
+	}
+	return time.Weekday(w)
+}
+
+// daysIn は y 年 m 月の日数を返す
+func daysIn(y int, m time.Month) int {
+	if m == time.February {
+		if y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+			return 29
+		}
+		return 28
+	}
+	return 31 - (int(m)-1)%7%2
+}
+
+// dayFromEpoch は epochDay の day を年月日に戻す (civilDay の逆、H. Hinnant の civil_from_days)
+func dayFromEpoch(day int32) (int, time.Month, int) {
+	z := int(day) + 719468
+	era := z / 146097
+	if z < 0 && z%146097 != 0 {
+		era--
+	}
+	doe := z - era*146097                                  // 400 年周期の中の日 (0~146096)
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // 400 年周期の中の年 (0~399)
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // 3 月 1 日からの日数
+	mp := (5*doy + 2) / 153
+	d := doy - (153*mp+2)/5 + 1
+	m := time.Month((mp+2)%12 + 1)
+	y := yoe + era*400
+	if m <= time.February {
+		y++
+	}
+	return y, m, d
+}
+
+// daySet は一覧由来の祝日・振替出勤日の epochDay の集合
+// Calendar を作った後は書き換えず (足すときは clone してから)、コピーした Calendar どうしで共有する
+type daySet map[int32]struct{}
+
+// newDaySet は ts の日付の集合を作る
+func newDaySet(ts []time.Time) daySet {
+	s := make(daySet, len(ts))
+	for _, t := range ts {
+		s[epochDay(t)] = struct{}{}
+	}
+	return s
+}
+
+// contains は s に day が含まれるかを判定する (nil の daySet は空)
+func (s daySet) contains(day int32) bool {
+	_, ok := s[day]
+	return ok
+}
+
+// clone は s のコピーを返す (nil の s からは空の daySet を作る)
+func (s daySet) clone() daySet {
+	n := make(daySet, len(s))
+	for day := range s {
+		n[day] = struct{}{}
+	}
+	return n
+}
+
+// sorted は s の日を昇順に返す
+func (s daySet) sorted() []int32 {
+	days := make([]int32, 0, len(s))
+	for day := range s {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+	return days
+}
+
+// yearMap は年ごとに算出した値を覚えておく map
+// 読み出しは毎日の判定のたびに起きるのでロックを取らずに済ませ、書き込み (年を初めて引いたときだけ) で map を作り直す
+type yearMap[V any] struct {
+	mu    sync.Mutex
+	years atomic.Pointer[map[int]V]
+}
+
+// load は year 年の値を返す
+func (ym *yearMap[V]) load(year int) (V, bool) {
+	if m := ym.years.Load(); m != nil {
+		v, ok := (*m)[year]
+		return v, ok
+	}
+	var zero V
+	return zero, false
+}
+
+// store は year 年の値を覚える
+func (ym *yearMap[V]) store(year int, v V) {
+	ym.mu.Lock()
+	defer ym.mu.Unlock()
+	n := map[int]V{year: v}
+	if m := ym.years.Load(); m != nil {
+		for y, old := range *m {
+			n[y] = old
+		}
+	}
+	ym.years.Store(&n)
+}
+
+// yearCache は Generate で算出した祝日を年ごとに覚えておく (年 → epochDay → 祝日名)
+// Calendar をコピーしても同じキャッシュを共有する (Generate も同じなので結果は変わらない)
+type yearCache = yearMap[map[int32]string]
+
+// generated は year 年に Generate で算出される祝日を返す
+// キャッシュを持たない Calendar (構造体リテラルで作ったもの) では毎回算出する
+func (c *Calendar) generated(year int) map[int32]string {
+	if c.genCache == nil {
+		return holidayNames(c.Generate(year))
+	}
+
+	if hs, ok := c.genCache.load(year); ok {
+		return hs
+	}
+	hs := holidayNames(c.Generate(year))
+	// NewProviderCalendar で取得に失敗した年 (組み合わせた元のカレンダーの失敗を含む) は、取得し直せるようにキャッシュしない
+	if c.providersComplete(year) {
+		c.genCache.store(year, hs)
+	}
+	return hs
+}
+
+// holidayNames は祝日の一覧を epochDay から名前への map にする (同じ日が複数あれば最初の名前を使う)
+func holidayNames(hs []Holiday) map[int32]string {
+	m := make(map[int32]string, len(hs))
+	for _, h := range hs {
+		day := epochDay(h.Date)
+		if _, dup := m[day]; !dup {
+			m[day] = h.Name
+		}
+	}
+	return m
+}
+
+// NewRuleCalendar は規則 generate で祝日を算出する Calendar を既定の営業時間で作る
+// 算出した祝日は年ごとにキャッシュされる
+func NewRuleCalendar(generate func(year int) []Holiday) *Calendar {
+	return &Calendar{
+		Hours:    DefaultWorkHours,
+		Generate: generate,
+		genCache: &yearCache{},
+	}
+}
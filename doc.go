@@ -0,0 +1,25 @@
+// Package bizday は祝日・定休日・営業時間を考慮した営業日の計算を提供する
+//
+// 日本のカレンダーは bizday/data/jp に埋め込んだ祝日データから作る。データにない年の祝日は規則で算出する。
+// 祝日データは国ごとのサブパッケージに分けてあり、import したものだけがバイナリに入る。
+//
+//	import _ "bizday/data/jp"
+//
+//	cal, err := bizday.Load("jp")
+//	if err != nil {
+//		return err
+//	}
+//	n, err := cal.CountBusinessDays(start, end)
+//
+// CountBusinessDays は start と end の両端を含めて数える。始点を含めないなど別の数え方にするには
+// CountBusinessDaysWith に CountOptions を渡す。
+// 表計算ソフトの NETWORKDAYS・WORKDAY と同じ値が必要なときは、土日と引数の祝日だけを見る Networkdays・Workday を使う。
+//
+// 米国・英国・韓国などの規則で算出できるカレンダーは Lookup("us") のように名前で取得できる。
+// 州や地域で祝日の異なる国は、Lookup("us-ma")・Lookup("uk-sct") のように国の名前に -地域 を付けた名前で地域のカレンダーを取得できる。
+// 東京証券取引所 (年末年始の 12/31~1/3 を含む) とニューヨーク証券取引所の休場日は Lookup("tse")・Lookup("nyse") で取得でき、営業時間は立会時間になる。
+// 祝日をファイルやデータベースなど別の取得元から読むには、HolidayProvider を実装して NewProviderCalendar に渡す。
+// 取得元の一覧に本来の日付だけを書いた米国や英国の祝日は、ObservedProvider で土日の振替日を算出できる。
+// データファイルを書き換えずに特定の日の扱いを変えるには、WithCustomRule で日の分類を上書きする関数を重ねる。
+// コマンドラインツールは cmd/bizday、ブラウザから使う WebAssembly 版は cmd/bizday-wasm、bizday serve の API を呼ぶクライアントは client にある。
+package bizday
@@ -0,0 +1,79 @@
+package bizday
+
+import "time"
+
+// WithExtra は entries (会社独自の休業日・振替出勤日) を祝日一覧に重ねた Calendar を返す
+// Workday が true の定義は、土日や祝日でも営業日として扱う日になる
+// 元の祝日一覧や生成規則はそのまま使うので、一覧にない年を規則で算出するかどうかには影響しない
+func (c *Calendar) WithExtra(entries []HolidayEntry) *Calendar {
+	n := *c
+	n.bitmaps = nil
+	n.extra = nil
+	return n.withExtra(append(append([]HolidayEntry{}, c.extra...), entries...))
+}
+
+// withExtra は entries のうち c の時点で有効なものを祝日・振替出勤日に加え、c を返す
+// 祝日・振替出勤日・names の集合は元の Calendar と共有しないよう作り直す
+func (c *Calendar) withExtra(entries []HolidayEntry) *Calendar {
+	if len(entries) == 0 {
+		return c
+	}
+	asOf := c.asOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	holidays, workdays := c.holidays.clone(), c.workdays.clone()
+	names := make(map[int32]string, len(c.names))
+	for k, v := range c.names {
+		names[k] = v
+	}
+	closures := map[int32]struct{}{}
+	for _, e := range entries {
+		switch {
+		case !e.validAt(asOf):
+		case e.Workday:
+			workdays[epochDay(e.Date)] = struct{}{}
+		default:
+			if !c.IsHoliday(e.Date) {
+				closures[epochDay(e.Date)] = struct{}{}
+			}
+			holidays[epochDay(e.Date)] = struct{}{}
+			// 国民の祝日と重なる日は祝日の名前を残す
+			if _, ok := names[epochDay(e.Date)]; !ok && e.Name != "" {
+				names[epochDay(e.Date)] = e.Name
+			}
+		}
+	}
+	c.holidays, c.workdays, c.names, c.closures = holidays, workdays, names, closures
+	c.extra = entries
+	return c
+}
+
+// isClosure は t の日付が WithExtra・WithClosureRules・WithCustomRule で重ねた会社独自の休業日 (祝日でない日) かどうかを判定
+// 組み合わせたカレンダーでは、どれかの元のカレンダーの休業日で、どの元のカレンダーでも祝日でない日も含める
+func (c *Calendar) isClosure(t time.Time) bool {
+	if _, ok := c.closures[epochDay(t)]; ok {
+		return true
+	}
+	if c.members != nil {
+		closure := false
+		for _, m := range c.members {
+			if _, ok := m.baseHolidayOn(t); ok {
+				return false
+			}
+			closure = closure || m.isClosure(t)
+		}
+		if closure {
+			return true
+		}
+	}
+	if _, ok := c.closureRuleOn(t); ok {
+		_, holiday := c.baseHolidayOn(t)
+		return !holiday
+	}
+	if class, ok := c.customClass(t); ok && class == ClassHoliday {
+		_, holiday := c.baseHolidayOn(t)
+		return !holiday
+	}
+	return false
+}
@@ -0,0 +1,1031 @@
+// bizday の営業日の判定・集計を gRPC で提供するサービスの定義
+// メッセージの項目は serve の HTTP API (/v1/...) の JSON と同じ名前・意味にそろえている
+// google.api.http の指定は grpc-gateway で既存の HTTP API と同じパスに割り当てるためのもの
+//
+// Go のコード (gen/bizday/v1) は次のようにして生成する (protoc-gen-go・protoc-gen-go-grpc・protoc-gen-grpc-gateway が必要)
+//
+//   protoc -I proto -I third_party/googleapis \
+//     --go_out=. --go_opt=module=bizday \
+//     --go-grpc_out=. --go-grpc_opt=module=bizday \
+//     --grpc-gateway_out=. --grpc-gateway_opt=module=bizday \
+//     proto/bizday/v1/bizday.proto
+//
+// サーバの実装は cmd/bizday/grpc.go (serve --grpc-addr で待ち受け、serve --gateway で HTTP API を grpc-gateway 経由にする)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: bizday/v1/bizday.proto
+
+package bizdayv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DayClass は日の分類 (bizday.DayClass)
+type DayClass int32
+
+const (
+	DayClass_DAY_CLASS_UNSPECIFIED DayClass = 0
+	DayClass_DAY_CLASS_BUSINESS    DayClass = 1 // 営業日
+	DayClass_DAY_CLASS_WEEKEND     DayClass = 2 // 定休日
+	DayClass_DAY_CLASS_HOLIDAY     DayClass = 3 // 祝日
+	DayClass_DAY_CLASS_WORKDAY     DayClass = 4 // 振替出勤日 (定休日・祝日だが営業日)
+)
+
+// Enum value maps for DayClass.
+var (
+	DayClass_name = map[int32]string{
+		0: "DAY_CLASS_UNSPECIFIED",
+		1: "DAY_CLASS_BUSINESS",
+		2: "DAY_CLASS_WEEKEND",
+		3: "DAY_CLASS_HOLIDAY",
+		4: "DAY_CLASS_WORKDAY",
+	}
+	DayClass_value = map[string]int32{
+		"DAY_CLASS_UNSPECIFIED": 0,
+		"DAY_CLASS_BUSINESS":    1,
+		"DAY_CLASS_WEEKEND":     2,
+		"DAY_CLASS_HOLIDAY":     3,
+		"DAY_CLASS_WORKDAY":     4,
+	}
+)
+
+func (x DayClass) Enum() *DayClass {
+	p := new(DayClass)
+	*p = x
+	return p
+}
+
+func (x DayClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_bizday_v1_bizday_proto_enumTypes[0].Descriptor()
+}
+
+func (DayClass) Type() protoreflect.EnumType {
+	return &file_bizday_v1_bizday_proto_enumTypes[0]
+}
+
+func (x DayClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayClass.Descriptor instead.
+func (DayClass) EnumDescriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{0}
+}
+
+type IsBusinessDayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      string                 `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"` // 省略時は今日
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsBusinessDayRequest) Reset() {
+	*x = IsBusinessDayRequest{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsBusinessDayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsBusinessDayRequest) ProtoMessage() {}
+
+func (x *IsBusinessDayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsBusinessDayRequest.ProtoReflect.Descriptor instead.
+func (*IsBusinessDayRequest) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IsBusinessDayRequest) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *IsBusinessDayRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type IsBusinessDayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Calendar      string                 `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	BusinessDay   bool                   `protobuf:"varint,4,opt,name=business_day,json=businessDay,proto3" json:"business_day,omitempty"`
+	Class         DayClass               `protobuf:"varint,5,opt,name=class,proto3,enum=bizday.v1.DayClass" json:"class,omitempty"`
+	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"` // 祝日の名前
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsBusinessDayResponse) Reset() {
+	*x = IsBusinessDayResponse{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsBusinessDayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsBusinessDayResponse) ProtoMessage() {}
+
+func (x *IsBusinessDayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsBusinessDayResponse.ProtoReflect.Descriptor instead.
+func (*IsBusinessDayResponse) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IsBusinessDayResponse) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *IsBusinessDayResponse) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *IsBusinessDayResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *IsBusinessDayResponse) GetBusinessDay() bool {
+	if x != nil {
+		return x.BusinessDay
+	}
+	return false
+}
+
+func (x *IsBusinessDayResponse) GetClass() DayClass {
+	if x != nil {
+		return x.Class
+	}
+	return DayClass_DAY_CLASS_UNSPECIFIED
+}
+
+func (x *IsBusinessDayResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      string                 `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Breakdown     bool                   `protobuf:"varint,4,opt,name=breakdown,proto3" json:"breakdown,omitempty"` // 内訳も返すか
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRequest) ProtoMessage() {}
+
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CountRequest) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *CountRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CountRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CountRequest) GetBreakdown() bool {
+	if x != nil {
+		return x.Breakdown
+	}
+	return false
+}
+
+type CountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Calendar      string                 `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	BusinessDays  int32                  `protobuf:"varint,5,opt,name=business_days,json=businessDays,proto3" json:"business_days,omitempty"`
+	Hours         float64                `protobuf:"fixed64,6,opt,name=hours,proto3" json:"hours,omitempty"`
+	Breakdown     *Breakdown             `protobuf:"bytes,7,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CountResponse) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *CountResponse) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *CountResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CountResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CountResponse) GetBusinessDays() int32 {
+	if x != nil {
+		return x.BusinessDays
+	}
+	return 0
+}
+
+func (x *CountResponse) GetHours() float64 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+func (x *CountResponse) GetBreakdown() *Breakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+// Breakdown は期間の営業日数の内訳 (bizday.RangeBreakdown)
+type Breakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalDays     int32                  `protobuf:"varint,1,opt,name=total_days,json=totalDays,proto3" json:"total_days,omitempty"`
+	WeekendDays   int32                  `protobuf:"varint,2,opt,name=weekend_days,json=weekendDays,proto3" json:"weekend_days,omitempty"`
+	Holidays      int32                  `protobuf:"varint,3,opt,name=holidays,proto3" json:"holidays,omitempty"`
+	Closures      int32                  `protobuf:"varint,4,opt,name=closures,proto3" json:"closures,omitempty"`
+	Workdays      int32                  `protobuf:"varint,5,opt,name=workdays,proto3" json:"workdays,omitempty"`
+	BusinessDays  int32                  `protobuf:"varint,6,opt,name=business_days,json=businessDays,proto3" json:"business_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Breakdown) Reset() {
+	*x = Breakdown{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breakdown) ProtoMessage() {}
+
+func (x *Breakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breakdown.ProtoReflect.Descriptor instead.
+func (*Breakdown) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Breakdown) GetTotalDays() int32 {
+	if x != nil {
+		return x.TotalDays
+	}
+	return 0
+}
+
+func (x *Breakdown) GetWeekendDays() int32 {
+	if x != nil {
+		return x.WeekendDays
+	}
+	return 0
+}
+
+func (x *Breakdown) GetHolidays() int32 {
+	if x != nil {
+		return x.Holidays
+	}
+	return 0
+}
+
+func (x *Breakdown) GetClosures() int32 {
+	if x != nil {
+		return x.Closures
+	}
+	return 0
+}
+
+func (x *Breakdown) GetWorkdays() int32 {
+	if x != nil {
+		return x.Workdays
+	}
+	return 0
+}
+
+func (x *Breakdown) GetBusinessDays() int32 {
+	if x != nil {
+		return x.BusinessDays
+	}
+	return 0
+}
+
+type AddBusinessDaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      string                 `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"` // 省略時は今日
+	N             int32                  `protobuf:"varint,3,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBusinessDaysRequest) Reset() {
+	*x = AddBusinessDaysRequest{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBusinessDaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBusinessDaysRequest) ProtoMessage() {}
+
+func (x *AddBusinessDaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBusinessDaysRequest.ProtoReflect.Descriptor instead.
+func (*AddBusinessDaysRequest) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddBusinessDaysRequest) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *AddBusinessDaysRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AddBusinessDaysRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type AddBusinessDaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Calendar      string                 `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	N             int32                  `protobuf:"varint,4,opt,name=n,proto3" json:"n,omitempty"`
+	Result        string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBusinessDaysResponse) Reset() {
+	*x = AddBusinessDaysResponse{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBusinessDaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBusinessDaysResponse) ProtoMessage() {}
+
+func (x *AddBusinessDaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBusinessDaysResponse.ProtoReflect.Descriptor instead.
+func (*AddBusinessDaysResponse) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddBusinessDaysResponse) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *AddBusinessDaysResponse) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *AddBusinessDaysResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AddBusinessDaysResponse) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *AddBusinessDaysResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type MonthSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      string                 `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Month         string                 `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"` // "2025-05" の形式、省略時は今月
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthSummaryRequest) Reset() {
+	*x = MonthSummaryRequest{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthSummaryRequest) ProtoMessage() {}
+
+func (x *MonthSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthSummaryRequest.ProtoReflect.Descriptor instead.
+func (*MonthSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MonthSummaryRequest) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *MonthSummaryRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+type MonthSummaryResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion          int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Calendar               string                 `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Date                   string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Month                  string                 `protobuf:"bytes,4,opt,name=month,proto3" json:"month,omitempty"`
+	BusinessDayIndex       int32                  `protobuf:"varint,5,opt,name=business_day_index,json=businessDayIndex,proto3" json:"business_day_index,omitempty"`
+	BusinessDayIndexLabel  string                 `protobuf:"bytes,6,opt,name=business_day_index_label,json=businessDayIndexLabel,proto3" json:"business_day_index_label,omitempty"`
+	BusinessDaysTotal      int32                  `protobuf:"varint,7,opt,name=business_days_total,json=businessDaysTotal,proto3" json:"business_days_total,omitempty"`
+	BusinessDaysRemaining  int32                  `protobuf:"varint,8,opt,name=business_days_remaining,json=businessDaysRemaining,proto3" json:"business_days_remaining,omitempty"`
+	PercentElapsed         float64                `protobuf:"fixed64,9,opt,name=percent_elapsed,json=percentElapsed,proto3" json:"percent_elapsed,omitempty"`
+	RemainingHours         float64                `protobuf:"fixed64,10,opt,name=remaining_hours,json=remainingHours,proto3" json:"remaining_hours,omitempty"`
+	WorkedHours            float64                `protobuf:"fixed64,11,opt,name=worked_hours,json=workedHours,proto3" json:"worked_hours,omitempty"`
+	CalendarDaysTotal      int32                  `protobuf:"varint,12,opt,name=calendar_days_total,json=calendarDaysTotal,proto3" json:"calendar_days_total,omitempty"`
+	CalendarDaysElapsed    int32                  `protobuf:"varint,13,opt,name=calendar_days_elapsed,json=calendarDaysElapsed,proto3" json:"calendar_days_elapsed,omitempty"`
+	Holidays               []*Holiday             `protobuf:"bytes,14,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	CalendarDaysRemaining  int32                  `protobuf:"varint,15,opt,name=calendar_days_remaining,json=calendarDaysRemaining,proto3" json:"calendar_days_remaining,omitempty"`
+	CalendarPercentElapsed float64                `protobuf:"fixed64,16,opt,name=calendar_percent_elapsed,json=calendarPercentElapsed,proto3" json:"calendar_percent_elapsed,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *MonthSummaryResponse) Reset() {
+	*x = MonthSummaryResponse{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthSummaryResponse) ProtoMessage() {}
+
+func (x *MonthSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthSummaryResponse.ProtoReflect.Descriptor instead.
+func (*MonthSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MonthSummaryResponse) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+func (x *MonthSummaryResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MonthSummaryResponse) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *MonthSummaryResponse) GetBusinessDayIndex() int32 {
+	if x != nil {
+		return x.BusinessDayIndex
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetBusinessDayIndexLabel() string {
+	if x != nil {
+		return x.BusinessDayIndexLabel
+	}
+	return ""
+}
+
+func (x *MonthSummaryResponse) GetBusinessDaysTotal() int32 {
+	if x != nil {
+		return x.BusinessDaysTotal
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetBusinessDaysRemaining() int32 {
+	if x != nil {
+		return x.BusinessDaysRemaining
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetPercentElapsed() float64 {
+	if x != nil {
+		return x.PercentElapsed
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetRemainingHours() float64 {
+	if x != nil {
+		return x.RemainingHours
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetWorkedHours() float64 {
+	if x != nil {
+		return x.WorkedHours
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetCalendarDaysTotal() int32 {
+	if x != nil {
+		return x.CalendarDaysTotal
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetCalendarDaysElapsed() int32 {
+	if x != nil {
+		return x.CalendarDaysElapsed
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetHolidays() []*Holiday {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+func (x *MonthSummaryResponse) GetCalendarDaysRemaining() int32 {
+	if x != nil {
+		return x.CalendarDaysRemaining
+	}
+	return 0
+}
+
+func (x *MonthSummaryResponse) GetCalendarPercentElapsed() float64 {
+	if x != nil {
+		return x.CalendarPercentElapsed
+	}
+	return 0
+}
+
+type Holiday struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Holiday) Reset() {
+	*x = Holiday{}
+	mi := &file_bizday_v1_bizday_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Holiday) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holiday) ProtoMessage() {}
+
+func (x *Holiday) ProtoReflect() protoreflect.Message {
+	mi := &file_bizday_v1_bizday_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holiday.ProtoReflect.Descriptor instead.
+func (*Holiday) Descriptor() ([]byte, []int) {
+	return file_bizday_v1_bizday_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Holiday) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Holiday) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_bizday_v1_bizday_proto protoreflect.FileDescriptor
+
+const file_bizday_v1_bizday_proto_rawDesc = "" +
+	"\n" +
+	"\x16bizday/v1/bizday.proto\x12\tbizday.v1\x1a\x1cgoogle/api/annotations.proto\"F\n" +
+	"\x14IsBusinessDayRequest\x12\x1a\n" +
+	"\bcalendar\x18\x01 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\"\xd0\x01\n" +
+	"\x15IsBusinessDayResponse\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x1a\n" +
+	"\bcalendar\x18\x02 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12!\n" +
+	"\fbusiness_day\x18\x04 \x01(\bR\vbusinessDay\x12)\n" +
+	"\x05class\x18\x05 \x01(\x0e2\x13.bizday.v1.DayClassR\x05class\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"l\n" +
+	"\fCountRequest\x12\x1a\n" +
+	"\bcalendar\x18\x01 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1c\n" +
+	"\tbreakdown\x18\x04 \x01(\bR\tbreakdown\"\xe5\x01\n" +
+	"\rCountResponse\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x1a\n" +
+	"\bcalendar\x18\x02 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12#\n" +
+	"\rbusiness_days\x18\x05 \x01(\x05R\fbusinessDays\x12\x14\n" +
+	"\x05hours\x18\x06 \x01(\x01R\x05hours\x122\n" +
+	"\tbreakdown\x18\a \x01(\v2\x14.bizday.v1.BreakdownR\tbreakdown\"\xc6\x01\n" +
+	"\tBreakdown\x12\x1d\n" +
+	"\n" +
+	"total_days\x18\x01 \x01(\x05R\ttotalDays\x12!\n" +
+	"\fweekend_days\x18\x02 \x01(\x05R\vweekendDays\x12\x1a\n" +
+	"\bholidays\x18\x03 \x01(\x05R\bholidays\x12\x1a\n" +
+	"\bclosures\x18\x04 \x01(\x05R\bclosures\x12\x1a\n" +
+	"\bworkdays\x18\x05 \x01(\x05R\bworkdays\x12#\n" +
+	"\rbusiness_days\x18\x06 \x01(\x05R\fbusinessDays\"V\n" +
+	"\x16AddBusinessDaysRequest\x12\x1a\n" +
+	"\bcalendar\x18\x01 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\f\n" +
+	"\x01n\x18\x03 \x01(\x05R\x01n\"\x96\x01\n" +
+	"\x17AddBusinessDaysResponse\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x1a\n" +
+	"\bcalendar\x18\x02 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\f\n" +
+	"\x01n\x18\x04 \x01(\x05R\x01n\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\tR\x06result\"G\n" +
+	"\x13MonthSummaryRequest\x12\x1a\n" +
+	"\bcalendar\x18\x01 \x01(\tR\bcalendar\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\"\xcd\x05\n" +
+	"\x14MonthSummaryResponse\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x1a\n" +
+	"\bcalendar\x18\x02 \x01(\tR\bcalendar\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x14\n" +
+	"\x05month\x18\x04 \x01(\tR\x05month\x12,\n" +
+	"\x12business_day_index\x18\x05 \x01(\x05R\x10businessDayIndex\x127\n" +
+	"\x18business_day_index_label\x18\x06 \x01(\tR\x15businessDayIndexLabel\x12.\n" +
+	"\x13business_days_total\x18\a \x01(\x05R\x11businessDaysTotal\x126\n" +
+	"\x17business_days_remaining\x18\b \x01(\x05R\x15businessDaysRemaining\x12'\n" +
+	"\x0fpercent_elapsed\x18\t \x01(\x01R\x0epercentElapsed\x12'\n" +
+	"\x0fremaining_hours\x18\n" +
+	" \x01(\x01R\x0eremainingHours\x12!\n" +
+	"\fworked_hours\x18\v \x01(\x01R\vworkedHours\x12.\n" +
+	"\x13calendar_days_total\x18\f \x01(\x05R\x11calendarDaysTotal\x122\n" +
+	"\x15calendar_days_elapsed\x18\r \x01(\x05R\x13calendarDaysElapsed\x12.\n" +
+	"\bholidays\x18\x0e \x03(\v2\x12.bizday.v1.HolidayR\bholidays\x126\n" +
+	"\x17calendar_days_remaining\x18\x0f \x01(\x05R\x15calendarDaysRemaining\x128\n" +
+	"\x18calendar_percent_elapsed\x18\x10 \x01(\x01R\x16calendarPercentElapsed\"1\n" +
+	"\aHoliday\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name*\x82\x01\n" +
+	"\bDayClass\x12\x19\n" +
+	"\x15DAY_CLASS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DAY_CLASS_BUSINESS\x10\x01\x12\x15\n" +
+	"\x11DAY_CLASS_WEEKEND\x10\x02\x12\x15\n" +
+	"\x11DAY_CLASS_HOLIDAY\x10\x03\x12\x15\n" +
+	"\x11DAY_CLASS_WORKDAY\x10\x042\xa6\x03\n" +
+	"\rBizdayService\x12o\n" +
+	"\rIsBusinessDay\x12\x1f.bizday.v1.IsBusinessDayRequest\x1a .bizday.v1.IsBusinessDayResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/is-business-day\x12M\n" +
+	"\x05Count\x12\x17.bizday.v1.CountRequest\x1a\x18.bizday.v1.CountResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/count\x12i\n" +
+	"\x0fAddBusinessDays\x12!.bizday.v1.AddBusinessDaysRequest\x1a\".bizday.v1.AddBusinessDaysResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/v1/add\x12j\n" +
+	"\fMonthSummary\x12\x1e.bizday.v1.MonthSummaryRequest\x1a\x1f.bizday.v1.MonthSummaryResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/month-summaryB\x1fZ\x1dbizday/gen/bizday/v1;bizdayv1b\x06proto3"
+
+var (
+	file_bizday_v1_bizday_proto_rawDescOnce sync.Once
+	file_bizday_v1_bizday_proto_rawDescData []byte
+)
+
+func file_bizday_v1_bizday_proto_rawDescGZIP() []byte {
+	file_bizday_v1_bizday_proto_rawDescOnce.Do(func() {
+		file_bizday_v1_bizday_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bizday_v1_bizday_proto_rawDesc), len(file_bizday_v1_bizday_proto_rawDesc)))
+	})
+	return file_bizday_v1_bizday_proto_rawDescData
+}
+
+var file_bizday_v1_bizday_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bizday_v1_bizday_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_bizday_v1_bizday_proto_goTypes = []any{
+	(DayClass)(0),                   // 0: bizday.v1.DayClass
+	(*IsBusinessDayRequest)(nil),    // 1: bizday.v1.IsBusinessDayRequest
+	(*IsBusinessDayResponse)(nil),   // 2: bizday.v1.IsBusinessDayResponse
+	(*CountRequest)(nil),            // 3: bizday.v1.CountRequest
+	(*CountResponse)(nil),           // 4: bizday.v1.CountResponse
+	(*Breakdown)(nil),               // 5: bizday.v1.Breakdown
+	(*AddBusinessDaysRequest)(nil),  // 6: bizday.v1.AddBusinessDaysRequest
+	(*AddBusinessDaysResponse)(nil), // 7: bizday.v1.AddBusinessDaysResponse
+	(*MonthSummaryRequest)(nil),     // 8: bizday.v1.MonthSummaryRequest
+	(*MonthSummaryResponse)(nil),    // 9: bizday.v1.MonthSummaryResponse
+	(*Holiday)(nil),                 // 10: bizday.v1.Holiday
+}
+var file_bizday_v1_bizday_proto_depIdxs = []int32{
+	0,  // 0: bizday.v1.IsBusinessDayResponse.class:type_name -> bizday.v1.DayClass
+	5,  // 1: bizday.v1.CountResponse.breakdown:type_name -> bizday.v1.Breakdown
+	10, // 2: bizday.v1.MonthSummaryResponse.holidays:type_name -> bizday.v1.Holiday
+	1,  // 3: bizday.v1.BizdayService.IsBusinessDay:input_type -> bizday.v1.IsBusinessDayRequest
+	3,  // 4: bizday.v1.BizdayService.Count:input_type -> bizday.v1.CountRequest
+	6,  // 5: bizday.v1.BizdayService.AddBusinessDays:input_type -> bizday.v1.AddBusinessDaysRequest
+	8,  // 6: bizday.v1.BizdayService.MonthSummary:input_type -> bizday.v1.MonthSummaryRequest
+	2,  // 7: bizday.v1.BizdayService.IsBusinessDay:output_type -> bizday.v1.IsBusinessDayResponse
+	4,  // 8: bizday.v1.BizdayService.Count:output_type -> bizday.v1.CountResponse
+	7,  // 9: bizday.v1.BizdayService.AddBusinessDays:output_type -> bizday.v1.AddBusinessDaysResponse
+	9,  // 10: bizday.v1.BizdayService.MonthSummary:output_type -> bizday.v1.MonthSummaryResponse
+	7,  // [7:11] is the sub-list for method output_type
+	3,  // [3:7] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_bizday_v1_bizday_proto_init() }
+func file_bizday_v1_bizday_proto_init() {
+	if File_bizday_v1_bizday_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bizday_v1_bizday_proto_rawDesc), len(file_bizday_v1_bizday_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bizday_v1_bizday_proto_goTypes,
+		DependencyIndexes: file_bizday_v1_bizday_proto_depIdxs,
+		EnumInfos:         file_bizday_v1_bizday_proto_enumTypes,
+		MessageInfos:      file_bizday_v1_bizday_proto_msgTypes,
+	}.Build()
+	File_bizday_v1_bizday_proto = out.File
+	file_bizday_v1_bizday_proto_goTypes = nil
+	file_bizday_v1_bizday_proto_depIdxs = nil
+}
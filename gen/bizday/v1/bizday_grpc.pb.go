@@ -0,0 +1,257 @@
+// bizday の営業日の判定・集計を gRPC で提供するサービスの定義
+// メッセージの項目は serve の HTTP API (/v1/...) の JSON と同じ名前・意味にそろえている
+// google.api.http の指定は grpc-gateway で既存の HTTP API と同じパスに割り当てるためのもの
+//
+// Go のコード (gen/bizday/v1) は次のようにして生成する (protoc-gen-go・protoc-gen-go-grpc・protoc-gen-grpc-gateway が必要)
+//
+//   protoc -I proto -I third_party/googleapis \
+//     --go_out=. --go_opt=module=bizday \
+//     --go-grpc_out=. --go-grpc_opt=module=bizday \
+//     --grpc-gateway_out=. --grpc-gateway_opt=module=bizday \
+//     proto/bizday/v1/bizday.proto
+//
+// サーバの実装は cmd/bizday/grpc.go (serve --grpc-addr で待ち受け、serve --gateway で HTTP API を grpc-gateway 経由にする)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bizday/v1/bizday.proto
+
+package bizdayv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BizdayService_IsBusinessDay_FullMethodName   = "/bizday.v1.BizdayService/IsBusinessDay"
+	BizdayService_Count_FullMethodName           = "/bizday.v1.BizdayService/Count"
+	BizdayService_AddBusinessDays_FullMethodName = "/bizday.v1.BizdayService/AddBusinessDays"
+	BizdayService_MonthSummary_FullMethodName    = "/bizday.v1.BizdayService/MonthSummary"
+)
+
+// BizdayServiceClient is the client API for BizdayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BizdayServiceClient interface {
+	// IsBusinessDay は日付が営業日かどうかと、その日の分類を返す
+	IsBusinessDay(ctx context.Context, in *IsBusinessDayRequest, opts ...grpc.CallOption) (*IsBusinessDayResponse, error)
+	// Count は期間 (両端を含む) の営業日数と想定稼働時間を返す
+	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	// AddBusinessDays は日付の n 営業日後 (負なら前) の日付を返す
+	AddBusinessDays(ctx context.Context, in *AddBusinessDaysRequest, opts ...grpc.CallOption) (*AddBusinessDaysResponse, error)
+	// MonthSummary は月の営業日の経過状況を返す
+	MonthSummary(ctx context.Context, in *MonthSummaryRequest, opts ...grpc.CallOption) (*MonthSummaryResponse, error)
+}
+
+type bizdayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBizdayServiceClient(cc grpc.ClientConnInterface) BizdayServiceClient {
+	return &bizdayServiceClient{cc}
+}
+
+func (c *bizdayServiceClient) IsBusinessDay(ctx context.Context, in *IsBusinessDayRequest, opts ...grpc.CallOption) (*IsBusinessDayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsBusinessDayResponse)
+	err := c.cc.Invoke(ctx, BizdayService_IsBusinessDay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bizdayServiceClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, BizdayService_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bizdayServiceClient) AddBusinessDays(ctx context.Context, in *AddBusinessDaysRequest, opts ...grpc.CallOption) (*AddBusinessDaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBusinessDaysResponse)
+	err := c.cc.Invoke(ctx, BizdayService_AddBusinessDays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bizdayServiceClient) MonthSummary(ctx context.Context, in *MonthSummaryRequest, opts ...grpc.CallOption) (*MonthSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonthSummaryResponse)
+	err := c.cc.Invoke(ctx, BizdayService_MonthSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BizdayServiceServer is the server API for BizdayService service.
+// All implementations must embed UnimplementedBizdayServiceServer
+// for forward compatibility.
+type BizdayServiceServer interface {
+	// IsBusinessDay は日付が営業日かどうかと、その日の分類を返す
+	IsBusinessDay(context.Context, *IsBusinessDayRequest) (*IsBusinessDayResponse, error)
+	// Count は期間 (両端を含む) の営業日数と想定稼働時間を返す
+	Count(context.Context, *CountRequest) (*CountResponse, error)
+	// AddBusinessDays は日付の n 営業日後 (負なら前) の日付を返す
+	AddBusinessDays(context.Context, *AddBusinessDaysRequest) (*AddBusinessDaysResponse, error)
+	// MonthSummary は月の営業日の経過状況を返す
+	MonthSummary(context.Context, *MonthSummaryRequest) (*MonthSummaryResponse, error)
+	mustEmbedUnimplementedBizdayServiceServer()
+}
+
+// UnimplementedBizdayServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBizdayServiceServer struct{}
+
+func (UnimplementedBizdayServiceServer) IsBusinessDay(context.Context, *IsBusinessDayRequest) (*IsBusinessDayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBusinessDay not implemented")
+}
+func (UnimplementedBizdayServiceServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedBizdayServiceServer) AddBusinessDays(context.Context, *AddBusinessDaysRequest) (*AddBusinessDaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBusinessDays not implemented")
+}
+func (UnimplementedBizdayServiceServer) MonthSummary(context.Context, *MonthSummaryRequest) (*MonthSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MonthSummary not implemented")
+}
+func (UnimplementedBizdayServiceServer) mustEmbedUnimplementedBizdayServiceServer() {}
+func (UnimplementedBizdayServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBizdayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BizdayServiceServer will
+// result in compilation errors.
+type UnsafeBizdayServiceServer interface {
+	mustEmbedUnimplementedBizdayServiceServer()
+}
+
+func RegisterBizdayServiceServer(s grpc.ServiceRegistrar, srv BizdayServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBizdayServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BizdayService_ServiceDesc, srv)
+}
+
+func _BizdayService_IsBusinessDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBusinessDayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizdayServiceServer).IsBusinessDay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BizdayService_IsBusinessDay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizdayServiceServer).IsBusinessDay(ctx, req.(*IsBusinessDayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BizdayService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizdayServiceServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BizdayService_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizdayServiceServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BizdayService_AddBusinessDays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBusinessDaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizdayServiceServer).AddBusinessDays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BizdayService_AddBusinessDays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizdayServiceServer).AddBusinessDays(ctx, req.(*AddBusinessDaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BizdayService_MonthSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MonthSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizdayServiceServer).MonthSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BizdayService_MonthSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizdayServiceServer).MonthSummary(ctx, req.(*MonthSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BizdayService_ServiceDesc is the grpc.ServiceDesc for BizdayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BizdayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bizday.v1.BizdayService",
+	HandlerType: (*BizdayServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IsBusinessDay",
+			Handler:    _BizdayService_IsBusinessDay_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _BizdayService_Count_Handler,
+		},
+		{
+			MethodName: "AddBusinessDays",
+			Handler:    _BizdayService_AddBusinessDays_Handler,
+		},
+		{
+			MethodName: "MonthSummary",
+			Handler:    _BizdayService_MonthSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bizday/v1/bizday.proto",
+}
@@ -3,3 +3,18 @@ module bizday
 go 1.23.5
 
 require gopkg.in/yaml.v3 v3.0.1
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.0
+	golang.org/x/text v0.28.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20250603155806-513f23925822
+	google.golang.org/grpc v1.73.0
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
+)
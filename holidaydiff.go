@@ -0,0 +1,70 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// HolidayChangeKind は祝日データの変更の種類
+type HolidayChangeKind string
+
+const (
+	HolidayAdded   HolidayChangeKind = "added"   // 新しいデータにだけある
+	HolidayRemoved HolidayChangeKind = "removed" // 古いデータにだけある
+	HolidayRenamed HolidayChangeKind = "renamed" // 両方にあるが名前が違う
+)
+
+// HolidayChange は祝日データの 1 日分の変更
+type HolidayChange struct {
+	Kind    HolidayChangeKind
+	Date    time.Time
+	Workday bool   // 振替出勤日の変更か
+	OldName string // 追加では空
+	NewName string // 削除では空
+}
+
+// DiffHolidays は old と new の祝日定義を asOf 時点で有効な内容どうしで比べ、変わった日を日付順に返す
+// 祝日と振替出勤日は別々に比べるので、同じ日が祝日から振替出勤日に変わったものは削除と追加になる
+func DiffHolidays(old, new []HolidayEntry, asOf time.Time) []HolidayChange {
+	type key struct {
+		day     int32
+		workday bool
+	}
+	index := func(entries []HolidayEntry) map[key]HolidayEntry {
+		m := map[key]HolidayEntry{}
+		for _, e := range entries {
+			if e.validAt(asOf) {
+				m[key{epochDay(e.Date), e.Workday}] = e
+			}
+		}
+		return m
+	}
+	before, after := index(old), index(new)
+
+	var changes []HolidayChange
+	for k, o := range before {
+		n, ok := after[k]
+		switch {
+		case !ok:
+			changes = append(changes, HolidayChange{Kind: HolidayRemoved, Date: o.Date, Workday: k.workday, OldName: o.Name})
+		case o.Name != n.Name:
+			changes = append(changes, HolidayChange{Kind: HolidayRenamed, Date: n.Date, Workday: k.workday, OldName: o.Name, NewName: n.Name})
+		}
+	}
+	for k, n := range after {
+		if _, ok := before[k]; !ok {
+			changes = append(changes, HolidayChange{Kind: HolidayAdded, Date: n.Date, Workday: k.workday, NewName: n.Name})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].Date.Equal(changes[j].Date) {
+			return changes[i].Date.Before(changes[j].Date)
+		}
+		// 同じ日なら祝日を振替出勤日より、削除をほかの変更より先に並べる
+		if changes[i].Workday != changes[j].Workday {
+			return !changes[i].Workday
+		}
+		return changes[i].Kind == HolidayRemoved && changes[j].Kind != HolidayRemoved
+	})
+	return changes
+}
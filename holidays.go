@@ -0,0 +1,152 @@
+package bizday
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHolidays は埋め込みの日本の祝日データ (bizday/data/jp の holidays.yaml) を読み込む
+// 使うには bizday/data/jp を import しておく。日本のカレンダーは NewCalendarAsOf(entries, time.Now()) のようにして作る
+func DefaultHolidays() ([]HolidayEntry, error) {
+	data, ok := Dataset("jp")
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("日本の祝日データが埋め込まれていません (bizday/data/jp を import してください)")
+	}
+	return ParseHolidays(data)
+}
+
+// 祝日の定義を読み込むための構造体
+type HolidayList struct {
+	Holidays []HolidayYAML `yaml:"holidays"`
+	// Overrides は年ごとの祝日一覧の差し替え (指定した年は Holidays の該当年を丸ごと置き換える)
+	Overrides map[int][]HolidayYAML `yaml:"overrides"`
+	// Workdays は土日や祝日でも営業日にする日 (振替出勤日)
+	Workdays []HolidayYAML `yaml:"workdays"`
+	// Calendars は国・地域ごとの祝日 (キーは us, uk などのカレンダー名、中身は holidays・overrides・workdays)
+	Calendars map[string]HolidayList `yaml:"calendars"`
+	// SubstituteHolidays は振替休日・国民の休日を holidays から算出するか (SubstituteHolidays を参照)
+	// 省略時は日本の祝日であるトップレベルの一覧だけ算出し、calendars の一覧では算出しない
+	SubstituteHolidays *bool `yaml:"substitute_holidays"`
+}
+
+// HolidayYAML は祝日 1 件の定義
+// "2025-01-01" のような日付だけの書き方、{"2025-01-01": 元日} のような日付と名前の組の書き方、
+// 有効期間付きのマップの書き方を受け付ける
+type HolidayYAML struct {
+	Date      string `yaml:"date"`
+	Name      string `yaml:"name"`
+	ValidFrom string `yaml:"valid_from"` // この日以降のカレンダーにだけ含める
+	ValidTo   string `yaml:"valid_to"`   // この日までのカレンダーにだけ含める
+}
+
+// UnmarshalYAML は日付だけのスカラー、日付と名前の組、マップのいずれも HolidayYAML として読み込む
+func (h *HolidayYAML) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		h.Date = n.Value
+		return nil
+	}
+	if n.Kind == yaml.MappingNode && len(n.Content) == 2 && n.Content[1].Kind == yaml.ScalarNode {
+		if _, err := ParseDate(n.Content[0].Value, time.UTC); err == nil {
+			h.Date, h.Name = n.Content[0].Value, n.Content[1].Value
+			return nil
+		}
+	}
+	type plain HolidayYAML
+	return n.Decode((*plain)(h))
+}
+
+// ParseHolidays は holidays.yaml の形式のデータを HolidayEntry のスライスにする
+// calendars に書かれた国・地域ごとの祝日は含めない (ParseHolidayCalendars で読む)
+func ParseHolidays(data []byte) ([]HolidayEntry, error) {
+	var holidayList HolidayList
+	err := yaml.Unmarshal(data, &holidayList)
+	if err != nil {
+		return nil, err
+	}
+	return holidayList.entries(true)
+}
+
+// ParseHolidayCalendars は holidays.yaml の形式のデータの calendars を、カレンダー名から HolidayEntry のスライスへの map にする
+func ParseHolidayCalendars(data []byte) (map[string][]HolidayEntry, error) {
+	var holidayList HolidayList
+	if err := yaml.Unmarshal(data, &holidayList); err != nil {
+		return nil, err
+	}
+	calendars := make(map[string][]HolidayEntry, len(holidayList.Calendars))
+	for name, l := range holidayList.Calendars {
+		entries, err := l.entries(false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		calendars[name] = entries
+	}
+	return calendars, nil
+}
+
+// entries は holidays・overrides・workdays の定義を HolidayEntry のスライスにする
+// substitute_holidays の指定がなければ、substitute に従って振替休日・国民の休日を算出する
+func (holidayList HolidayList) entries(substitute bool) ([]HolidayEntry, error) {
+	var holidays []HolidayEntry
+	for _, h := range holidayList.Holidays {
+		e, err := h.Entry()
+		if err != nil {
+			return nil, err
+		}
+		// 差し替え対象の年は overrides 側の一覧を使う
+		if _, ok := holidayList.Overrides[e.Date.Year()]; ok {
+			continue
+		}
+		holidays = append(holidays, e)
+	}
+
+	for year, dates := range holidayList.Overrides {
+		for _, h := range dates {
+			e, err := h.Entry()
+			if err != nil {
+				return nil, err
+			}
+			if e.Date.Year() != year {
+				return nil, fmt.Errorf("%d 年の差し替えに別の年の日付が含まれています: %s", year, h.Date)
+			}
+			holidays = append(holidays, e)
+		}
+	}
+	if holidayList.SubstituteHolidays != nil {
+		substitute = *holidayList.SubstituteHolidays
+	}
+	if substitute {
+		holidays = SubstituteHolidays(holidays)
+	}
+
+	for _, h := range holidayList.Workdays {
+		e, err := h.Entry()
+		if err != nil {
+			return nil, err
+		}
+		e.Workday = true
+		holidays = append(holidays, e)
+	}
+	return holidays, nil
+}
+
+// Entry は YAML の定義をパースして HolidayEntry にする (日付は ParseDate の書式で書ける)
+func (h HolidayYAML) Entry() (HolidayEntry, error) {
+	e := HolidayEntry{Name: h.Name}
+	var err error
+	if e.Date, err = ParseDate(h.Date, time.UTC); err != nil {
+		return e, fmt.Errorf("祝日のパースに失敗: %w", err)
+	}
+	if h.ValidFrom != "" {
+		if e.ValidFrom, err = ParseDate(h.ValidFrom, time.UTC); err != nil {
+			return e, fmt.Errorf("valid_from のパースに失敗: %w", err)
+		}
+	}
+	if h.ValidTo != "" {
+		if e.ValidTo, err = ParseDate(h.ValidTo, time.UTC); err != nil {
+			return e, fmt.Errorf("valid_to のパースに失敗: %w", err)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,100 @@
+package bizday
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GoogleJapanHolidaysURL は Google カレンダーが公開している「日本の祝日」の iCalendar
+const GoogleJapanHolidaysURL = "https://calendar.google.com/calendar/ical/ja.japanese%23holiday%40group.v.calendar.google.com/public/basic.ics"
+
+// ParseICSHolidays は iCalendar (.ics) の終日の予定を祝日として HolidayEntry のスライスにする
+// 予定の名前 (SUMMARY) を祝日の名前にし、複数日にわたる予定は 1 日ずつの祝日にする
+// Google の祝日カレンダーで説明 (DESCRIPTION) が「記念日」の予定 (ひな祭りなど祝日でないもの) は含めない
+func ParseICSHolidays(data []byte) ([]HolidayEntry, error) {
+	var holidays []HolidayEntry
+	var event map[string]string
+	for _, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ";") // VALUE=DATE などのパラメータは使わない
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = map[string]string{}
+		case name == "END" && value == "VEVENT":
+			es, err := icsEventHolidays(event)
+			if err != nil {
+				return nil, err
+			}
+			holidays = append(holidays, es...)
+			event = nil
+		case event != nil:
+			event[name] = value
+		}
+	}
+	if len(holidays) == 0 {
+		return nil, fmt.Errorf("iCalendar に祝日がありません")
+	}
+	return holidays, nil
+}
+
+// icsEventHolidays は VEVENT 1 件のプロパティを祝日にする
+func icsEventHolidays(event map[string]string) ([]HolidayEntry, error) {
+	if strings.HasPrefix(unescapeICS(event["DESCRIPTION"]), "記念日") {
+		return nil, nil
+	}
+	start, err := parseICSDate(event["DTSTART"])
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 0, 1)
+	if v, ok := event["DTEND"]; ok {
+		if end, err = parseICSDate(v); err != nil {
+			return nil, err
+		}
+	}
+	name := unescapeICS(event["SUMMARY"])
+	var hs []HolidayEntry
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		hs = append(hs, HolidayEntry{Date: d, Name: name})
+	}
+	return hs, nil
+}
+
+// parseICSDate は DTSTART・DTEND の値 ("20250101" か "20250101T000000Z") の日付部分をパースする
+func parseICSDate(v string) (time.Time, error) {
+	if len(v) < 8 {
+		return time.Time{}, fmt.Errorf("iCalendar の日付のパースに失敗: %q", v)
+	}
+	d, err := time.Parse("20060102", v[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("iCalendar の日付のパースに失敗: %q", v)
+	}
+	return d, nil
+}
+
+// unfoldICS は折り返された行 (空白かタブで始まる継続行) をつないで 1 行ずつ返す
+func unfoldICS(data []byte) []string {
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// unescapeICS は TEXT 型の値のエスケープ (\\ \; \, \n) を戻す
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
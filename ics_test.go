@@ -0,0 +1,46 @@
+package bizday
+
+import "testing"
+
+func TestParseICSHolidays(t *testing.T) {
+	data := []byte("BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20250505\r\n" +
+		"DTEND;VALUE=DATE:20250506\r\n" +
+		"SUMMARY:こどもの日\r\n" +
+		"DESCRIPTION:祝日\\n祝日を非表示にするには\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20250303\r\n" +
+		"SUMMARY:ひな祭り\r\n" +
+		"DESCRIPTION:記念日\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20251229\r\n" +
+		"DTEND;VALUE=DATE:20251231\r\n" +
+		"SUMMARY:年末休み\\, 会社\r\n" +
+		" 指定\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n")
+	got, err := ParseICSHolidays(data)
+	if err != nil {
+		t.Fatalf("ParseICSHolidays: %v", err)
+	}
+	want := []HolidayEntry{
+		{Date: date(2025, 5, 5), Name: "こどもの日"},
+		{Date: date(2025, 12, 29), Name: "年末休み, 会社指定"},
+		{Date: date(2025, 12, 30), Name: "年末休み, 会社指定"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseICSHolidays = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Name != want[i].Name {
+			t.Errorf("[%d] = %s %q, want %s %q", i, got[i].Date.Format("2006-01-02"), got[i].Name, want[i].Date.Format("2006-01-02"), want[i].Name)
+		}
+	}
+
+	if _, err := ParseICSHolidays([]byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n")); err == nil {
+		t.Error("予定のない iCalendar でエラーにならない")
+	}
+}
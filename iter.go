@@ -0,0 +1,22 @@
+package bizday
+
+import (
+	"iter"
+	"time"
+)
+
+// BusinessDays は from~to (両端含む) の営業日を順に返すイテレータ (時刻は from のまま)
+// スライスを作らずに 1 日ずつ判定するので、複数年にわたる範囲でも range で途中まで読んで打ち切れる
+//
+//	for d := range cal.BusinessDays(start, end) {
+//		...
+//	}
+func (c *Calendar) BusinessDays(from, to time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			if c.IsBusinessDay(d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// 日本の祝日の生成規則 (国民の祝日に関する法律、1948 年 7 月 20 日施行)
+// 移動した祝日・名前の変わった祝日は、その年に有効だった規則で算出する
+
+// jpLawStart は国民の祝日に関する法律の施行日 (これより前の日付は祝日にしない)
+var jpLawStart = date(1948, time.July, 20)
+
+// jpRuleLastYear は春分・秋分の日の近似式が使える最後の年 (これより後の年は祝日を算出しない)
+const jpRuleLastYear = 2150
+
+// jpSpecialHolidays は法律で 1 回限り定められた休日 (皇室の慶弔など)
+var jpSpecialHolidays = []Holiday{
+	{date(1959, time.April, 10), "結婚の儀"},
+	{date(1989, time.February, 24), "大喪の礼"},
+	{date(1990, time.November, 12), "即位礼正殿の儀"},
+	{date(1993, time.June, 9), "結婚の儀"},
+	{date(2019, time.May, 1), "天皇の即位の日"},
+	{date(2019, time.October, 22), "即位礼正殿の儀"},
+}
+
+// jpOlympicMoves は東京オリンピック・パラリンピックに合わせて移動した祝日 (年 → 名前 → 日付)
+var jpOlympicMoves = map[int]map[string]time.Time{
+	2020: {"海の日": date(2020, time.July, 23), "スポーツの日": date(2020, time.July, 24), "山の日": date(2020, time.August, 10)},
+	2021: {"海の日": date(2021, time.July, 22), "スポーツの日": date(2021, time.July, 23), "山の日": date(2021, time.August, 8)},
+}
+
+// JapaneseHolidays は year 年の日本の祝日・休日 (振替休日・国民の休日を含む) を日付順に返す
+// 春分・秋分の日は天文計算の近似式で求めるため、官報で公示される前の年は予測値になる
+// 近似式の使えない jpRuleLastYear (2150 年) より後の年は空を返す
+func JapaneseHolidays(year int) []time.Time {
+	hs := japaneseHolidays(year)
+	ts := make([]time.Time, len(hs))
+	for i, h := range hs {
+		ts[i] = h.Date
+	}
+	return ts
+}
+
+// japaneseHolidays は year 年の日本の祝日・休日を名前付きで日付順に返す
+func japaneseHolidays(year int) []Holiday {
+	if year < jpLawStart.Year() || year > jpRuleLastYear {
+		return nil
+	}
+	national := jpNationalHolidays(year)
+	names := make(map[int32]string, len(national))
+	for _, h := range national {
+		names[epochDay(h.Date)] = h.Name
+	}
+	isNational := func(d time.Time) bool {
+		_, ok := names[epochDay(d)]
+		return ok
+	}
+
+	var extra []Holiday
+	// 振替休日 (1973 年 4 月 12 日から): 日曜の祝日の翌日
+	// 2007 年からは、日曜の祝日の後で最初の祝日でない日
+	for _, h := range national {
+		if h.Date.Weekday() != time.Sunday || h.Date.Before(date(1973, time.April, 12)) {
+			continue
+		}
+		d := h.Date.AddDate(0, 0, 1)
+		if year >= 2007 {
+			for isNational(d) {
+				d = d.AddDate(0, 0, 1)
+			}
+		} else if isNational(d) {
+			continue
+		}
+		if d.Year() == year {
+			extra = append(extra, Holiday{Date: d, Name: "振替休日"})
+			names[epochDay(d)] = "振替休日"
+		}
+	}
+
+	// 国民の休日 (1985 年 12 月 27 日から): 前日と翌日が祝日に挟まれた、祝日でない日
+	// 2006 年までは日曜と振替休日を除く
+	if year >= 1986 {
+		for _, h := range national {
+			d := h.Date.AddDate(0, 0, 1)
+			if d.Year() != year || isNational(d) || !isNational(d.AddDate(0, 0, 1)) {
+				continue
+			}
+			if _, taken := names[epochDay(d)]; taken {
+				continue
+			}
+			if year < 2007 && d.Weekday() == time.Sunday {
+				continue
+			}
+			extra = append(extra, Holiday{Date: d, Name: "国民の休日"})
+		}
+	}
+
+	hs := append(national, extra...)
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Date.Before(hs[j].Date) })
+	return hs
+}
+
+// jpNationalHolidays は year 年の「国民の祝日」(振替休日・国民の休日を除く) を返す
+func jpNationalHolidays(year int) []Holiday {
+	var hs []Holiday
+	add := func(d time.Time, name string) {
+		if moved, ok := jpOlympicMoves[year][name]; ok {
+			d = moved
+		}
+		if !d.Before(jpLawStart) {
+			hs = append(hs, Holiday{Date: d, Name: name})
+		}
+	}
+	mon := func(month time.Month, n int) time.Time {
+		return nthWeekday(year, month, time.Monday, n)
+	}
+
+	add(date(year, time.January, 1), "元日")
+	if year >= 2000 {
+		add(mon(time.January, 2), "成人の日")
+	} else {
+		add(date(year, time.January, 15), "成人の日")
+	}
+	if year >= 1967 {
+		add(date(year, time.February, 11), "建国記念の日")
+	}
+	if year >= 2020 {
+		add(date(year, time.February, 23), "天皇誕生日")
+	}
+	add(vernalEquinox(year), "春分の日")
+	switch {
+	case year >= 2007:
+		add(date(year, time.April, 29), "昭和の日")
+	case year >= 1989:
+		add(date(year, time.April, 29), "みどりの日")
+	default:
+		add(date(year, time.April, 29), "天皇誕生日")
+	}
+	add(date(year, time.May, 3), "憲法記念日")
+	if year >= 2007 {
+		add(date(year, time.May, 4), "みどりの日")
+	}
+	add(date(year, time.May, 5), "こどもの日")
+	switch {
+	case year >= 2003:
+		add(mon(time.July, 3), "海の日")
+	case year >= 1996:
+		add(date(year, time.July, 20), "海の日")
+	}
+	if year >= 2016 {
+		add(date(year, time.August, 11), "山の日")
+	}
+	switch {
+	case year >= 2003:
+		add(mon(time.September, 3), "敬老の日")
+	case year >= 1966:
+		add(date(year, time.September, 15), "敬老の日")
+	}
+	add(autumnalEquinox(year), "秋分の日")
+	switch {
+	case year >= 2020:
+		add(mon(time.October, 2), "スポーツの日")
+	case year >= 2000:
+		add(mon(time.October, 2), "体育の日")
+	case year >= 1966:
+		add(date(year, time.October, 10), "体育の日")
+	}
+	add(date(year, time.November, 3), "文化の日")
+	add(date(year, time.November, 23), "勤労感謝の日")
+	if year >= 1989 && year <= 2018 {
+		add(date(year, time.December, 23), "天皇誕生日")
+	}
+	for _, h := range jpSpecialHolidays {
+		if h.Date.Year() == year {
+			add(h.Date, h.Name)
+		}
+	}
+	return hs
+}
+
+// vernalEquinox は year 年の春分の日を近似式で求める (1900~2150 年)
+func vernalEquinox(year int) time.Time {
+	return equinox(year, time.March, []float64{20.8357, 20.8431, 21.8510})
+}
+
+// autumnalEquinox は year 年の秋分の日を近似式で求める (1900~2150 年)
+func autumnalEquinox(year int) time.Time {
+	return equinox(year, time.September, []float64{23.2588, 23.2488, 24.2488})
+}
+
+// equinox は 1980 年を基準にした近似式で春分・秋分の日を求める
+// base は 1900~1979 年、1980~2099 年、2100~2150 年それぞれの定数
+// 閏年の補正 (y-1983)/4・(y-1980)/4 は 0 に向けて切り捨てる (1983 年より前は負になるので、math.Floor では 1 日ずれる)
+func equinox(year int, month time.Month, base []float64) time.Time {
+	y := float64(year)
+	var day float64
+	switch {
+	case year < 1980:
+		day = base[0] + 0.242194*(y-1980) - float64((year-1983)/4)
+	case year < 2100:
+		day = base[1] + 0.242194*(y-1980) - float64((year-1980)/4)
+	default:
+		day = base[2] + 0.242194*(y-1980) - float64((year-1980)/4)
+	}
+	return date(year, month, int(day))
+}
+
+// NewJapanCalendarAsOf は祝日一覧 entries を at 時点の内容で使い、一覧にない年は規則で算出する日本のカレンダーを作る
+// 一覧に 1 件でも祝日がある年は一覧をそのまま使い、規則による算出は行わない
+func NewJapanCalendarAsOf(entries []HolidayEntry, at time.Time) *Calendar {
+	covered := map[int]bool{}
+	for _, e := range entries {
+		if !e.Workday {
+			covered[e.Date.Year()] = true
+		}
+	}
+	c := NewCalendarAsOf(entries, at)
+	c.Generate = func(year int) []Holiday {
+		if covered[year] {
+			return nil
+		}
+		return japaneseHolidays(year)
+	}
+	c.genCache = &yearCache{}
+	return c
+}
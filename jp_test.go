@@ -0,0 +1,243 @@
+package bizday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseHolidaysMatchesList(t *testing.T) {
+	entries, err := DefaultHolidays()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 一覧の年ごとに、会社独自の休み (年始休み) を除いた祝日が規則の算出結果と一致するか
+	want := map[int]map[string]string{}
+	for _, e := range entries {
+		if e.Workday || e.Name == "年始休み" {
+			continue
+		}
+		y := e.Date.Year()
+		if want[y] == nil {
+			want[y] = map[string]string{}
+		}
+		want[y][e.Date.Format("2006-01-02")] = e.Name
+	}
+	for year, days := range want {
+		got := japaneseHolidays(year)
+		if len(got) != len(days) {
+			t.Errorf("%d 年: %d 件, want %d 件", year, len(got), len(days))
+		}
+		for _, h := range got {
+			if name, ok := days[h.Date.Format("2006-01-02")]; !ok || name != h.Name {
+				t.Errorf("%d 年: %s %s が一覧にない", year, h.Date.Format("2006-01-02"), h.Name)
+			}
+		}
+	}
+}
+
+func TestEquinox(t *testing.T) {
+	// 1960~1979 年の春分の日・秋分の日 (国立天文台の暦象年表による)
+	autumn24 := map[int]bool{1963: true, 1967: true, 1971: true, 1975: true, 1979: true}
+	for year := 1960; year <= 1979; year++ {
+		spring, autumn := 21, 23
+		if year%4 == 0 {
+			spring = 20
+		}
+		if autumn24[year] {
+			autumn = 24
+		}
+		if got := vernalEquinox(year); !got.Equal(date(year, time.March, spring)) {
+			t.Errorf("%d 年の春分の日 = %s, want 3 月 %d 日", year, got.Format("2006-01-02"), spring)
+		}
+		if got := autumnalEquinox(year); !got.Equal(date(year, time.September, autumn)) {
+			t.Errorf("%d 年の秋分の日 = %s, want 9 月 %d 日", year, got.Format("2006-01-02"), autumn)
+		}
+	}
+
+	// 1978 年の秋分の日 (9/23) は土曜なので振替休日はない
+	for _, h := range japaneseHolidays(1978) {
+		if h.Date.Month() == time.September && h.Name == "振替休日" {
+			t.Errorf("1978 年に振替休日 %s がある", h.Date.Format("2006-01-02"))
+		}
+	}
+
+	// 近似式の範囲外の年は算出しない
+	for _, year := range []int{2151, 2300, 10000} {
+		if hs := japaneseHolidays(year); len(hs) != 0 {
+			t.Errorf("%d 年の祝日 = %d 件, want 0", year, len(hs))
+		}
+	}
+	if hs := japaneseHolidays(2150); len(hs) == 0 {
+		t.Error("2150 年の祝日が算出されない")
+	}
+}
+
+func TestJapaneseHolidaysHistorical(t *testing.T) {
+	tests := []struct {
+		day  time.Time
+		name string
+	}{
+		{date(1970, 1, 15), "成人の日"},  // ハッピーマンデー以前
+		{date(1985, 4, 29), "天皇誕生日"}, // 昭和
+		{date(1988, 5, 4), "国民の休日"},  // 憲法記念日とこどもの日に挟まれた日
+		{date(1989, 2, 24), "大喪の礼"},
+		{date(1995, 4, 29), "みどりの日"}, // 1989~2006 年
+		{date(1999, 10, 11), "振替休日"}, // 体育の日 10/10 が日曜
+		{date(2008, 5, 6), "振替休日"},   // 5/4 (日) の振替は連休明け
+		{date(2009, 9, 22), "国民の休日"}, // シルバーウィーク
+		{date(2015, 3, 21), "春分の日"},
+		{date(2018, 12, 24), "振替休日"}, // 天皇誕生日 (平成) が日曜
+		{date(2019, 4, 30), "国民の休日"},
+		{date(2019, 5, 2), "国民の休日"},
+		{date(2030, 9, 23), "秋分の日"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, h := range japaneseHolidays(tt.day.Year()) {
+			if h.Date.Equal(tt.day) {
+				found = true
+				if h.Name != tt.name {
+					t.Errorf("%s = %s, want %s", tt.day.Format("2006-01-02"), h.Name, tt.name)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s (%s) が祝日にならない", tt.day.Format("2006-01-02"), tt.name)
+		}
+	}
+
+	for _, d := range []time.Time{date(2019, 12, 23), date(1986, 5, 4), date(1948, 1, 1)} {
+		for _, h := range japaneseHolidays(d.Year()) {
+			if h.Date.Equal(d) {
+				t.Errorf("%s は祝日ではない (%s)", d.Format("2006-01-02"), h.Name)
+			}
+		}
+	}
+}
+
+func TestJapanCalendarFallback(t *testing.T) {
+	entries, err := DefaultHolidays()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := NewJapanCalendarAsOf(entries, date(2025, 6, 1))
+	// 一覧にある年は一覧のまま (2020 年の体育の日は五輪で移動済み)
+	if !cal.IsBusinessDay(date(2020, 10, 12)) {
+		t.Error("2020-10-12 は一覧では営業日")
+	}
+	// 一覧にない年は規則で算出する
+	if cal.IsBusinessDay(date(2030, 1, 14)) {
+		t.Error("2030-01-14 (成人の日) が祝日にならない")
+	}
+}
+
+func TestSubstituteHolidays(t *testing.T) {
+	// 規則で算出した祝日から振替休日・国民の休日を除いた一覧に対して、除いた分がそのまま算出されるか
+	for year := 1980; year <= 2030; year++ {
+		full := japaneseHolidays(year)
+		var base []HolidayEntry
+		for _, h := range full {
+			if h.Name != "振替休日" && h.Name != "国民の休日" {
+				base = append(base, HolidayEntry{Date: h.Date, Name: h.Name})
+			}
+		}
+		got := SubstituteHolidays(base)
+		if len(got) != len(full) {
+			t.Errorf("%d 年: %d 件, want %d 件", year, len(got), len(full))
+			continue
+		}
+		for i, h := range full {
+			if !got[i].Date.Equal(h.Date) || got[i].Name != h.Name {
+				t.Errorf("%d 年: %s %s, want %s %s", year, got[i].Date.Format("2006-01-02"), got[i].Name, h.Date.Format("2006-01-02"), h.Name)
+			}
+		}
+	}
+
+	// 会社独自の休みは元の祝日にせず、すでに休みの日には加えない
+	entries := []HolidayEntry{
+		{Date: date(2023, time.January, 1), Name: "元日"},
+		{Date: date(2023, time.January, 2), Name: "年始休み"},
+		{Date: date(2023, time.January, 3), Name: "年始休み"},
+	}
+	if got := SubstituteHolidays(entries); len(got) != 3 {
+		t.Errorf("年始休みと重なる振替休日 = %v, want 追加なし", got)
+	}
+}
+
+func TestParseHolidaysSubstitute(t *testing.T) {
+	data := []byte(`
+holidays:
+  - {date: "2026-05-03", name: 憲法記念日}
+  - {date: "2026-05-04", name: みどりの日}
+  - {date: "2026-05-05", name: こどもの日}
+calendars:
+  jp2:
+    substitute_holidays: true
+    holidays:
+      - {date: "2026-05-03", name: 憲法記念日}
+  other:
+    holidays:
+      - {date: "2026-05-03", name: 憲法記念日}
+`)
+	entries, err := ParseHolidays(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 || !entries[3].Date.Equal(date(2026, time.May, 6)) || entries[3].Name != "振替休日" {
+		t.Errorf("ParseHolidays = %v, want 2026-05-06 の振替休日を含む 4 件", entries)
+	}
+	cals, err := ParseHolidayCalendars(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cals["jp2"]) != 2 || len(cals["other"]) != 1 {
+		t.Errorf("calendars = jp2 %d 件, other %d 件, want 2 件と 1 件", len(cals["jp2"]), len(cals["other"]))
+	}
+}
+
+func TestDiffHolidays(t *testing.T) {
+	old, err := ParseHolidays([]byte(`
+substitute_holidays: false
+holidays:
+  - {date: "2020-07-20", name: 海の日}
+  - {date: "2020-10-12", name: 体育の日}
+  - {date: "2020-11-03", name: 文化の日}
+  - date: "2020-12-28"
+    name: 年末休み
+    valid_to: "2020-06-30"
+workdays:
+  - "2020-05-09"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := ParseHolidays([]byte(`
+substitute_holidays: false
+holidays:
+  - {date: "2020-07-23", name: 海の日}
+  - {date: "2020-10-12", name: スポーツの日}
+  - {date: "2020-11-03", name: 文化の日}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2020-12-28 は 2025 年時点では古いデータでも無効なので変更に含めない
+	want := []HolidayChange{
+		{Kind: HolidayRemoved, Date: date(2020, 5, 9), Workday: true},
+		{Kind: HolidayRemoved, Date: date(2020, 7, 20), OldName: "海の日"},
+		{Kind: HolidayAdded, Date: date(2020, 7, 23), NewName: "海の日"},
+		{Kind: HolidayRenamed, Date: date(2020, 10, 12), OldName: "体育の日", NewName: "スポーツの日"},
+	}
+	got := DiffHolidays(old, new, date(2025, 1, 1))
+	if len(got) != len(want) {
+		t.Fatalf("DiffHolidays = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got := DiffHolidays(old, old, date(2025, 1, 1)); len(got) != 0 {
+		t.Errorf("同じデータの差分 = %+v", got)
+	}
+}
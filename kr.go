@@ -0,0 +1,134 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// krSubstitute は韓国の代替公休日 (대체공휴일) の適用条件
+type krSubstitute int
+
+const (
+	krNoSubstitute     krSubstitute = iota
+	krSundayOrOverlap               // 日曜または他の祝日と重なった場合 (설날・추석)
+	krWeekendOrOverlap              // 土日または他の祝日と重なった場合
+)
+
+// krHoliday は代替公休日の判定に使う情報を持つ韓国の祝日
+type krHoliday struct {
+	Holiday
+	rule krSubstitute
+	// group は連続する祝日 (설날・추석の 3 連休) の最終日、単日の祝日なら Date と同じ
+	group time.Time
+}
+
+// krTemporaryHolidays は臨時公休日と選挙日
+var krTemporaryHolidays = map[int][]Holiday{
+	2022: {{date(2022, time.March, 9), "제20대 대통령 선거"}, {date(2022, time.June, 1), "제8회 전국동시지방선거"}},
+	2023: {{date(2023, time.October, 2), "임시공휴일"}},
+	2024: {{date(2024, time.April, 10), "제22대 국회의원 선거"}, {date(2024, time.October, 1), "국군의 날 임시공휴일"}},
+	2025: {{date(2025, time.January, 27), "임시공휴일"}, {date(2025, time.June, 3), "제21대 대통령 선거"}},
+}
+
+// koreanHolidays は year 年の韓国の公休日を代替公休日込みで返す
+// 설날・추석・부처님오신날は旧暦 (UTC+9) から算出する
+func koreanHolidays(year int) []Holiday {
+	var base []krHoliday
+	add := func(d time.Time, name string, rule krSubstitute) {
+		base = append(base, krHoliday{Holiday{d, name}, rule, d})
+	}
+	addGroup := func(center time.Time, name string) {
+		last := center.AddDate(0, 0, 1)
+		for i := -1; i <= 1; i++ {
+			base = append(base, krHoliday{Holiday{center.AddDate(0, 0, i), name}, krSundayOrOverlap, last})
+		}
+	}
+
+	// 2021 年 8 月以降は三一節・光復節・開天節・ハングルの日、2023 年 5 月以降は釈迦誕生日・クリスマスにも拡大
+	national := krNoSubstitute
+	if year >= 2021 {
+		national = krWeekendOrOverlap
+	}
+	later := krNoSubstitute
+	if year >= 2023 {
+		later = krWeekendOrOverlap
+	}
+	seollalRule, childrenRule := krNoSubstitute, krNoSubstitute
+	if year >= 2014 {
+		seollalRule, childrenRule = krSundayOrOverlap, krWeekendOrOverlap
+	}
+
+	add(date(year, time.January, 1), "신정", krNoSubstitute)
+	if year == 2021 {
+		// 三一節への拡大は 2022 年から
+		add(date(year, time.March, 1), "삼일절", krNoSubstitute)
+	} else {
+		add(date(year, time.March, 1), "삼일절", national)
+	}
+	add(date(year, time.May, 5), "어린이날", childrenRule)
+	add(fromLunar(year, 4, 8), "부처님오신날", later)
+	add(date(year, time.June, 6), "현충일", krNoSubstitute)
+	add(date(year, time.August, 15), "광복절", national)
+	add(date(year, time.October, 3), "개천절", national)
+	if year >= 2013 {
+		add(date(year, time.October, 9), "한글날", national)
+	}
+	add(date(year, time.December, 25), "기독탄신일", later)
+	addGroup(fromLunar(year, 1, 1), "설날")
+	addGroup(fromLunar(year, 8, 15), "추석")
+	if seollalRule == krNoSubstitute {
+		for i := range base {
+			if base[i].rule == krSundayOrOverlap {
+				base[i].rule = krNoSubstitute
+			}
+		}
+	}
+
+	var hs []Holiday
+	for _, h := range base {
+		hs = append(hs, h.Holiday)
+	}
+	hs = append(hs, krTemporaryHolidays[year]...)
+
+	// 代替公休日: 条件に当たった祝日 (連休ならその最終日) の後で最初の、土日でも祝日でもない日
+	// 同じ日に重なった祝日どうしには 1 日だけ与える
+	var triggered []Holiday
+	for _, h := range base {
+		if !krNeedsSubstitute(h, base) || containsDate(triggered, h.Date) {
+			continue
+		}
+		triggered = append(triggered, h.Holiday)
+		d := h.group.AddDate(0, 0, 1)
+		for isWeekend(d) || containsDate(hs, d) {
+			d = d.AddDate(0, 0, 1)
+		}
+		if d.Year() == year {
+			hs = append(hs, Holiday{d, "대체공휴일(" + h.Name + ")"})
+		}
+	}
+
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Date.Before(hs[j].Date) })
+	return hs
+}
+
+// krNeedsSubstitute は h に代替公休日が必要かどうかを判定
+func krNeedsSubstitute(h krHoliday, all []krHoliday) bool {
+	switch h.rule {
+	case krNoSubstitute:
+		return false
+	case krSundayOrOverlap:
+		if h.Date.Weekday() == time.Sunday {
+			return true
+		}
+	case krWeekendOrOverlap:
+		if isWeekend(h.Date) {
+			return true
+		}
+	}
+	for _, o := range all {
+		if o.Name != h.Name && isSameDay(o.Date, h.Date) {
+			return true
+		}
+	}
+	return false
+}
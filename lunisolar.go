@@ -0,0 +1,262 @@
+package bizday
+
+import (
+	"math"
+	"time"
+)
+
+// 旧暦 (太陰太陽暦) の計算
+//
+// 日本標準時 (UTC+9) で朔 (新月) を含む日を月の初日とし、冬至を含む月を 11 月、
+// 冬至から次の冬至までに 13 か月ある年は中気を含まない最初の月を閏月とする。
+// 天体位置は Jean Meeus "Astronomical Algorithms" の略算式による。
+
+// LunarDate は旧暦の年月日
+type LunarDate struct {
+	Year  int
+	Month int
+	Leap  bool // 閏月かどうか
+	Day   int
+}
+
+// lunarOffset は旧暦の日付の区切りに使うタイムゾーン (UTC からの時間)
+const lunarOffset = 9.0
+
+// ToLunar は t の日付 (t のロケーションの年月日) を旧暦に変換する
+func ToLunar(t time.Time) LunarDate {
+	y, m, d := t.Date()
+	day := dayNumber(y, m, d)
+
+	k := lunationBefore(day)
+
+	// 対象日を含む「冬至の月 (11 月)」から数えて何か月目かを求める
+	year := y
+	k11 := lunationBefore(winterSolsticeDay(year))
+	if k < k11 {
+		year--
+		k11 = lunationBefore(winterSolsticeDay(year))
+	}
+	k11Next := lunationBefore(winterSolsticeDay(year + 1))
+
+	n := k - k11
+	leap := false
+	if k11Next-k11 == 13 {
+		for j := 1; j <= 12; j++ {
+			if hasMajorTerm(k11 + j) {
+				continue
+			}
+			if n == j {
+				leap = true
+			}
+			if n >= j {
+				n--
+			}
+			break
+		}
+	}
+
+	month := (11+n-1)%12 + 1
+	lunarYear := year
+	if month < 11 {
+		lunarYear++
+	}
+	return LunarDate{Year: lunarYear, Month: month, Leap: leap, Day: day - newMoonDay(k) + 1}
+}
+
+// dayNumber は年月日をユリウス通日 (日単位) に変換する
+func dayNumber(y int, m time.Month, d int) int {
+	return int(time.Date(y, m, d, 12, 0, 0, 0, time.UTC).Unix()/86400) + 2440588
+}
+
+// newMoonDay は k 番目 (2000 年 1 月 6 日を 0 とする) の朔を含む日の通日を返す
+func newMoonDay(k int) int {
+	jd := newMoonJDE(float64(k)) - deltaT(2000+float64(k)/12.3685)/86400
+	return int(math.Floor(jd + 0.5 + lunarOffset/24))
+}
+
+// lunationBefore は通日 day 以前で最も近い朔の番号を返す
+func lunationBefore(day int) int {
+	k := int(math.Floor(float64(day-2451550) / 29.530588861))
+	for newMoonDay(k) > day {
+		k--
+	}
+	for newMoonDay(k+1) <= day {
+		k++
+	}
+	return k
+}
+
+// winterSolsticeDay は year 年の冬至を含む日の通日を返す
+func winterSolsticeDay(year int) int {
+	jde := solarTermJDE(float64(year)+0.97, 270)
+	return int(math.Floor(jde - deltaT(float64(year))/86400 + 0.5 + lunarOffset/24))
+}
+
+// hasMajorTerm は k 番目の朔から始まる月が中気 (太陽黄経が 30 度の倍数になる瞬間) を含むかを判定
+func hasMajorTerm(k int) bool {
+	start, end := newMoonDay(k), newMoonDay(k+1)
+	l1 := sunLongitude(dayStartJDE(start))
+	l2 := sunLongitude(dayStartJDE(end))
+	return math.Floor(l1/30) != math.Floor(l2/30)
+}
+
+// dayStartJDE は通日 day の 0:00 (UTC+9) の力学時ユリウス日を返す
+func dayStartJDE(day int) float64 {
+	jd := float64(day) - 0.5 - lunarOffset/24
+	return jd + deltaT(2000+(jd-2451545)/365.25)/86400
+}
+
+// newMoonJDE は k 番目の朔の力学時ユリウス日 (Meeus 49 章)
+func newMoonJDE(k float64) float64 {
+	t := k / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+	jde := 2451550.09766 + 29.530588861*k + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := rad(2.5534 + 29.10535670*k - 0.0000014*t2 - 0.00000011*t3)
+	mp := rad(201.5643 + 385.81693528*k + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4)
+	f := rad(160.7108 + 390.67050284*k - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4)
+	om := rad(124.7746 - 1.56375588*k + 0.0020672*t2 + 0.00000215*t3)
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(om) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	// 惑星による補正
+	planetary := [...][2]float64{
+		{0.000325, 299.77 + 0.107408*k - 0.009173*t2},
+		{0.000165, 251.88 + 0.016321*k},
+		{0.000164, 251.83 + 26.651886*k},
+		{0.000126, 349.42 + 36.412478*k},
+		{0.000110, 84.66 + 18.206239*k},
+		{0.000062, 141.74 + 53.303771*k},
+		{0.000060, 207.14 + 2.453732*k},
+		{0.000056, 154.84 + 7.306860*k},
+		{0.000047, 34.52 + 27.261239*k},
+		{0.000042, 207.19 + 0.121824*k},
+		{0.000040, 291.34 + 1.844379*k},
+		{0.000037, 161.72 + 24.198154*k},
+		{0.000035, 239.56 + 25.513099*k},
+		{0.000023, 331.55 + 3.592518*k},
+	}
+	for _, p := range planetary {
+		jde += p[0] * math.Sin(rad(p[1]))
+	}
+	return jde
+}
+
+// sunLongitude は力学時ユリウス日 jde における太陽の視黄経 (度, 0~360) を返す (Meeus 25 章の略算式)
+func sunLongitude(jde float64) float64 {
+	t := (jde - 2451545) / 36525
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := rad(357.52911 + 35999.05029*t - 0.0001537*t*t)
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) +
+		(0.019993-0.000101*t)*math.Sin(2*m) +
+		0.000289*math.Sin(3*m)
+	om := rad(125.04 - 1934.136*t)
+	return normalizeDegrees(l0 + c - 0.00569 - 0.00478*math.Sin(om))
+}
+
+// solarTermJDE は year (小数可) 付近で太陽の視黄経が target 度になる力学時ユリウス日を返す
+func solarTermJDE(year, target float64) float64 {
+	jde := 2451545 + (year-2000)*365.2422
+	for i := 0; i < 50; i++ {
+		diff := normalizeDegrees(target-sunLongitude(jde)+180) - 180
+		if math.Abs(diff) < 1e-6 {
+			break
+		}
+		jde += diff * 365.2422 / 360
+	}
+	return jde
+}
+
+// deltaT は力学時と世界時の差 (秒) の近似値 (Espenak & Meeus の多項式)
+func deltaT(year float64) float64 {
+	switch {
+	case year < 1900:
+		t := year - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*t*t*t*t + t*t*t*t*t/233174
+	case year < 1920:
+		t := year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case year < 1941:
+		t := year - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case year < 1961:
+		t := year - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-year)
+	}
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// fromLunar は旧暦 year 年 month 月 day 日 (閏月でない月) の日付を UTC の 0:00 で返す
+func fromLunar(year, month, day int) time.Time {
+	k11 := lunationBefore(winterSolsticeDay(year - 1))
+	for j := 1; j <= 15; j++ {
+		start := dayToDate(newMoonDay(k11 + j))
+		l := ToLunar(start)
+		if l.Year == year && l.Month == month && !l.Leap {
+			return start.AddDate(0, 0, day-1)
+		}
+	}
+	return time.Time{}
+}
+
+// dayToDate は通日 day を UTC の 0:00 の日付に変換する
+func dayToDate(day int) time.Time {
+	return time.Date(1970, 1, 1+day-2440588, 0, 0, 0, 0, time.UTC)
+}
+
+// rokuyoNames は (旧暦の月 + 日) を 6 で割った余りに対応する六曜
+var rokuyoNames = [...]string{"大安", "赤口", "先勝", "友引", "先負", "仏滅"}
+
+// Rokuyo は t の日付の六曜を返す
+func Rokuyo(t time.Time) string {
+	l := ToLunar(t)
+	return rokuyoNames[(l.Month+l.Day)%6]
+}
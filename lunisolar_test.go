@@ -0,0 +1,29 @@
+package bizday
+
+import "testing"
+
+func TestToLunar(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		want             LunarDate
+	}{
+		{2025, 1, 29, LunarDate{Year: 2025, Month: 1, Day: 1}}, // 旧正月
+		{2023, 3, 22, LunarDate{Year: 2023, Month: 2, Leap: true, Day: 1}},
+		{2025, 7, 25, LunarDate{Year: 2025, Month: 6, Leap: true, Day: 1}},
+		{2020, 5, 23, LunarDate{Year: 2020, Month: 4, Leap: true, Day: 1}},
+		{2017, 6, 24, LunarDate{Year: 2017, Month: 5, Leap: true, Day: 1}},
+	}
+	for _, tt := range tests {
+		got := ToLunar(date(tt.year, 1, 1).AddDate(0, tt.month-1, tt.day-1))
+		if got != tt.want {
+			t.Errorf("ToLunar(%d-%02d-%02d) = %+v, want %+v", tt.year, tt.month, tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestRokuyo(t *testing.T) {
+	// 旧暦 1 月 1 日は先勝
+	if got := Rokuyo(date(2025, 1, 29)); got != "先勝" {
+		t.Errorf("Rokuyo(2025-01-29) = %s, want 先勝", got)
+	}
+}
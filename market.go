@@ -0,0 +1,82 @@
+package bizday
+
+import "time"
+
+// 取引所の休場日のカレンダー (tse・nyse)
+// 取引所の休場日は国の祝日と一致しないので、祝日のカレンダーとは別に登録する
+
+// tseTradingHours は東京証券取引所の立会時間 (前場 9:00~11:30、後場 12:30~15:30)
+var tseTradingHours = WorkHours{
+	Start:      9 * time.Hour,
+	End:        15*time.Hour + 30*time.Minute,
+	BreakStart: 11*time.Hour + 30*time.Minute,
+	BreakEnd:   12*time.Hour + 30*time.Minute,
+}
+
+// nyseTradingHours はニューヨーク証券取引所の取引時間 (9:30~16:00、昼休みなし)
+var nyseTradingHours = WorkHours{
+	Start:      9*time.Hour + 30*time.Minute,
+	End:        16 * time.Hour,
+	BreakStart: 16 * time.Hour,
+	BreakEnd:   16 * time.Hour,
+}
+
+// tseHolidays は year 年の東京証券取引所の休場日 (土日を除く) を返す
+// 日本の祝日・休日に加えて、年末年始の 12 月 31 日と 1 月 2 日・3 日を休場とする
+func tseHolidays(year int) []Holiday {
+	hs := japaneseHolidays(year)
+	hs = append(hs,
+		Holiday{date(year, time.January, 2), "年始休場日"},
+		Holiday{date(year, time.January, 3), "年始休場日"},
+		Holiday{date(year, time.December, 31), "年末休場日"},
+	)
+	return hs
+}
+
+// nyseSpecialClosures は NYSE の臨時休場日 (国葬・災害など)
+var nyseSpecialClosures = []Holiday{
+	{date(1994, time.April, 27), "National Day of Mourning for Richard Nixon"},
+	{date(2001, time.September, 11), "September 11 Attacks"},
+	{date(2001, time.September, 12), "September 11 Attacks"},
+	{date(2001, time.September, 13), "September 11 Attacks"},
+	{date(2001, time.September, 14), "September 11 Attacks"},
+	{date(2004, time.June, 11), "National Day of Mourning for Ronald Reagan"},
+	{date(2007, time.January, 2), "National Day of Mourning for Gerald Ford"},
+	{date(2012, time.October, 29), "Hurricane Sandy"},
+	{date(2012, time.October, 30), "Hurricane Sandy"},
+	{date(2018, time.December, 5), "National Day of Mourning for George H.W. Bush"},
+	{date(2025, time.January, 9), "National Day of Mourning for Jimmy Carter"},
+}
+
+// nyseHolidays は year 年のニューヨーク証券取引所の休場日を返す
+// 連邦祝日のうち Columbus Day・Veterans Day は取引があり、代わりに Good Friday が休場になる
+// 土曜の休場日は前の金曜、日曜の休場日は翌月曜に振り替える。ただし元日が土曜のときは前年の 12/31 を休場にしない (NYSE Rule 7.2)
+func nyseHolidays(year int) []Holiday {
+	var hs []Holiday
+	add := func(d time.Time, name string) {
+		hs = append(hs, Holiday{Date: d, Name: name})
+	}
+
+	if newYear := date(year, time.January, 1); newYear.Weekday() != time.Saturday {
+		add(newYear, "New Year's Day")
+	}
+	if year >= 1998 {
+		add(nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day")
+	}
+	add(nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday")
+	add(easterSunday(year).AddDate(0, 0, -2), "Good Friday")
+	add(nthWeekday(year, time.May, time.Monday, -1), "Memorial Day")
+	if year >= 2022 {
+		add(date(year, time.June, 19), "Juneteenth National Independence Day")
+	}
+	add(date(year, time.July, 4), "Independence Day")
+	add(nthWeekday(year, time.September, time.Monday, 1), "Labor Day")
+	add(nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day")
+	add(date(year, time.December, 25), "Christmas Day")
+	for _, h := range nyseSpecialClosures {
+		if h.Date.Year() == year {
+			hs = append(hs, h)
+		}
+	}
+	return observeYear(hs, USObservance, year)
+}
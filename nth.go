@@ -0,0 +1,46 @@
+package bizday
+
+import (
+	"fmt"
+	"time"
+)
+
+// NthBusinessDay は year 年 month 月の第 n 営業日 (time.Local の 0:00) を返す
+// n が負なら月末から数え、-1 が最終営業日になる
+// n が 0 の場合や、月の営業日が n 日に満たない場合はエラー
+func (c *Calendar) NthBusinessDay(year int, month time.Month, n int) (time.Time, error) {
+	if n == 0 {
+		return time.Time{}, fmt.Errorf("n には 0 以外を指定してください")
+	}
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, -1)
+	d, step, want := start, 1, n
+	if n < 0 {
+		d, step, want = end, -1, -n
+	}
+	count := 0
+	for ; d.Month() == month; d = d.AddDate(0, 0, step) {
+		if c.IsBusinessDay(d) {
+			if count++; count == want {
+				return d, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%d年%d月の営業日は %d 日しかありません", year, month, count)
+}
+
+// LastBusinessDayOfMonth は year 年 month 月の最終営業日を返す
+func (c *Calendar) LastBusinessDayOfMonth(year int, month time.Month) (time.Time, error) {
+	return c.NthBusinessDay(year, month, -1)
+}
+
+// PaydayAdjusted は year 年 month 月の day 日 (月の日数を超えるなら月末) を conv に従って営業日にずらして返す (time.Local の 0:00)
+// 給料日が土日祝日に当たったら前の営業日に払う、のような日付は RollPreceding で求める
+func (c *Calendar) PaydayAdjusted(year int, month time.Month, day int, conv RollConvention) (time.Time, error) {
+	if day < 1 || day > 31 {
+		return time.Time{}, fmt.Errorf("日には 1~31 を指定してください: %d", day)
+	}
+	end := time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local)
+	d := time.Date(year, month, min(day, end.Day()), 0, 0, 0, 0, time.Local)
+	return c.Roll(d, conv)
+}
@@ -0,0 +1,97 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// Observance は土日に当たった祝日を平日に振り替える国ごとの規則
+// 祝日の一覧には本来の日付だけを書いておき、振替日は ObservedProvider で算出する
+type Observance struct {
+	// Shift は土日の祝日 d の振替日を返す。taken はその日がすでにほかの祝日 (振替日を含む) で休みかどうかを返す
+	Shift func(d time.Time, taken func(time.Time) bool) time.Time
+	// Suffix は振り替えた祝日の名前に付ける文字列
+	Suffix string
+}
+
+var (
+	// USObservance は米国式の振替 (土曜の祝日は前の金曜、日曜の祝日は翌月曜)
+	USObservance = Observance{
+		Shift:  func(d time.Time, _ func(time.Time) bool) time.Time { return observedNearest(d) },
+		Suffix: " (observed)",
+	}
+	// UKObservance は英国式の振替 (土日の祝日は次の空いている平日。クリスマスが土曜なら翌週の月曜と火曜が休みになる)
+	UKObservance = Observance{
+		Shift: func(d time.Time, taken func(time.Time) bool) time.Time {
+			for isWeekend(d) || taken(d) {
+				d = d.AddDate(0, 0, 1)
+			}
+			return d
+		},
+		Suffix: " (substitute day)",
+	}
+)
+
+// observances は組み込みカレンダーの名前ごとの振替の規則
+var observances = map[string]Observance{
+	"us":     USObservance,
+	"uk":     UKObservance,
+	"uk-sct": UKObservance,
+	"uk-ni":  UKObservance,
+}
+
+func init() {
+	for state := range usStateDays {
+		observances["us-"+state] = USObservance
+	}
+}
+
+// ObservanceFor は組み込みカレンダー name ("us" や "uk" など) の振替の規則を返す
+// 振替の規則がない国 (日本のように一覧に振替休日を書く国を含む) なら false を返す
+func ObservanceFor(name string) (Observance, bool) {
+	o, ok := observances[name]
+	return o, ok
+}
+
+// ObservedProvider は p の祝日のうち土日に当たるものを o の規則で振り替えて返す HolidayProvider
+// 振替日は年をまたぐことがある (米国では 1/1 が土曜なら前年の 12/31 が休み) ので、p には前後の年も問い合わせる
+func ObservedProvider(p HolidayProvider, o Observance) HolidayProvider {
+	return HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		var hs []Holiday
+		for y := year - 1; y <= year+1; y++ {
+			got, err := p.Holidays(y)
+			if err != nil {
+				return nil, err
+			}
+			hs = append(hs, got...)
+		}
+		return observeYear(hs, o, year), nil
+	})
+}
+
+// observeYear は hs の祝日を o の規則で振り替え、振替後の日付が year 年のものを日付順に返す
+// 振替日は日付の早い祝日から順に決める。土日の祝日のほか、それより前の祝日の振替日と重なった祝日も振り替える
+// (スコットランドで 1/1 が日曜なら、1/2 の月曜が元日の振替日になり、2nd January は 1/3 に移る)
+func observeYear(hs []Holiday, o Observance, year int) []Holiday {
+	hs = append([]Holiday{}, hs...)
+	sort.SliceStable(hs, func(i, j int) bool { return hs[i].Date.Before(hs[j].Date) })
+	taken := map[int32]bool{}
+	var out []Holiday
+	for i, h := range hs {
+		if i > 0 && isSameDay(h.Date, hs[i-1].Date) && h.Name == hs[i-1].Name {
+			continue // 複数の取得元にある同じ祝日
+		}
+		if k := epochDay(h.Date); isWeekend(h.Date) || taken[k] {
+			d := o.Shift(h.Date, func(t time.Time) bool { return taken[epochDay(t)] })
+			if !d.Equal(h.Date) {
+				h = Holiday{Date: d, Name: h.Name + o.Suffix}
+			}
+		}
+		taken[epochDay(h.Date)] = true
+		if h.Date.Year() == year {
+			out = append(out, h)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
@@ -0,0 +1,39 @@
+package bizday
+
+import (
+	"errors"
+	"time"
+)
+
+// NonBusinessDay は営業日でない日とその理由
+type NonBusinessDay struct {
+	Date    time.Time
+	Weekend bool   // 定休日に当たる
+	Holiday bool   // 祝日に当たる
+	Closure bool   // 祝日のうち、会社独自の休業日 (WithExtra・WithClosureRules・WithCustomRule で重ねたもの) に当たる
+	Name    string // 祝日の名前 (分からなければ空)
+}
+
+// NonBusinessDays は start~end (両端含む) の営業日でない日を理由付きで返す
+// 振替出勤日は営業日なので含まれない
+func (c *Calendar) NonBusinessDays(start, end time.Time) ([]NonBusinessDay, error) {
+	if end.Before(start) {
+		return nil, errors.New("end は start より後の日付を指定してください")
+	}
+
+	var days []NonBusinessDay
+	for d := BeginningOfDay(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			continue
+		}
+		n := NonBusinessDay{Date: d, Weekend: c.IsWeekend(d)}
+		if class, ok := c.customClass(d); ok {
+			n.Weekend = class == ClassWeekend
+		}
+		if h, ok := c.holidayOn(d); ok {
+			n.Holiday, n.Closure, n.Name = true, c.isClosure(d), h.Name
+		}
+		days = append(days, n)
+	}
+	return days, nil
+}
@@ -0,0 +1,52 @@
+package bizday
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessDayOfYear は t がその年の何営業日目かを返す (1 月 1 日から t までの両端を含む営業日数)
+// t が休業日なら直前の営業日と同じ値 (年初の休業日なら 0) になる
+func (c *Calendar) BusinessDayOfYear(t time.Time) int {
+	n, _ := c.CountBusinessDays(time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location()), t)
+	return n
+}
+
+// BusinessDaysSince は epoch を 0 とする t の営業日の通し番号を返す
+// t が epoch より後なら (epoch, t] の営業日数、前なら (t, epoch] の営業日数の負の値なので、
+// epoch の前後を通して営業日ごとに 1 ずつ増え、休業日は直前の営業日と同じ値になる
+// 時系列の特徴量のように、日付を単調に増える営業日の番号に変えるのに使う
+func (c *Calendar) BusinessDaysSince(epoch, t time.Time) int {
+	from, to := BeginningOfDay(epoch), BeginningOfDay(t)
+	switch {
+	case from.Before(to):
+		n, _ := c.CountBusinessDays(from.AddDate(0, 0, 1), to)
+		return n
+	case to.Before(from):
+		n, _ := c.CountBusinessDays(to.AddDate(0, 0, 1), from)
+		return -n
+	}
+	return 0
+}
+
+// DateOfBusinessDayOrdinal は year 年の第 n 営業日 (time.Local の 0:00) を返す (BusinessDayOfYear の逆)
+// n が負なら年末から数え、-1 がその年の最終営業日になる
+// n が 0 の場合や、年の営業日が n 日に満たない場合はエラー
+func (c *Calendar) DateOfBusinessDayOrdinal(year, n int) (time.Time, error) {
+	if n == 0 {
+		return time.Time{}, fmt.Errorf("n には 0 以外を指定してください")
+	}
+	d, step, want := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local), 1, n
+	if n < 0 {
+		d, step, want = time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local), -1, -n
+	}
+	count := 0
+	for ; d.Year() == year; d = d.AddDate(0, 0, step) {
+		if c.IsBusinessDay(d) {
+			if count++; count == want {
+				return d, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%d年の営業日は %d 日しかありません", year, count)
+}
@@ -0,0 +1,31 @@
+package bizday
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFormats は ParseDate が受け付ける日付の書式 (エラーメッセージや --help の説明に使う)
+var DateFormats = []string{"2006-01-02", "2006/01/02", "20060102", "RFC3339 (2006-01-02T15:04:05Z07:00)"}
+
+// dateLayouts は ParseDate が順に試す日付だけの書式
+// 月と日は 1 桁でもよい (2025-5-7, 2025/5/7)
+var dateLayouts = []string{"2006-1-2", "2006/1/2", "20060102"}
+
+// ParseDate は s を日付としてパースし、loc でのその日の 0:00 を返す
+// 2006-01-02・2006/01/02・20060102 の形式と、RFC3339 の日時 (時刻は切り捨て、日付は書かれたオフセットでのもの) を受け付ける
+// どれにも当てはまらなければ、受け付ける書式を並べたエラーを返す
+func ParseDate(s string, loc *time.Location) (time.Time, error) {
+	v := strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("日付の形式が不正です: %q (%s のいずれかで指定してください)", s, strings.Join(DateFormats, ", "))
+}
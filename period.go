@@ -0,0 +1,157 @@
+package bizday
+
+import (
+	"fmt"
+	"time"
+)
+
+// PeriodStats は任意の期間の営業日の経過状況
+type PeriodStats struct {
+	Start        time.Time // 期間の初日
+	End          time.Time // 期間の末日
+	BusinessDays int       // 期間の営業日数
+	Elapsed      int       // 初日から t まで (t を含む) の営業日数
+	Remaining    int       // t より後の残り営業日数
+}
+
+// PeriodStats は start~end (両端含む) の営業日数と、t 時点の経過・残り営業日数を返す
+// t が期間より前ならすべて残り、期間より後ならすべて経過として数える
+func (c *Calendar) PeriodStats(start, end, t time.Time) PeriodStats {
+	s := PeriodStats{Start: BeginningOfDay(start), End: BeginningOfDay(end)}
+	for d := s.Start; !d.After(s.End); d = d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		s.BusinessDays++
+		if !d.After(t) {
+			s.Elapsed++
+		}
+	}
+	s.Remaining = s.BusinessDays - s.Elapsed
+	return s
+}
+
+// Period は基準日 anchor を含む集計期間の初日と末日 (両端含む) を返す (Summary に渡す)
+type Period func(anchor time.Time) (start, end time.Time)
+
+var (
+	// MonthPeriod は anchor を含む月
+	MonthPeriod Period = func(t time.Time) (time.Time, time.Time) {
+		return BeginningOfMonth(t), BeginningOfDay(EndOfMonth(t))
+	}
+	// WeekPeriod は anchor を含む ISO 週 (月曜~日曜)
+	WeekPeriod Period = ISOWeek
+)
+
+// QuarterPeriod は startMonth 月始まりの会計年度で anchor を含む四半期 (1 月始まりなら暦の四半期)
+func QuarterPeriod(startMonth time.Month) Period {
+	return func(t time.Time) (time.Time, time.Time) {
+		_, start, end := FiscalQuarter(t, startMonth)
+		return start, end
+	}
+}
+
+// FiscalYearPeriod は startMonth 月始まりの会計年度で anchor を含む年度
+func FiscalYearPeriod(startMonth time.Month) Period {
+	return func(t time.Time) (time.Time, time.Time) {
+		start, end, _ := FiscalYear(t, startMonth)
+		return start, end
+	}
+}
+
+// RangePeriod は anchor によらない from~to の期間
+func RangePeriod(from, to time.Time) Period {
+	return func(time.Time) (time.Time, time.Time) {
+		return BeginningOfDay(from), BeginningOfDay(to)
+	}
+}
+
+// Summary は期間の営業日・暦日の経過状況
+type Summary struct {
+	Start           time.Time // 期間の初日
+	End             time.Time // 期間の末日
+	Total           int       // 期間の営業日数
+	Elapsed         int       // 初日から anchor まで (anchor を含む) の営業日数 = anchor が何営業日目か
+	Remaining       int       // anchor より後の残り営業日数
+	Percent         float64   // 営業日の経過率 (%)、営業日がなければ 0
+	CalendarDays    int       // 期間の暦日数
+	CalendarElapsed int       // 初日から anchor まで (anchor を含む) の暦日数
+}
+
+// Summary は period の anchor を含む期間について、anchor 時点の営業日の経過状況を返す
+// anchor が期間より前ならすべて残り、期間より後ならすべて経過として数える (暦日も同じ)
+func (c *Calendar) Summary(anchor time.Time, period Period) Summary {
+	start, end := period(anchor)
+	st := c.PeriodStats(start, end, anchor)
+	s := Summary{
+		Start:        st.Start,
+		End:          st.End,
+		Total:        st.BusinessDays,
+		Elapsed:      st.Elapsed,
+		Remaining:    st.Remaining,
+		CalendarDays: int(epochDay(st.End)-epochDay(st.Start)) + 1,
+	}
+	if s.Total > 0 {
+		s.Percent = float64(s.Elapsed) / float64(s.Total) * 100
+	}
+	s.CalendarElapsed = min(max(int(epochDay(anchor)-epochDay(st.Start))+1, 0), s.CalendarDays)
+	return s
+}
+
+// FiscalYear は startMonth 月始まりの会計年度のうち t を含むものの初日と末日を返す
+// year は年度の呼び名で、初日の年 (4 月始まりなら 2025 年 4 月~2026 年 3 月が 2025 年度)
+func FiscalYear(t time.Time, startMonth time.Month) (start, end time.Time, year int) {
+	year = t.Year()
+	if t.Month() < startMonth {
+		year--
+	}
+	start = time.Date(year, startMonth, 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(1, 0, -1), year
+}
+
+// FiscalQuarter は t を含む四半期 (会計年度の初めから 3 か月ごと、1~4) とその初日・末日を返す
+func FiscalQuarter(t time.Time, startMonth time.Month) (q int, start, end time.Time) {
+	fy, _, _ := FiscalYear(t, startMonth)
+	months := (t.Year()-fy.Year())*12 + int(t.Month()) - int(fy.Month())
+	q = months/3 + 1
+	start = fy.AddDate(0, 3*(q-1), 0)
+	return q, start, start.AddDate(0, 3, -1)
+}
+
+// ISOWeek は t を含む ISO 週 (月曜始まり) の月曜と日曜を返す
+func ISOWeek(t time.Time) (start, end time.Time) {
+	back := (int(t.Weekday()) + 6) % 7 // 月曜からの日数
+	start = BeginningOfDay(t).AddDate(0, 0, -back)
+	return start, start.AddDate(0, 0, 6)
+}
+
+// ParseISOWeek は "2025-W23" 形式の ISO 週をその週の月曜 (loc の 0:00) にする
+func ParseISOWeek(s string, loc *time.Location) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(s, "%4d-W%2d", &year, &week); err != nil || len(s) != len("2025-W23") {
+		return time.Time{}, fmt.Errorf("週は 2025-W23 の形式で指定してください: %s", s)
+	}
+	// 1 月 4 日を含む週がその年の第 1 週
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday, _ := ISOWeek(jan4)
+	monday = monday.AddDate(0, 0, 7*(week-1))
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("%d 年に第 %d 週はありません", year, week)
+	}
+	return monday, nil
+}
+
+// Sprint は first を初日とする length 日ごとのスプリントのうち t を含むものの番号 (first からのスプリントが 1) と初日・末日を返す
+// t が first より前なら 0 以下の番号になる
+func Sprint(t, first time.Time, length int) (n int, start, end time.Time) {
+	// 夏時間の切り替えで 1 日が 24 時間でない日があっても数えられるよう、暦日の差を UTC で求める
+	y1, m1, d1 := first.Date()
+	y2, m2, d2 := t.Date()
+	days := int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)).Hours()) / 24
+	idx := days / length
+	if days < 0 && days%length != 0 {
+		idx--
+	}
+	start = BeginningOfDay(first).AddDate(0, 0, idx*length)
+	return idx + 1, start, start.AddDate(0, 0, length-1)
+}
@@ -0,0 +1,143 @@
+package bizday
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HolidayProvider は年ごとの祝日の取得元
+// 埋め込みの YAML やファイル、HTTP、社内の人事システムのデータベースなど、どこから祝日を読むかを差し替えられる
+type HolidayProvider interface {
+	Holidays(year int) ([]Holiday, error)
+}
+
+// HolidayProviderFunc は関数を HolidayProvider として使うためのアダプタ
+type HolidayProviderFunc func(year int) ([]Holiday, error)
+
+// Holidays は f(year) を返す
+func (f HolidayProviderFunc) Holidays(year int) ([]Holiday, error) {
+	return f(year)
+}
+
+// EntriesProvider は有効期間付きの祝日定義 (holidays.yaml などを読み込んだもの) のうち、asOf 時点で有効な祝日を返す HolidayProvider
+// 振替出勤日の定義は祝日ではないので含めない
+func EntriesProvider(entries []HolidayEntry, asOf time.Time) HolidayProvider {
+	return HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		var hs []Holiday
+		for _, e := range entries {
+			if !e.Workday && e.Date.Year() == year && e.validAt(asOf) {
+				hs = append(hs, Holiday{Date: e.Date, Name: e.Name})
+			}
+		}
+		return hs, nil
+	})
+}
+
+// CalendarProvider は cal の祝日 (一覧と生成規則によるもの) を返す HolidayProvider
+// Lookup("us") のような組み込みのカレンダーを他の取得元と組み合わせるのに使う
+func CalendarProvider(cal *Calendar) HolidayProvider {
+	return HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		return cal.HolidaysBetween(date(year, time.January, 1), date(year, time.December, 31)), nil
+	})
+}
+
+// providerRetry は取得に失敗した年を取得元に問い合わせ直すまでの間隔
+// その間は取得できた分の祝日とエラーを使い、取得元が落ちている間に問い合わせが殺到しないようにする
+const providerRetry = time.Minute
+
+// providerSet は複数の HolidayProvider の結果を年ごとに合わせてキャッシュする
+// すべての取得元から取得できた年だけをずっと覚え、失敗した年は retry の間だけ覚えて取得し直す
+type providerSet struct {
+	providers []HolidayProvider
+	retry     time.Duration
+
+	mu     sync.Mutex
+	years  map[int][]Holiday
+	failed map[int]providerFailure
+}
+
+// providerFailure は取得に失敗した年の、取得できた分の祝日とエラー
+type providerFailure struct {
+	holidays []Holiday
+	err      error
+	at       time.Time
+}
+
+// holidays は year 年の祝日を providers の順に合わせて返す (同じ日は先の取得元の名前を使う)
+// 取得に失敗した取得元は飛ばし、そのエラーを year 年のエラーとして retry の間だけ覚えておく
+func (p *providerSet) holidays(year int) []Holiday {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if hs, ok := p.years[year]; ok {
+		return hs
+	}
+	if f, ok := p.failed[year]; ok && time.Since(f.at) < p.retry {
+		return f.holidays
+	}
+	var hs []Holiday
+	var errs []error
+	for _, pr := range p.providers {
+		got, err := pr.Holidays(year)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		hs = append(hs, got...)
+	}
+	if len(errs) > 0 {
+		p.failed[year] = providerFailure{holidays: hs, err: fmt.Errorf("%d 年の祝日の取得に失敗: %w", year, errors.Join(errs...)), at: time.Now()}
+		return hs
+	}
+	delete(p.failed, year)
+	p.years[year] = hs
+	return hs
+}
+
+// complete は year 年の祝日をすべての取得元から取得できたかどうか (Calendar の年ごとのキャッシュに入れてよいか)
+func (p *providerSet) complete(year int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.years[year]
+	return ok
+}
+
+// err は year 年の取得で覚えているエラー (失敗していなければ nil)
+func (p *providerSet) err(year int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.failed[year].err
+}
+
+// NewProviderCalendar は providers から年ごとに祝日を取得する Calendar を既定の営業時間で作る
+// 祝日は初めて問い合わせのあった年に取得してキャッシュするので、取得元には年ごとに 1 回しか問い合わせない
+// 取得に失敗した年は取得できた分の祝日だけで数え、providerRetry が過ぎた後の問い合わせで取得し直す
+// 失敗を確かめるには先に LoadYears を呼ぶ
+func NewProviderCalendar(providers ...HolidayProvider) *Calendar {
+	p := &providerSet{
+		providers: append([]HolidayProvider{}, providers...),
+		retry:     providerRetry,
+		years:     map[int][]Holiday{},
+		failed:    map[int]providerFailure{},
+	}
+	c := NewRuleCalendar(p.holidays)
+	c.providers = p
+	return c
+}
+
+// LoadYears は from~to 年の祝日を取得元から読み込み、失敗した年があればそのエラーを返す
+// NewProviderCalendar で作った Calendar 以外では何もしない
+func (c *Calendar) LoadYears(from, to int) error {
+	if c.providers == nil {
+		return nil
+	}
+	var errs []error
+	for y := from; y <= to; y++ {
+		c.providers.holidays(y)
+		if err := c.providers.err(y); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
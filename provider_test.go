@@ -0,0 +1,182 @@
+package bizday
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewProviderCalendar(t *testing.T) {
+	calls := 0
+	down := true
+	hr := HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		calls++
+		if year == 2030 && down {
+			return nil, errors.New("人事システムに接続できません")
+		}
+		return []Holiday{{Date: date(year, time.August, 13), Name: "夏季休業"}}, nil
+	})
+	entries, err := DefaultHolidays()
+	if err != nil {
+		t.Fatalf("DefaultHolidays: %v", err)
+	}
+	us, _ := Lookup("us")
+	cal := NewProviderCalendar(EntriesProvider(entries, date(2025, 6, 1)), hr, CalendarProvider(us))
+
+	tests := []struct {
+		d    time.Time
+		want bool
+	}{
+		{date(2025, 5, 6), false},  // 祝日データ
+		{date(2025, 8, 13), false}, // 人事システム
+		{date(2025, 7, 4), false},  // 米国の祝日
+		{date(2025, 8, 12), true},
+	}
+	for _, tt := range tests {
+		if got := cal.IsBusinessDay(tt.d); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.d.Format("2006-01-02"), got, tt.want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("2025 年の問い合わせ回数 = %d, want 1", calls)
+	}
+	if name, _ := cal.HolidayName(date(2025, 8, 13)); name != "夏季休業" {
+		t.Errorf("2025-08-13 の名前 = %q", name)
+	}
+
+	if err := cal.LoadYears(2025, 2026); err != nil {
+		t.Errorf("LoadYears(2025, 2026): %v", err)
+	}
+	if err := cal.LoadYears(2029, 2031); err == nil {
+		t.Error("取得に失敗した年があってもエラーにならない")
+	}
+	// 失敗した取得元を飛ばして、ほかの取得元の祝日は使う
+	if cal.IsBusinessDay(date(2030, 7, 4)) {
+		t.Error("2030-07-04 が営業日になる")
+	}
+	if !cal.IsBusinessDay(date(2030, 8, 13)) {
+		t.Error("取得に失敗した 2030-08-13 が休業日になる")
+	}
+
+	// 失敗した年は providerRetry の間は問い合わせ直さず、過ぎたら取得し直す
+	down = false
+	before := calls
+	cal.IsBusinessDay(date(2030, 8, 14))
+	if calls != before {
+		t.Errorf("providerRetry の間に問い合わせ直した (%d 回)", calls-before)
+	}
+	cal.providers.retry = 0
+	if cal.IsBusinessDay(date(2030, 8, 13)) {
+		t.Error("取得し直した 2030-08-13 が営業日のまま")
+	}
+	if err := cal.LoadYears(2030, 2030); err != nil {
+		t.Errorf("取得し直した後の LoadYears(2030, 2030): %v", err)
+	}
+	before = calls
+	cal.IsBusinessDay(date(2030, 8, 14))
+	if calls != before {
+		t.Error("取得できた年を問い合わせ直した")
+	}
+}
+
+func TestProviderBitmapCache(t *testing.T) {
+	down := true
+	hr := HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		if down {
+			return nil, errors.New("人事システムに接続できません")
+		}
+		return []Holiday{{Date: date(year, time.August, 13), Name: "夏季休業"}}, nil
+	})
+	tests := []struct {
+		name string
+		cal  func(provider *Calendar) *Calendar
+	}{
+		{"provider", func(provider *Calendar) *Calendar { return provider }},
+		{"combined", func(provider *Calendar) *Calendar { return NewCombinedCalendar(provider, NewCalendar(nil)) }},
+	}
+	for _, tt := range tests {
+		down = true
+		provider := NewProviderCalendar(hr)
+		provider.providers.retry = 0
+		cal := tt.cal(provider).WithBitmapCache()
+		if !cal.IsBusinessDay(date(2030, 8, 13)) {
+			t.Errorf("%s: 取得に失敗した 2030-08-13 が休業日になる", tt.name)
+		}
+		if n, _ := cal.CountBusinessDays(date(2030, 8, 1), date(2030, 8, 31)); n != 22 {
+			t.Errorf("%s: 取得に失敗した 2030 年 8 月の営業日数 = %d, want 22", tt.name, n)
+		}
+		// 取得に失敗した年のビットマップは覚えず、取得し直した祝日で数える
+		down = false
+		if cal.IsBusinessDay(date(2030, 8, 13)) {
+			t.Errorf("%s: 取得し直した 2030-08-13 が営業日のまま", tt.name)
+		}
+		if n, _ := cal.CountBusinessDays(date(2030, 8, 1), date(2030, 8, 31)); n != 21 {
+			t.Errorf("%s: 取得し直した 2030 年 8 月の営業日数 = %d, want 21", tt.name, n)
+		}
+		if _, ok := cal.bitmaps.load(2030); !ok {
+			t.Errorf("%s: 取得できた 2030 年のビットマップを覚えていない", tt.name)
+		}
+	}
+}
+
+func TestObservedProvider(t *testing.T) {
+	// 一覧には本来の日付だけを書いておく
+	list := HolidayProviderFunc(func(year int) ([]Holiday, error) {
+		return []Holiday{
+			{Date: date(year, time.January, 1), Name: "New Year's Day"},
+			{Date: date(year, time.July, 4), Name: "Independence Day"},
+			{Date: date(year, time.December, 25), Name: "Christmas Day"},
+			{Date: date(year, time.December, 26), Name: "Boxing Day"},
+		}, nil
+	})
+
+	tests := []struct {
+		o    Observance
+		year int
+		want []Holiday
+	}{
+		// 2021: 7/4 が日曜、12/25 が土曜、2022/1/1 が土曜
+		{USObservance, 2021, []Holiday{
+			{date(2021, 1, 1), "New Year's Day"},
+			{date(2021, 7, 5), "Independence Day (observed)"},
+			{date(2021, 12, 24), "Christmas Day (observed)"},
+			{date(2021, 12, 27), "Boxing Day (observed)"},
+			{date(2021, 12, 31), "New Year's Day (observed)"},
+		}},
+		{USObservance, 2022, []Holiday{
+			{date(2022, 7, 4), "Independence Day"},
+			{date(2022, 12, 26), "Christmas Day (observed)"},
+			{date(2022, 12, 26), "Boxing Day"},
+		}},
+		// 12/25 が土曜なら 12/27 (月)、12/26 が日曜なら空いている次の平日の 12/28 (火)
+		{UKObservance, 2021, []Holiday{
+			{date(2021, 1, 1), "New Year's Day"},
+			{date(2021, 7, 5), "Independence Day (substitute day)"},
+			{date(2021, 12, 27), "Christmas Day (substitute day)"},
+			{date(2021, 12, 28), "Boxing Day (substitute day)"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ObservedProvider(list, tt.o).Holidays(tt.year)
+		if err != nil {
+			t.Fatalf("Holidays(%d): %v", tt.year, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%d%s: %v, want %v", tt.year, tt.o.Suffix, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Date.Equal(tt.want[i].Date) || got[i].Name != tt.want[i].Name {
+				t.Errorf("%d%s [%d] = %s %s, want %s %s", tt.year, tt.o.Suffix, i,
+					got[i].Date.Format("2006-01-02"), got[i].Name, tt.want[i].Date.Format("2006-01-02"), tt.want[i].Name)
+			}
+		}
+	}
+
+	if o, ok := ObservanceFor("uk-sct"); !ok || o.Suffix != UKObservance.Suffix {
+		t.Error(`ObservanceFor("uk-sct") が英国式の振替を返さない`)
+	}
+	if _, ok := ObservanceFor("jp"); ok {
+		t.Error(`ObservanceFor("jp") に振替の規則がある`)
+	}
+}
@@ -0,0 +1,81 @@
+package bizday
+
+import (
+	"sort"
+	"sync"
+)
+
+// 名前付きカレンダーの登録簿
+// 組み込みカレンダーのほか、埋め込み先のアプリケーションやプラグインが独自のカレンダーを公開できる
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Calendar{}
+)
+
+// Register は cal を name で登録する
+// cal が nil の場合や、同じ名前が登録済みの場合は panic する
+func Register(name string, cal *Calendar) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if cal == nil {
+		panic("bizday: Register calendar is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("bizday: Register called twice for calendar " + name)
+	}
+	registry[name] = cal
+}
+
+// Replace は name の登録を cal に差し替える (name が未登録なら Register と同じ)
+// 祝日データを読み込み直したカレンダーに入れ替えるときに使う。cal が nil の場合は panic する
+func Replace(name string, cal *Calendar) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if cal == nil {
+		panic("bizday: Replace calendar is nil")
+	}
+	registry[name] = cal
+}
+
+// Lookup は name で登録されたカレンダーを返す
+func Lookup(name string) (*Calendar, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	cal, ok := registry[name]
+	return cal, ok
+}
+
+// CalendarNames は登録済みのカレンダー名を昇順で返す
+func CalendarNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 規則で算出できる組み込みカレンダーを登録する (jp は祝日データの読み込み後に main で登録)
+func init() {
+	Register("us", NewRuleCalendar(usFederalHolidays))
+	Register("uk", NewRuleCalendar(func(y int) []Holiday { return ukBankHolidays(y, ukEnglandWales) }))
+	Register("uk-sct", NewRuleCalendar(func(y int) []Holiday { return ukBankHolidays(y, ukScotland) }))
+	Register("uk-ni", NewRuleCalendar(func(y int) []Holiday { return ukBankHolidays(y, ukNorthernIreland) }))
+	for state := range usStateDays {
+		Register("us-"+state, NewRuleCalendar(usStateHolidays(state)))
+	}
+	Register("kr", NewRuleCalendar(koreanHolidays))
+	Register("target2", NewRuleCalendar(target2ClosingDays))
+	tse := NewRuleCalendar(tseHolidays)
+	tse.Hours = tseTradingHours
+	Register("tse", tse)
+	nyse := NewRuleCalendar(nyseHolidays)
+	nyse.Hours = nyseTradingHours
+	Register("nyse", nyse)
+	for country, preset := range countryWeekends {
+		Register(country, &Calendar{Hours: DefaultWorkHours, Weekend: weekendPresets[preset]})
+	}
+}
@@ -0,0 +1,48 @@
+package bizday
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RollConvention は休業日を営業日にずらすときの規約
+type RollConvention string
+
+const (
+	RollFollowing         RollConvention = "following"          // 次の営業日
+	RollPreceding         RollConvention = "preceding"          // 前の営業日
+	RollModifiedFollowing RollConvention = "modified-following" // 次の営業日、月をまたぐなら前の営業日
+)
+
+// ParseRollConvention は following (f, forward)・preceding (p, backward)・modified-following (mf) をパースする
+func ParseRollConvention(s string) (RollConvention, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "following", "f", "forward":
+		return RollFollowing, nil
+	case "preceding", "p", "backward":
+		return RollPreceding, nil
+	case "modified-following", "modifiedfollowing", "mf":
+		return RollModifiedFollowing, nil
+	}
+	return "", fmt.Errorf("ずらし方は following, preceding, modified-following のいずれかにしてください: %s", s)
+}
+
+// Roll は t が休業日なら conv に従って営業日にずらした日付を返す (営業日ならそのまま、時刻は t のまま)
+// ずらす先の営業日が見つからなければ ErrNoBusinessDay を返す
+func (c *Calendar) Roll(t time.Time, conv RollConvention) (time.Time, error) {
+	if c.IsBusinessDay(t) {
+		return t, nil
+	}
+	switch conv {
+	case RollPreceding:
+		return c.PrevBusinessDay(t)
+	case RollModifiedFollowing:
+		next, err := c.NextBusinessDay(t)
+		if err != nil || next.Month() != t.Month() {
+			return c.PrevBusinessDay(t)
+		}
+		return next, nil
+	}
+	return c.NextBusinessDay(t)
+}
@@ -0,0 +1,64 @@
+package bizday
+
+import "time"
+
+// 祝日の生成規則で共通に使う日付計算
+
+// date は UTC の 0:00 の日付を作る
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday は year 年 month 月の第 n weekday を返す (n が負なら末尾から数える)
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := date(year, month+1, 0)
+		back := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -back+7*(n+1))
+	}
+	first := date(year, month, 1)
+	ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, ahead+7*(n-1))
+}
+
+// observedNearest は土曜なら前の金曜、日曜なら翌月曜に振り替えた日を返す (米国式)
+func observedNearest(d time.Time) time.Time {
+	switch d.Weekday() {
+	case time.Saturday:
+		return d.AddDate(0, 0, -1)
+	case time.Sunday:
+		return d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// easterSunday は year 年の復活祭 (グレゴリオ暦) を返す
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+// isWeekend は土曜または日曜かどうかを判定
+func isWeekend(d time.Time) bool {
+	return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+}
+
+// containsDate は hs に d と同じ日付の祝日が含まれるかを判定
+func containsDate(hs []Holiday, d time.Time) bool {
+	for _, h := range hs {
+		if isSameDay(h.Date, d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package bizday
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuiltinCalendars(t *testing.T) {
+	tests := []struct {
+		calendar string
+		day      time.Time
+		want     bool // 営業日かどうか
+	}{
+		{"us", date(2026, 7, 3), false},   // 独立記念日 (7/4 が土曜) の振替
+		{"us", date(2025, 11, 27), false}, // 感謝祭
+		{"us", date(2025, 11, 28), true},
+		{"us-ma", date(2025, 4, 21), false}, // Patriots' Day
+		{"us", date(2025, 4, 21), true},
+		{"us-ma", date(2025, 7, 4), false},   // 州のカレンダーも連邦祝日で休む
+		{"us-tx", date(2025, 11, 28), false}, // 感謝祭の翌日
+		{"us-ca", date(2027, 3, 31), false},  // Cesar Chavez Day
+		{"us-ca", date(2000, 3, 31), true},
+		{"us-hi", date(2027, 6, 11), false}, // King Kamehameha I Day
+		{"us-hi", date(2022, 6, 10), false}, // 6/11 が土曜の振替
+		{"us-hi", date(2025, 8, 15), false}, // Statehood Day
+		{"uk", date(2025, 8, 25), false},    // Summer bank holiday
+		{"uk-sct", date(2025, 8, 4), false},
+		{"uk-sct", date(2025, 8, 25), true},
+		{"uk", date(2025, 4, 18), false}, // Good Friday
+		{"kr", date(2025, 5, 6), false},  // こどもの日と釈迦誕生日の振替
+		{"kr", date(2025, 5, 7), true},
+		{"target2", date(2025, 5, 1), false},
+		{"target2", date(2025, 12, 26), false},
+		{"tse", date(2025, 12, 31), false}, // 大納会の翌日
+		{"tse", date(2026, 1, 2), false},
+		{"tse", date(2026, 1, 5), true},    // 大発会
+		{"tse", date(2025, 5, 6), false},   // 振替休日
+		{"nyse", date(2025, 4, 18), false}, // Good Friday
+		{"nyse", date(2025, 10, 13), true}, // Columbus Day は取引あり
+		{"nyse", date(2025, 1, 9), false},  // Carter の国葬
+		{"nyse", date(2021, 12, 31), true}, // 元日が土曜でも前日は休場にしない
+		{"nyse", date(2026, 7, 3), false},
+		{"sa", date(2025, 5, 2), false}, // 金曜
+		{"sa", date(2025, 5, 4), true},  // 日曜
+	}
+	for _, tt := range tests {
+		cal, ok := Lookup(tt.calendar)
+		if !ok {
+			t.Fatalf("Lookup(%q) が見つからない", tt.calendar)
+		}
+		if got := cal.IsBusinessDay(tt.day); got != tt.want {
+			t.Errorf("%s: IsBusinessDay(%s) = %v, want %v", tt.calendar, tt.day.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestEasterSunday(t *testing.T) {
+	for year, want := range map[int]time.Time{
+		2024: date(2024, 3, 31),
+		2025: date(2025, 4, 20),
+		2026: date(2026, 4, 5),
+	} {
+		if got := easterSunday(year); !got.Equal(want) {
+			t.Errorf("easterSunday(%d) = %s, want %s", year, got.Format("2006-01-02"), want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("同じ名前の Register で panic しない")
+		}
+	}()
+	Register("us", &Calendar{})
+}
+
+func TestReplace(t *testing.T) {
+	cal := &Calendar{}
+	Replace("test-replace", &Calendar{})
+	Replace("test-replace", cal)
+	if got, ok := Lookup("test-replace"); !ok || got != cal {
+		t.Error("Replace で登録が差し替わらない")
+	}
+}
+
+func TestLookupWeekend(t *testing.T) {
+	w, err := LookupWeekend("fri-sat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := &Calendar{Weekend: w}
+	if !cal.IsWeekend(date(2025, 5, 2)) || cal.IsWeekend(date(2025, 5, 4)) {
+		t.Error("fri-sat の定休日判定が違う")
+	}
+	if _, err := LookupWeekend("mon-tue"); err == nil {
+		t.Error("未知のプリセットでエラーにならない")
+	}
+}
+
+func TestParseWeekend(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []time.Weekday
+	}{
+		{"fri-sat", []time.Weekday{time.Friday, time.Saturday}},
+		{"sun,mon", []time.Weekday{time.Sunday, time.Monday}},
+		{" Sun , MON ", []time.Weekday{time.Sunday, time.Monday}},
+		{"wed", []time.Weekday{time.Wednesday}},
+		{"none", []time.Weekday{}},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekend(tt.in)
+		if err != nil {
+			t.Errorf("ParseWeekend(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseWeekend(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := ParseWeekend("sun,funday"); err == nil {
+		t.Error("未知の曜日でエラーにならない")
+	}
+}
+
+func TestWithWeekend(t *testing.T) {
+	cal := (&Calendar{}).WithWeekend(time.Sunday, time.Monday)
+	if !cal.IsBusinessDay(date(2025, 5, 10)) || cal.IsBusinessDay(date(2025, 5, 12)) {
+		t.Error("火曜~土曜勤務の定休日判定が違う")
+	}
+	if all := (&Calendar{}).WithWeekend(); all.IsWeekend(date(2025, 5, 11)) {
+		t.Error("WithWeekend() で定休日がなくならない")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	jp, err := Load("jp")
+	if err != nil {
+		t.Fatalf(`Load("jp"): %v`, err)
+	}
+	// 2025-01-02 は祝日データにだけある年始休み
+	if jp.IsBusinessDay(date(2025, 1, 2)) {
+		t.Error(`Load("jp") に祝日データの年始休みがない`)
+	}
+	// データにない年は規則で算出する
+	if jp.IsBusinessDay(date(2030, 5, 6)) {
+		t.Error(`Load("jp") で 2030-05-06 (振替休日) が営業日になる`)
+	}
+	if us, err := Load("us"); err != nil || us.IsBusinessDay(date(2025, 7, 4)) {
+		t.Errorf(`Load("us") が組み込みのカレンダーにならない: %v`, err)
+	}
+	if _, err := Load("zz"); err == nil {
+		t.Error(`Load("zz") がエラーにならない`)
+	}
+}
@@ -0,0 +1,16 @@
+package bizday
+
+import "fmt"
+
+// SchemaVersion は JSON/YAML 出力の形式のバージョン
+// 同じバージョンの間はフィールドの削除・改名・意味の変更をせず、追加のみ行う
+const SchemaVersion = 1
+
+// checkSchemaVersion は読み込んだデータの schema_version が扱えるものかを確認する
+// 0 (未指定) は SchemaVersion が導入される前のデータとして受け付ける
+func checkSchemaVersion(v int) error {
+	if v > SchemaVersion {
+		return fmt.Errorf("schema_version %d には対応していません (このバージョンは %d まで)", v, SchemaVersion)
+	}
+	return nil
+}
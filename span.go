@@ -0,0 +1,85 @@
+package bizday
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Span の単位
+const (
+	SpanBusinessDays  = "bd" // 営業日
+	SpanBusinessHours = "bh" // 営業時間
+	SpanDays          = "d"  // 暦日
+	SpanWeeks         = "w"  // 暦週
+)
+
+// Span は "5bd"・"3bh"・"2w" のような単位付きの期間
+type Span struct {
+	Value float64
+	Unit  string
+}
+
+// ParseSpan は単位付きの期間をパースする。単位がなければ defaultUnit として扱う
+func ParseSpan(s, defaultUnit string) (Span, error) {
+	s = strings.TrimSpace(s)
+	num := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyz")
+	unit := s[len(num):]
+	if unit == "" {
+		unit = defaultUnit
+	}
+	switch unit {
+	case SpanBusinessDays, SpanBusinessHours, SpanDays, SpanWeeks:
+	default:
+		return Span{}, fmt.Errorf("期間の単位は bd (営業日), bh (営業時間), d (暦日), w (週) のいずれかにしてください: %s", s)
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || v < 0 {
+		return Span{}, fmt.Errorf("期間のパースに失敗: %s", s)
+	}
+	return Span{Value: v, Unit: unit}, nil
+}
+
+func (s Span) String() string {
+	return strconv.FormatFloat(s.Value, 'f', -1, 64) + s.Unit
+}
+
+// calendarDays は暦日・暦週の期間を日数にする
+func (s Span) calendarDays() int {
+	if s.Unit == SpanWeeks {
+		return int(s.Value * 7)
+	}
+	return int(s.Value)
+}
+
+// SpanBusinessDays は from の翌日から数えた s の期間に含まれる営業日数を返す
+// 営業時間の指定は 1 営業日の時間で割って切り捨てる
+func (c *Calendar) SpanBusinessDays(from time.Time, s Span) int {
+	switch s.Unit {
+	case SpanBusinessDays:
+		return int(s.Value)
+	case SpanBusinessHours:
+		return int(s.Value / c.Hours.Duration().Hours())
+	}
+	n := 0
+	start := BeginningOfDay(from)
+	for i := 1; i <= s.calendarDays(); i++ {
+		if c.IsBusinessDay(start.AddDate(0, 0, i)) {
+			n++
+		}
+	}
+	return n
+}
+
+// SpanDuration は from から数えた s の期間に含まれる営業時間を返す
+// 営業日の指定は 1 営業日の営業時間を掛け合わせる
+func (c *Calendar) SpanDuration(from time.Time, s Span) time.Duration {
+	switch s.Unit {
+	case SpanBusinessHours:
+		return time.Duration(s.Value * float64(time.Hour))
+	case SpanBusinessDays:
+		return time.Duration(s.Value * float64(c.Hours.Duration()))
+	}
+	return c.WorkedDuration(from, from.AddDate(0, 0, s.calendarDays()))
+}
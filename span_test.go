@@ -0,0 +1,51 @@
+package bizday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpan(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Span
+	}{
+		{"5bd", Span{5, SpanBusinessDays}},
+		{"3bh", Span{3, SpanBusinessHours}},
+		{"2w", Span{2, SpanWeeks}},
+		{"10", Span{10, SpanBusinessDays}},
+		{"1.5bd", Span{1.5, SpanBusinessDays}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSpan(tt.in, SpanBusinessDays)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSpan(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"5x", "bd", "-1bd"} {
+		if _, err := ParseSpan(in, SpanBusinessDays); err == nil {
+			t.Errorf("ParseSpan(%q) がエラーにならない", in)
+		}
+	}
+}
+
+func TestSpanBusinessDays(t *testing.T) {
+	cal := mustJapan(t)
+	from := date(2025, 5, 2)
+	tests := []struct {
+		span Span
+		want int
+	}{
+		{Span{3, SpanBusinessDays}, 3},
+		{Span{16, SpanBusinessHours}, 2},
+		{Span{1, SpanWeeks}, 3}, // 5/3~5/9 のうち 5/7~5/9
+	}
+	for _, tt := range tests {
+		if got := cal.SpanBusinessDays(from, tt.span); got != tt.want {
+			t.Errorf("SpanBusinessDays(%s) = %d, want %d", tt.span, got, tt.want)
+		}
+	}
+	if got := cal.SpanDuration(from, Span{2, SpanBusinessDays}); got != 16*time.Hour {
+		t.Errorf("SpanDuration(2bd) = %v, want 16h", got)
+	}
+}
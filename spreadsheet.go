@@ -0,0 +1,66 @@
+package bizday
+
+import "time"
+
+// Networkdays は Excel・Google スプレッドシートの NETWORKDAYS(start, end, holidays) と同じ値を返す
+// start~end の両端を含む月~金の日数から holidays の日を除いたもので、次の点も表計算ソフトに合わせている
+//   - 日付だけを見る (時刻は切り捨てる)
+//   - start が end より後なら、end~start の日数の負の値を返す
+//   - holidays の土日の日・期間外の日・重複は数に影響しない
+//
+// カレンダーの定休日や祝日データは使わないので、経理のシートと 1 対 1 で突き合わせるときに使う
+func Networkdays(start, end time.Time, holidays []time.Time) int {
+	from, to := epochDay(start), epochDay(end)
+	sign := 1
+	if from > to {
+		from, to, sign = to, from, -1
+	}
+	off := spreadsheetHolidays(holidays)
+	n := 0
+	for d := from; d <= to; d++ {
+		if spreadsheetWorkday(d, off) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// Workday は Excel・Google スプレッドシートの WORKDAY(start, days, holidays) と同じ日付を返す
+// start の翌日 (days が負なら前日) から数えて days 日目の、月~金で holidays に含まれない日で、
+// start 自身は数えないので、start が土日や holidays の日でもそのまま数え始める
+// days が 0 なら、休業日かどうかにかかわらず start の日付を返す (表計算ソフトと同じ)
+// 結果は start のロケーションの 0:00
+func Workday(start time.Time, days int, holidays []time.Time) time.Time {
+	d := epochDay(start)
+	step := int32(1)
+	if days < 0 {
+		step, days = -1, -days
+	}
+	off := spreadsheetHolidays(holidays)
+	for days > 0 {
+		d += step
+		if spreadsheetWorkday(d, off) {
+			days--
+		}
+	}
+	y, m, dd := start.Date()
+	return time.Date(y, m, dd+int(d-epochDay(start)), 0, 0, 0, 0, start.Location())
+}
+
+// spreadsheetHolidays は NETWORKDAYS・WORKDAY の holidays 引数を epochDay の集合にする
+func spreadsheetHolidays(holidays []time.Time) map[int32]struct{} {
+	off := make(map[int32]struct{}, len(holidays))
+	for _, h := range holidays {
+		off[epochDay(h)] = struct{}{}
+	}
+	return off
+}
+
+// spreadsheetWorkday は day が表計算ソフトの稼働日 (月~金で off に含まれない日) かどうかを返す
+func spreadsheetWorkday(day int32, off map[int32]struct{}) bool {
+	if w := weekdayOf(day); w == time.Saturday || w == time.Sunday {
+		return false
+	}
+	_, holiday := off[day]
+	return !holiday
+}
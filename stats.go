@@ -0,0 +1,88 @@
+package bizday
+
+import "time"
+
+// MonthStats は t を含む月の営業日に関する集計
+type MonthStats struct {
+	Start            time.Time     // 月初 (1 日 0:00)
+	End              time.Time     // 月末 (末日 23:59:59)
+	BusinessDays     int           // 月の営業日数
+	Index            int           // 月初から t まで (t を含む) の営業日数 = t が何営業日目か
+	Remaining        int           // t より後の残り営業日数
+	Holidays         []Holiday     // 月内の祝日 (土日に当たるものも含む)
+	FirstBusinessDay time.Time     // 月の最初の営業日 (営業日がなければゼロ値)
+	LastBusinessDay  time.Time     // 月の最後の営業日 (営業日がなければゼロ値)
+	WorkingHours     time.Duration // 月の営業時間の合計
+	RemainingHours   time.Duration // 残り営業日の営業時間の合計
+}
+
+// MonthStats は t を含む月の営業日数・t の営業日目・残り営業日数などをまとめて返す
+// t が営業日なら Index にはその日も含まれるため、Remaining は t を除いた先の日数になる
+func (c *Calendar) MonthStats(t time.Time) MonthStats {
+	s := MonthStats{Start: BeginningOfMonth(t), End: EndOfMonth(t)}
+	for d := s.Start; !d.After(s.End); d = d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		s.BusinessDays++
+		if !d.After(t) {
+			s.Index++
+		}
+		if s.FirstBusinessDay.IsZero() {
+			s.FirstBusinessDay = d
+		}
+		s.LastBusinessDay = d
+	}
+	s.Remaining = s.BusinessDays - s.Index
+	s.Holidays = c.HolidaysBetween(s.Start, s.End)
+	s.WorkingHours = time.Duration(s.BusinessDays) * c.Hours.Duration()
+	s.RemainingHours = time.Duration(s.Remaining) * c.Hours.Duration()
+	return s
+}
+
+// HolidaysBetween は start~end (両端含む) の祝日を日付順に返す
+// 名前の分からない祝日は Name が空になる
+func (c *Calendar) HolidaysBetween(start, end time.Time) []Holiday {
+	var hs []Holiday
+	for d := BeginningOfDay(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if h, ok := c.holidayOn(d); ok {
+			hs = append(hs, h)
+		}
+	}
+	return hs
+}
+
+// HolidayName は t の日付が祝日 (一覧または生成規則によるもの) ならその名前を返す
+// 名前のない祝日は "" と true を返す
+func (c *Calendar) HolidayName(t time.Time) (string, bool) {
+	h, ok := c.holidayOn(t)
+	return h.Name, ok
+}
+
+// holidayOn は d の日付が祝日 (繰り返しの休業日の規則と、WithCustomRule で ClassHoliday とした日を含む) ならその祝日を返す
+func (c *Calendar) holidayOn(d time.Time) (Holiday, bool) {
+	if h, ok := c.baseHolidayOn(d); ok {
+		return h, true
+	}
+	if r, ok := c.closureRuleOn(d); ok {
+		return Holiday{Date: d, Name: r.Name}, true
+	}
+	if class, ok := c.customClass(d); ok && class == ClassHoliday {
+		return Holiday{Date: d}, true
+	}
+	return Holiday{}, false
+}
+
+// baseHolidayOn は d の日付が一覧または生成規則による祝日ならその祝日を返す
+func (c *Calendar) baseHolidayOn(d time.Time) (Holiday, bool) {
+	day := epochDay(d)
+	if c.holidays.contains(day) {
+		return Holiday{Date: d, Name: c.names[day]}, true
+	}
+	if c.Generate != nil {
+		if name, ok := c.generated(d.Year())[day]; ok {
+			return Holiday{Date: d, Name: name}, true
+		}
+	}
+	return Holiday{}, false
+}
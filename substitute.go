@@ -0,0 +1,101 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// 振替休日・国民の休日の名前
+const (
+	substituteHolidayName = "振替休日"
+	citizensHolidayName   = "国民の休日"
+)
+
+// jpNationalHolidayNames は振替休日・国民の休日の元になる「国民の祝日」の名前 (jpSpecialHolidays の名前も含める)
+var jpNationalHolidayNames = func() map[string]bool {
+	names := map[string]bool{}
+	for _, n := range []string{"元日", "成人の日", "建国記念の日", "天皇誕生日", "春分の日", "昭和の日", "みどりの日", "憲法記念日",
+		"こどもの日", "海の日", "山の日", "敬老の日", "秋分の日", "体育の日", "スポーツの日", "文化の日", "勤労感謝の日"} {
+		names[n] = true
+	}
+	for _, h := range jpSpecialHolidays {
+		names[h.Name] = true
+	}
+	return names
+}()
+
+// SubstituteHolidays は entries の祝日から、日本の法律で決まる振替休日と国民の休日のうち entries にないものを加えて日付順に返す
+//   - 振替休日: 日曜の祝日の後で最初の祝日でない日 (2006 年までは翌日が祝日でなければ翌日)
+//   - 国民の休日: 前日と翌日が祝日に挟まれた、祝日でない日 (2006 年までは日曜を除く)
+//
+// 元になる祝日は名前が国民の祝日のもの (元日、海の日など) だけで、年始休みのような会社独自の休みは数えない。
+// 算出した日がすでに一覧で休みになっていれば加えない。
+// 算出した休日の有効期間 (ValidFrom/ValidTo) は元になった祝日のものを引き継ぐ
+func SubstituteHolidays(entries []HolidayEntry) []HolidayEntry {
+	listed := map[int32]bool{}           // 一覧で休みの日
+	national := map[int32]HolidayEntry{} // 国民の祝日 (算出した振替休日も加えていく)
+	var sources []HolidayEntry
+	for _, e := range entries {
+		if e.Workday {
+			continue
+		}
+		listed[epochDay(e.Date)] = true
+		if jpNationalHolidayNames[e.Name] {
+			national[epochDay(e.Date)] = e
+			sources = append(sources, e)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Date.Before(sources[j].Date) })
+	isNational := func(d time.Time) bool {
+		_, ok := national[epochDay(d)]
+		return ok
+	}
+
+	out := append([]HolidayEntry(nil), entries...)
+	var substitutes []HolidayEntry
+	for _, h := range sources {
+		if h.Date.Weekday() != time.Sunday || h.Date.Before(date(1973, time.April, 12)) {
+			continue
+		}
+		d := h.Date.AddDate(0, 0, 1)
+		if h.Date.Year() >= 2007 {
+			for isNational(d) {
+				d = d.AddDate(0, 0, 1)
+			}
+		} else if isNational(d) {
+			continue
+		}
+		e := HolidayEntry{Date: d, Name: substituteHolidayName, ValidFrom: h.ValidFrom, ValidTo: h.ValidTo}
+		national[epochDay(d)] = e
+		substitutes = append(substitutes, e)
+	}
+	for _, e := range substitutes {
+		if !listed[epochDay(e.Date)] {
+			listed[epochDay(e.Date)] = true
+			out = append(out, e)
+		}
+	}
+
+	for _, h := range sources {
+		d := h.Date.AddDate(0, 0, 1)
+		next, ok := national[epochDay(d.AddDate(0, 0, 1))]
+		if !ok || next.Name == substituteHolidayName || isNational(d) || listed[epochDay(d)] || d.Year() < 1986 {
+			continue
+		}
+		if d.Year() < 2007 && d.Weekday() == time.Sunday {
+			continue
+		}
+		e := HolidayEntry{Date: d, Name: citizensHolidayName, ValidFrom: h.ValidFrom, ValidTo: h.ValidTo}
+		// 前後どちらの祝日も載っている期間だけ有効にする
+		if next.ValidFrom.After(e.ValidFrom) {
+			e.ValidFrom = next.ValidFrom
+		}
+		if !next.ValidTo.IsZero() && (e.ValidTo.IsZero() || next.ValidTo.Before(e.ValidTo)) {
+			e.ValidTo = next.ValidTo
+		}
+		listed[epochDay(d)] = true
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
@@ -0,0 +1,60 @@
+package bizday
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// SyukujitsuURL は内閣府が公開している「国民の祝日」の CSV (Shift_JIS)
+const SyukujitsuURL = "https://www8.cao.go.jp/chosei/shukujitsu/syukujitsu.csv"
+
+// ParseSyukujitsuCSV は内閣府の syukujitsu.csv ("1955/1/1,元日" の形式、Shift_JIS) を HolidayEntry のスライスにする
+// 先頭の見出し行は読み飛ばす。UTF-8 に変換済みのデータもそのまま受け付ける
+func ParseSyukujitsuCSV(data []byte) ([]HolidayEntry, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	var r io.Reader = bytes.NewReader(data)
+	// Shift_JIS の日本語は UTF-8 としてはほぼ必ず不正になるので、これで判別できる
+	if !utf8.Valid(data) {
+		r = transform.NewReader(r, japanese.ShiftJIS.NewDecoder())
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	var holidays []HolidayEntry
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV の読み込みに失敗: %w", err)
+		}
+		if len(rec) == 0 || strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		d, err := time.Parse("2006/1/2", strings.TrimSpace(rec[0]))
+		if err != nil {
+			if line == 1 {
+				continue // 見出し行
+			}
+			return nil, fmt.Errorf("%d 行目の日付のパースに失敗: %s", line, rec[0])
+		}
+		e := HolidayEntry{Date: d}
+		if len(rec) > 1 {
+			e.Name = strings.TrimSpace(rec[1])
+		}
+		holidays = append(holidays, e)
+	}
+	if len(holidays) == 0 {
+		return nil, fmt.Errorf("CSV に祝日がありません")
+	}
+	return holidays, nil
+}
@@ -0,0 +1,31 @@
+package bizday
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestParseSyukujitsuCSV(t *testing.T) {
+	const src = "国民の祝日・休日月日,国民の祝日・休日名称\r\n2025/1/1,元日\r\n2025/5/6,休日\r\n"
+	sjis, err := japanese.ShiftJIS.NewEncoder().String(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{"Shift_JIS": sjis, "UTF-8": src, "BOM 付き UTF-8": "\xef\xbb\xbf" + src} {
+		entries, err := ParseSyukujitsuCSV([]byte(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("%s: %d 件, want 2", name, len(entries))
+		}
+		if e := entries[1]; !e.Date.Equal(date(2025, 5, 6)) || e.Name != "休日" {
+			t.Errorf("%s: 2 件目 = %+v", name, e)
+		}
+	}
+
+	if _, err := ParseSyukujitsuCSV([]byte("見出し\n2025/13/1,元日\n")); err == nil {
+		t.Error("不正な日付でエラーにならない")
+	}
+}
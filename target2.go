@@ -0,0 +1,22 @@
+package bizday
+
+import "time"
+
+// target2ClosingDays は year 年の TARGET2 (ユーロ決済システム) の休業日を返す
+// 2002 年以降の休業日は元日・聖金曜日・復活祭月曜日・5 月 1 日・12 月 25 日・12 月 26 日の 6 日
+func target2ClosingDays(year int) []Holiday {
+	easter := easterSunday(year)
+	hs := []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{date(year, time.May, 1), "Labour Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Christmas Holiday"},
+	}
+	// 2000・2001 年は 12 月 31 日も休業
+	if year == 2000 || year == 2001 {
+		hs = append(hs, Holiday{date(year, time.December, 31), "New Year's Eve"})
+	}
+	return hs
+}
@@ -0,0 +1,99 @@
+package bizday
+
+import (
+	"sort"
+	"time"
+)
+
+// ukRegion は英国のバンクホリデーの地域区分
+type ukRegion int
+
+const (
+	ukEnglandWales ukRegion = iota
+	ukScotland
+	ukNorthernIreland
+)
+
+// ukProclamation は布告による一回限りのバンクホリデーや移動
+// From がゼロ値なら追加、そうでなければ From の祝日を To に移す
+type ukProclamation struct {
+	From time.Time
+	To   time.Time
+	Name string
+}
+
+// ukProclamations は英国全域に適用された布告 (年ごとの例外)
+var ukProclamations = map[int][]ukProclamation{
+	1995: {{From: date(1995, time.May, 1), To: date(1995, time.May, 8), Name: "Early May bank holiday (VE day)"}},
+	1999: {{To: date(1999, time.December, 31), Name: "Millennium Celebrations"}},
+	2002: {
+		{From: date(2002, time.May, 27), To: date(2002, time.June, 4), Name: "Spring bank holiday"},
+		{To: date(2002, time.June, 3), Name: "Golden Jubilee bank holiday"},
+	},
+	2011: {{To: date(2011, time.April, 29), Name: "Royal wedding"}},
+	2012: {
+		{From: date(2012, time.May, 28), To: date(2012, time.June, 4), Name: "Spring bank holiday"},
+		{To: date(2012, time.June, 5), Name: "Diamond Jubilee"},
+	},
+	2020: {{From: date(2020, time.May, 4), To: date(2020, time.May, 8), Name: "Early May bank holiday (VE day)"}},
+	2022: {
+		{From: date(2022, time.May, 30), To: date(2022, time.June, 2), Name: "Spring bank holiday"},
+		{To: date(2022, time.June, 3), Name: "Platinum Jubilee bank holiday"},
+		{To: date(2022, time.September, 19), Name: "Bank Holiday for the State Funeral of Queen Elizabeth II"},
+	},
+	2023: {{To: date(2023, time.May, 8), Name: "Bank holiday for the coronation of King Charles III"}},
+}
+
+// ukBankHolidays は地域 region の year 年のバンクホリデーを返す
+// 土日に当たる固定日の祝日は、次の空いている平日を振替日 (substitute day) とする (UKObservance)
+func ukBankHolidays(year int, region ukRegion) []Holiday {
+	var hs []Holiday
+	add := func(d time.Time, name string) {
+		hs = append(hs, Holiday{Date: d, Name: name})
+	}
+
+	easter := easterSunday(year)
+	add(date(year, time.January, 1), "New Year's Day")
+	if region == ukScotland {
+		add(date(year, time.January, 2), "2nd January")
+	}
+	if region == ukNorthernIreland {
+		add(date(year, time.March, 17), "St Patrick's Day")
+	}
+	add(easter.AddDate(0, 0, -2), "Good Friday")
+	if region != ukScotland {
+		add(easter.AddDate(0, 0, 1), "Easter Monday")
+	}
+	if year >= 1978 {
+		add(nthWeekday(year, time.May, time.Monday, 1), "Early May bank holiday")
+	}
+	add(nthWeekday(year, time.May, time.Monday, -1), "Spring bank holiday")
+	if region == ukNorthernIreland {
+		add(date(year, time.July, 12), "Battle of the Boyne (Orangemen's Day)")
+	}
+	if region == ukScotland {
+		add(nthWeekday(year, time.August, time.Monday, 1), "Summer bank holiday")
+	} else {
+		add(nthWeekday(year, time.August, time.Monday, -1), "Summer bank holiday")
+	}
+	if region == ukScotland && year >= 2007 {
+		add(date(year, time.November, 30), "St Andrew's Day")
+	}
+	add(date(year, time.December, 25), "Christmas Day")
+	add(date(year, time.December, 26), "Boxing Day")
+	hs = observeYear(hs, UKObservance, year)
+
+	for _, p := range ukProclamations[year] {
+		if !p.From.IsZero() {
+			for i := range hs {
+				if isSameDay(hs[i].Date, p.From) {
+					hs = append(hs[:i], hs[i+1:]...)
+					break
+				}
+			}
+		}
+		hs = append(hs, Holiday{Date: p.To, Name: p.Name})
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Date.Before(hs[j].Date) })
+	return hs
+}
@@ -0,0 +1,35 @@
+package bizday
+
+import "time"
+
+// UpcomingHolidays は t の翌日から n 営業日先までの間にある平日の祝日を返す
+// 土日 (定休日) に重なる祝日は業務に影響しないため含めない
+// before には各祝日までに残っている営業日数 (t の翌日から祝日の前日まで) が入る
+func (c *Calendar) UpcomingHolidays(t time.Time, n int) (hs []Holiday, before []int) {
+	count := 0
+	for d := BeginningOfDay(t).AddDate(0, 0, 1); count < n; d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			count++
+			continue
+		}
+		if c.IsWeekend(d) {
+			continue
+		}
+		if h, ok := c.holidayOn(d); ok {
+			hs = append(hs, h)
+			before = append(before, count)
+		}
+	}
+	return hs, before
+}
+
+// NextBusinessDays は t の翌日以降の営業日を n 日分返す
+func (c *Calendar) NextBusinessDays(t time.Time, n int) []time.Time {
+	days := make([]time.Time, 0, n)
+	for d := BeginningOfDay(t).AddDate(0, 0, 1); len(days) < n; d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
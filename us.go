@@ -0,0 +1,91 @@
+package bizday
+
+import "time"
+
+// usFederalHolidays は year 年の米国連邦祝日を返す
+// 土曜の祝日は前の金曜、日曜の祝日は翌月曜に振り替えた日付 (observed) で返す
+// 1/1 が土曜の場合、振替日は前年の 12/31 になるので、前後の年の祝日も合わせて振り替える
+func usFederalHolidays(year int) []Holiday {
+	var hs []Holiday
+	for y := year - 1; y <= year+1; y++ {
+		hs = append(hs, usFederalDays(y)...)
+	}
+	return observeYear(hs, USObservance, year)
+}
+
+// usFederalDays は year 年の米国連邦祝日を振り替える前の日付で返す
+func usFederalDays(year int) []Holiday {
+	var hs []Holiday
+	add := func(d time.Time, name string) {
+		hs = append(hs, Holiday{Date: d, Name: name})
+	}
+
+	add(date(year, time.January, 1), "New Year's Day")
+	if year >= 1986 {
+		add(nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day")
+	}
+	add(nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday")
+	add(nthWeekday(year, time.May, time.Monday, -1), "Memorial Day")
+	if year >= 2021 {
+		add(date(year, time.June, 19), "Juneteenth National Independence Day")
+	}
+	add(date(year, time.July, 4), "Independence Day")
+	add(nthWeekday(year, time.September, time.Monday, 1), "Labor Day")
+	add(nthWeekday(year, time.October, time.Monday, 2), "Columbus Day")
+	add(date(year, time.November, 11), "Veterans Day")
+	add(nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day")
+	add(date(year, time.December, 25), "Christmas Day")
+	return hs
+}
+
+// usStateDays は州のカレンダー (us-ma など) で連邦祝日に加えて休む、州の祝日を振り替える前の日付で返す規則 (キーは州の略称の小文字)
+// 州のカレンダーは連邦祝日に州の祝日を足したもので、州が休まない連邦祝日 (Columbus Day など) は除かない
+var usStateDays = map[string]func(year int) []Holiday{
+	"ca": func(year int) []Holiday {
+		var hs []Holiday
+		if year >= 2001 {
+			hs = append(hs, Holiday{Date: date(year, time.March, 31), Name: "Cesar Chavez Day"})
+		}
+		return append(hs, usDayAfterThanksgiving(year))
+	},
+	"hi": func(year int) []Holiday {
+		hs := []Holiday{
+			{Date: date(year, time.March, 26), Name: "Prince Jonah Kuhio Kalanianaole Day"},
+			{Date: date(year, time.June, 11), Name: "King Kamehameha I Day"},
+		}
+		if year >= 1959 {
+			hs = append(hs, Holiday{Date: nthWeekday(year, time.August, time.Friday, 3), Name: "Statehood Day"})
+		}
+		return hs
+	},
+	"ma": usPatriotsDay,
+	"me": usPatriotsDay,
+	"tx": func(year int) []Holiday { return []Holiday{usDayAfterThanksgiving(year)} },
+}
+
+// usPatriotsDay は 4 月の第 3 月曜の Patriots' Day (マサチューセッツ州とメイン州、1969 年から)
+func usPatriotsDay(year int) []Holiday {
+	if year < 1969 {
+		return nil
+	}
+	return []Holiday{{Date: nthWeekday(year, time.April, time.Monday, 3), Name: "Patriots' Day"}}
+}
+
+// usDayAfterThanksgiving は感謝祭の翌日の金曜
+func usDayAfterThanksgiving(year int) Holiday {
+	return Holiday{Date: nthWeekday(year, time.November, time.Thursday, 4).AddDate(0, 0, 1), Name: "Day after Thanksgiving"}
+}
+
+// usStateHolidays は州 state の祝日 (連邦祝日と州の祝日) を返す規則
+// 州の祝日も連邦祝日と同じく、土曜なら前の金曜、日曜なら翌月曜に振り替える
+func usStateHolidays(state string) func(year int) []Holiday {
+	stateDays := usStateDays[state]
+	return func(year int) []Holiday {
+		var hs []Holiday
+		for y := year - 1; y <= year+1; y++ {
+			hs = append(hs, usFederalDays(y)...)
+			hs = append(hs, stateDays(y)...)
+		}
+		return observeYear(hs, USObservance, year)
+	}
+}
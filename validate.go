@@ -0,0 +1,165 @@
+package bizday
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HolidayProblem は祝日データの検証で見つかった問題 1 件
+type HolidayProblem struct {
+	Line    int    // YAML の行番号 (年の抜けのように行に結び付かない問題は 0)
+	Section string // 問題のあった一覧 (holidays, overrides.2025, workdays, calendars.us.holidays など)
+	Message string
+	// Warning は誤りとは限らない問題 (土日の祝日、データのない年) か
+	Warning bool
+}
+
+// String は問題を「行番号: 一覧: 内容」の形にする
+func (p HolidayProblem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.Section, p.Message)
+	}
+	return fmt.Sprintf("%d: %s: %s", p.Line, p.Section, p.Message)
+}
+
+// holidayListNodes は検証のために行番号を残して読み込んだ HolidayList
+type holidayListNodes struct {
+	Holidays  []yaml.Node                 `yaml:"holidays"`
+	Overrides map[int][]yaml.Node         `yaml:"overrides"`
+	Workdays  []yaml.Node                 `yaml:"workdays"`
+	Calendars map[string]holidayListNodes `yaml:"calendars"`
+}
+
+// ValidateHolidays は holidays.yaml の形式のデータを検証し、見つかった問題を行番号順に返す
+// 次のものを誤りとする
+//   - 日付・有効期間の書式の誤り、valid_from が valid_to より後のもの
+//   - 有効期間の重なる同じ日付の重複
+//   - 一覧の中で前の日付より前にある日付 (年をコピーしたまま書き換え忘れたものなど)
+//   - overrides の年と日付の年の食い違い
+//
+// 土日の祝日と、最初の年から最後の年までの間でデータのない年は警告にする
+// YAML として読めないデータはエラーを返す
+func ValidateHolidays(data []byte) ([]HolidayProblem, error) {
+	var l holidayListNodes
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	problems := l.validate("")
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems, nil
+}
+
+// validate は一覧ごとの検証結果を返す (prefix は calendars の中の一覧なら "calendars.us." のようなもの)
+func (l holidayListNodes) validate(prefix string) []HolidayProblem {
+	years := map[int]bool{}
+	problems := validateSection(prefix+"holidays", l.Holidays, true, 0, years)
+	overrideYears := make([]int, 0, len(l.Overrides))
+	for y := range l.Overrides {
+		overrideYears = append(overrideYears, y)
+	}
+	sort.Ints(overrideYears)
+	for _, y := range overrideYears {
+		years[y] = true
+		problems = append(problems, validateSection(prefix+"overrides."+strconv.Itoa(y), l.Overrides[y], true, y, years)...)
+	}
+	problems = append(problems, validateSection(prefix+"workdays", l.Workdays, false, 0, nil)...)
+
+	if len(years) > 0 {
+		first, last := 0, 0
+		for y := range years {
+			if first == 0 || y < first {
+				first = y
+			}
+			if y > last {
+				last = y
+			}
+		}
+		for y := first; y <= last; y++ {
+			if !years[y] {
+				problems = append(problems, HolidayProblem{Section: prefix + "holidays",
+					Message: fmt.Sprintf("%d 年の祝日がありません (%d~%d 年のうち)", y, first, last), Warning: true})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(l.Calendars))
+	for name := range l.Calendars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		problems = append(problems, l.Calendars[name].validate(prefix+"calendars."+name+".")...)
+	}
+	return problems
+}
+
+// validateSection は一覧 1 つ分を検証する
+// holidays が真なら土日の日付を警告する。year が 0 でなければ overrides の年として日付の年と比べる
+// years には一覧に含まれる年を記録する (nil なら記録しない)
+func validateSection(section string, nodes []yaml.Node, holidays bool, year int, years map[int]bool) []HolidayProblem {
+	var problems []HolidayProblem
+	add := func(n *yaml.Node, warning bool, format string, args ...any) {
+		problems = append(problems, HolidayProblem{Line: n.Line, Section: section, Message: fmt.Sprintf(format, args...), Warning: warning})
+	}
+	seen := map[int32][]HolidayEntry{}
+	var prev time.Time
+	for i := range nodes {
+		n := &nodes[i]
+		var h HolidayYAML
+		if err := n.Decode(&h); err != nil {
+			add(n, false, "祝日の定義を読み込めません: %v", err)
+			continue
+		}
+		e, err := h.Entry()
+		if err != nil {
+			add(n, false, "%v", err)
+			continue
+		}
+		if !e.ValidFrom.IsZero() && !e.ValidTo.IsZero() && e.ValidFrom.After(e.ValidTo) {
+			add(n, false, "valid_from %s が valid_to %s より後です", h.ValidFrom, h.ValidTo)
+		}
+		if year != 0 && e.Date.Year() != year {
+			add(n, false, "%d 年の差し替えに別の年の日付があります: %s", year, h.Date)
+		}
+		if !prev.IsZero() && e.Date.Before(prev) {
+			add(n, false, "%s が前の日付 %s より前にあります", h.Date, prev.Format("2006-01-02"))
+		}
+		if e.Date.After(prev) {
+			prev = e.Date
+		}
+		k := epochDay(e.Date)
+		for _, o := range seen[k] {
+			if validityOverlaps(o, e) {
+				add(n, false, "%s が重複しています", h.Date)
+				break
+			}
+		}
+		seen[k] = append(seen[k], e)
+		if holidays && isWeekend(e.Date) {
+			day := "日曜日"
+			if e.Date.Weekday() == time.Saturday {
+				day = "土曜日"
+			}
+			add(n, true, "%s (%s) は%sです", h.Date, e.Name, day)
+		}
+		if years != nil {
+			years[e.Date.Year()] = true
+		}
+	}
+	return problems
+}
+
+// validityOverlaps は a と b の有効期間が重なるかを判定する (ゼロ値は期限なし)
+func validityOverlaps(a, b HolidayEntry) bool {
+	if !a.ValidTo.IsZero() && !b.ValidFrom.IsZero() && a.ValidTo.Before(b.ValidFrom) {
+		return false
+	}
+	if !b.ValidTo.IsZero() && !a.ValidFrom.IsZero() && b.ValidTo.Before(a.ValidFrom) {
+		return false
+	}
+	return true
+}
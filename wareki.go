@@ -0,0 +1,73 @@
+package bizday
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// era は和暦の元号とその開始日
+type era struct {
+	Name  string
+	Abbr  string
+	Start time.Time
+}
+
+// eras は対応する元号 (新しい順)
+var eras = []era{
+	{"令和", "R", time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)},
+	{"平成", "H", time.Date(1989, 1, 8, 0, 0, 0, 0, time.UTC)},
+	{"昭和", "S", time.Date(1926, 12, 25, 0, 0, 0, 0, time.UTC)},
+	{"大正", "T", time.Date(1912, 7, 30, 0, 0, 0, 0, time.UTC)},
+	{"明治", "M", time.Date(1868, 1, 25, 0, 0, 0, 0, time.UTC)},
+}
+
+// warekiPattern は "令和7年4月1日"・"R7.4.1"・"令和元年5月1日 10:00" などにマッチする
+var warekiPattern = regexp.MustCompile(
+	`^(明治|大正|昭和|平成|令和|[MTSHRmtshr])\s*(元|\d+)\s*[年./-]\s*(\d+)\s*[月./-]\s*(\d+)\s*日?` +
+		`(?:[\sT]+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`)
+
+// fullWidthDigits は全角数字・記号を半角に直す
+var fullWidthDigits = strings.NewReplacer(
+	"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+	"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+	"．", ".", "：", ":", "　", " ",
+)
+
+// ParseWareki は和暦表記の日付 (時刻は任意) を loc のタイムゾーンでパースする
+// 和暦表記でなければ ok は false
+func ParseWareki(s string, loc *time.Location) (t time.Time, ok bool, err error) {
+	m := warekiPattern.FindStringSubmatch(strings.TrimSpace(fullWidthDigits.Replace(s)))
+	if m == nil {
+		return time.Time{}, false, nil
+	}
+
+	var e *era
+	for i := range eras {
+		if m[1] == eras[i].Name || strings.EqualFold(m[1], eras[i].Abbr) {
+			e = &eras[i]
+			break
+		}
+	}
+
+	year := 1
+	if m[2] != "元" {
+		year, _ = strconv.Atoi(m[2])
+	}
+	month, _ := strconv.Atoi(m[3])
+	day, _ := strconv.Atoi(m[4])
+	hour, _ := strconv.Atoi(m[5])
+	minute, _ := strconv.Atoi(m[6])
+	sec, _ := strconv.Atoi(m[7])
+
+	t = time.Date(e.Start.Year()+year-1, time.Month(month), day, hour, minute, sec, 0, loc)
+	if t.Month() != time.Month(month) || t.Day() != day || hour > 23 || minute > 59 || sec > 59 {
+		return time.Time{}, true, fmt.Errorf("存在しない日時です: %s", s)
+	}
+	if y, mo, d := t.Date(); time.Date(y, mo, d, 0, 0, 0, 0, time.UTC).Before(e.Start) {
+		return time.Time{}, true, fmt.Errorf("%s は %s より前の日付です: %s", e.Name, e.Start.Format("2006-01-02"), s)
+	}
+	return t, true, nil
+}
@@ -0,0 +1,36 @@
+package bizday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWareki(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"令和7年4月1日", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"R7.4.1", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"令和元年5月1日 10:00", time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{"平成31年4月30日", time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{"令和７年４月１日", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok, err := ParseWareki(tt.in, time.UTC)
+		if !ok || err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseWareki(%q) = %v, %v, %v, want %v", tt.in, got, ok, err, tt.want)
+		}
+	}
+}
+
+func TestParseWarekiInvalid(t *testing.T) {
+	if _, ok, _ := ParseWareki("2025-04-01", time.UTC); ok {
+		t.Error("西暦を和暦として扱っている")
+	}
+	for _, in := range []string{"令和元年4月30日", "令和7年2月30日"} {
+		if _, ok, err := ParseWareki(in, time.UTC); !ok || err == nil {
+			t.Errorf("ParseWareki(%q) がエラーにならない", in)
+		}
+	}
+}
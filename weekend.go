@@ -0,0 +1,90 @@
+package bizday
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekendPresets は名前付きの定休日 (週末) の組み合わせ
+var weekendPresets = map[string][]time.Weekday{
+	"sat-sun":  {time.Saturday, time.Sunday},
+	"fri-sat":  {time.Friday, time.Saturday},
+	"thu-fri":  {time.Thursday, time.Friday},
+	"sun-only": {time.Sunday},
+	"fri-only": {time.Friday},
+	"sat-only": {time.Saturday},
+}
+
+// countryWeekends は週末が土日でない国の組み込みカレンダー (祝日データなし、定休日のみ)
+var countryWeekends = map[string]string{
+	"sa": "fri-sat",  // サウジアラビア
+	"il": "fri-sat",  // イスラエル
+	"eg": "fri-sat",  // エジプト
+	"qa": "fri-sat",  // カタール
+	"kw": "fri-sat",  // クウェート
+	"ir": "fri-only", // イラン
+	"ae": "sat-sun",  // アラブ首長国連邦 (2022 年から土日)
+}
+
+// LookupWeekend は名前から定休日の組み合わせを返す
+func LookupWeekend(name string) ([]time.Weekday, error) {
+	w, ok := weekendPresets[name]
+	if !ok {
+		return nil, fmt.Errorf("未知の定休日プリセット: %s (sat-sun, fri-sat, thu-fri, sun-only, fri-only, sat-only)", name)
+	}
+	return w, nil
+}
+
+// ParseWeekend はプリセット名 ("fri-sat")、またはカンマ区切りの曜日の略称 ("sun,mon") から定休日を返す
+// "none" は定休日なし (毎日営業) を表す。7 曜日すべてを定休日にすると営業日がなくなるのでエラーにする
+func ParseWeekend(s string) ([]time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" {
+		return []time.Weekday{}, nil
+	}
+	if w, ok := weekendPresets[s]; ok {
+		return w, nil
+	}
+	w, err := ParseWeekdays(strings.Split(s, ","))
+	if err != nil {
+		return nil, fmt.Errorf("定休日の指定が不正です: %s (sat-sun のようなプリセットか、sun,mon のような曜日の並び)", s)
+	}
+	if len(w) == 7 {
+		return nil, fmt.Errorf("7 曜日すべてを定休日にはできません: %s", s)
+	}
+	return w, nil
+}
+
+// ParseWeekdays は曜日の略称 (sun, mon, ...) の並びを曜日のスライスにする (重複は 1 つにまとめる)
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	days := []time.Weekday{}
+	seen := map[time.Weekday]bool{}
+	for _, name := range names {
+		w, err := parseWeekdayName(strings.ToLower(strings.TrimSpace(name)))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[w] {
+			seen[w] = true
+			days = append(days, w)
+		}
+	}
+	return days, nil
+}
+
+// WithWeekend は定休日を days に差し替えた Calendar を返す (days を省略すると定休日なし)
+// 例: 火曜~土曜の勤務なら cal.WithWeekend(time.Sunday, time.Monday)
+// 組み合わせたカレンダー (NewCombinedCalendar) では元のカレンダーの定休日もすべて days に差し替える
+func (c *Calendar) WithWeekend(days ...time.Weekday) *Calendar {
+	n := *c
+	n.bitmaps = nil
+	n.Weekend = append([]time.Weekday{}, days...)
+	if c.members != nil {
+		n.members = make([]*Calendar, len(c.members))
+		for i, m := range c.members {
+			n.members[i] = m.WithWeekend(days...)
+		}
+	}
+	return &n
+}
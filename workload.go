@@ -0,0 +1,111 @@
+package bizday
+
+import "time"
+
+// TimeOff は個人の休暇 1 日分
+type TimeOff struct {
+	Date time.Time
+	Half bool // 半休 (その日の想定稼働時間の半分だけ休む)
+}
+
+// Meeting は稼働時間を使う予定 (会議など)
+// Date があればその日だけ、なければ Weekdays の曜日の営業日に毎週入る
+type Meeting struct {
+	Name     string
+	Date     time.Time
+	Weekdays []time.Weekday
+	Duration time.Duration
+}
+
+// on は会議が t の日付にあるかを判定
+func (m Meeting) on(t time.Time) bool {
+	if !m.Date.IsZero() {
+		return epochDay(m.Date) == epochDay(t)
+	}
+	for _, w := range m.Weekdays {
+		if t.Weekday() == w {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkloadOptions は EstimateWorkload の見積もりの条件
+type WorkloadOptions struct {
+	Hours DayHours // 営業日 1 日あたりの想定稼働時間
+	// HalfDays は半日だけ営業する日 (年末の短縮営業など)、その日の想定稼働時間を半分にする
+	HalfDays []time.Time
+	TimeOff  []TimeOff
+	Meetings []Meeting
+}
+
+// Workload は期間の稼働可能時間の見積もり
+// Available = Planned - HalfDayHours - TimeOffHours - MeetingHours
+type Workload struct {
+	Start, End   time.Time
+	BusinessDays int           // 期間の営業日数
+	Planned      time.Duration // 営業日数 × 想定稼働時間 (曜日ごとの設定を含む)
+	HalfDays     int           // 期間の半日営業の日数
+	HalfDayHours time.Duration // 半日営業で減る時間
+	TimeOffDays  float64       // 営業日に当たる休暇の日数 (半休は 0.5 日)
+	TimeOffHours time.Duration // 休暇で減る時間
+	Meetings     int           // 稼働時間を使った会議の件数
+	MeetingHours time.Duration // 会議で使う時間 (その日の残りの稼働時間を超える分は数えない)
+	Available    time.Duration // 残りの稼働可能時間
+}
+
+// EstimateWorkload は start~end (両端含む) の稼働可能時間を見積もる
+// 営業日ごとに想定稼働時間から半日営業・休暇・会議の順に差し引くので、休暇の日の会議は数えず、
+// 1 日で差し引く時間はその日の想定稼働時間を超えない。営業日でない日の休暇・会議は無視する
+func (c *Calendar) EstimateWorkload(start, end time.Time, opt WorkloadOptions) Workload {
+	w := Workload{Start: BeginningOfDay(start), End: BeginningOfDay(end)}
+	half := make(map[int32]bool, len(opt.HalfDays))
+	for _, d := range opt.HalfDays {
+		half[epochDay(d)] = true
+	}
+	// 同じ日に全休と半休があれば全休とする
+	off := make(map[int32]bool, len(opt.TimeOff))
+	for _, t := range opt.TimeOff {
+		k := epochDay(t.Date)
+		off[k] = off[k] || !t.Half
+	}
+
+	for d := w.Start; !d.After(w.End); d = d.AddDate(0, 0, 1) {
+		if !c.IsBusinessDay(d) {
+			continue
+		}
+		w.BusinessDays++
+		day := opt.Hours.On(d)
+		w.Planned += day
+		left := day
+		if half[epochDay(d)] {
+			w.HalfDays++
+			w.HalfDayHours += left / 2
+			left -= left / 2
+		}
+		if full, ok := off[epochDay(d)]; ok {
+			cut := left
+			w.TimeOffDays++
+			if !full {
+				cut = left / 2
+				w.TimeOffDays -= 0.5
+			}
+			w.TimeOffHours += cut
+			left -= cut
+		}
+		for _, m := range opt.Meetings {
+			if left <= 0 {
+				break
+			}
+			if !m.on(d) {
+				continue
+			}
+			w.Meetings++
+			used := min(m.Duration, left)
+			w.MeetingHours += used
+			left -= used
+		}
+		w.Available += left
+	}
+	return w
+}
@@ -0,0 +1,53 @@
+package bizday
+
+import "time"
+
+// ZonedCalendar はカレンダーとそれを評価するタイムゾーンの組
+type ZonedCalendar struct {
+	Name     string
+	Location *time.Location
+	Calendar *Calendar
+}
+
+// OverlapSearchDays は重なる営業時間を探す最大日数
+const OverlapSearchDays = 366
+
+// NextOverlap は from 以降で a と b の営業時間が重なる最初の日を a の日付で探す
+// day は a のロケーションでのその日の 0:00、start/end は重なる時間帯
+func NextOverlap(a, b ZonedCalendar, from time.Time) (day, start, end time.Time, ok bool) {
+	base := BeginningOfDay(from)
+	for i := 0; i < OverlapSearchDays; i++ {
+		d := base.AddDate(0, 0, i)
+		aStart, aEnd, open := a.Calendar.BusinessHours(d)
+		if !open {
+			continue
+		}
+		// a の営業時間帯に掛かる可能性のある b 側の日付 (前日~翌日) を調べる
+		bDay := BeginningOfDay(aStart.In(b.Location))
+		for j := -1; j <= 1; j++ {
+			bStart, bEnd, open := b.Calendar.BusinessHours(bDay.AddDate(0, 0, j))
+			if !open {
+				continue
+			}
+			s, e := later(aStart, bStart), earlier(aEnd, bEnd)
+			if s.Before(e) && e.After(from) {
+				return d, s.In(a.Location), e.In(a.Location), true
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, time.Time{}, false
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}